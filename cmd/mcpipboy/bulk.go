@@ -0,0 +1,223 @@
+// Package main provides bulk validation for the validating commands of mcpipboy
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kluzzebass/mcpipboy/internal/output"
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// Policies for --fail-on, deciding when a bulk validation exits with exitInvalid
+const (
+	failOnNever = "never"
+	failOnAny   = "any"
+	failOnAll   = "all"
+)
+
+// exitInvalid is the exit status of a bulk validation that fails its --fail-on
+// policy, distinguishing invalid values from errors such as an unreadable file
+const exitInvalid = 2
+
+// failOnPolicies returns the accepted --fail-on values
+func failOnPolicies() []string {
+	return []string{failOnNever, failOnAny, failOnAll}
+}
+
+// exitError is returned by commands that must exit with a specific status
+type exitError struct {
+	code    int
+	message string
+}
+
+// Error implements the error interface
+func (e *exitError) Error() string {
+	return e.message
+}
+
+// addBulkFlags adds the --input-file and --fail-on flags of bulk validation to
+// a validating command
+func addBulkFlags(cmd *cobra.Command, inputFile, failOn *string) {
+	cmd.Flags().StringVar(inputFile, "input-file", "", "File with values to validate, one per line, or - for stdin")
+	cmd.Flags().StringVar(failOn, "fail-on", failOnNever, "Exit with status 2 when any or all values are invalid: "+strings.Join(failOnPolicies(), ", "))
+	cmd.MarkFlagsMutuallyExclusive("input", "input-file")
+	cmd.RegisterFlagCompletionFunc("fail-on", cobra.FixedCompletions(failOnPolicies(), cobra.ShellCompDirectiveNoFileComp))
+}
+
+// runBulkValidation validates the values in a file, one per line, with the
+// tool's validate operation. Blank lines are skipped but still counted, so
+// results refer to the line numbers of the file. Results are written as they
+// are produced in the text, ndjson and csv formats, and as a single document
+// in the json and yaml formats, followed by a summary. The operation defaults
+// to validate, whatever the tool's own default.
+func runBulkValidation(cmd *cobra.Command, tool tools.Tool, params map[string]interface{}, path, failOn string, out io.Writer) error {
+	if _, ok := params["operation"]; !ok {
+		params["operation"] = "validate"
+	}
+	if operation, _ := params["operation"].(string); operation != "validate" {
+		return fmt.Errorf("--input-file requires the validate operation")
+	}
+	if !slices.Contains(failOnPolicies(), failOn) {
+		return fmt.Errorf("invalid --fail-on policy: %s. Available policies: %s", failOn, strings.Join(failOnPolicies(), ", "))
+	}
+
+	var in io.Reader
+	if path == "-" {
+		in = os.Stdin
+		if cmd != nil {
+			in = cmd.InOrStdin()
+		}
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer file.Close()
+		in = file
+	}
+
+	writer := newBulkWriter(out, outputFormat)
+	summary := tools.NewBulkSummary()
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		value := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			value = strings.TrimPrefix(value, "\ufeff")
+		}
+		if value == "" {
+			continue
+		}
+
+		result := tools.ValidateLine(tool, params, line, value)
+		summary.Add(result)
+		if err := writer.writeResult(result); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+	if err := writer.writeSummary(summary); err != nil {
+		return err
+	}
+
+	return checkFailOn(cmd, summary, failOn)
+}
+
+// checkFailOn applies the --fail-on policy to the outcome of a bulk validation
+func checkFailOn(cmd *cobra.Command, summary *tools.BulkSummary, failOn string) error {
+	failed := (failOn == failOnAny && summary.Invalid > 0) ||
+		(failOn == failOnAll && summary.Total > 0 && summary.Invalid == summary.Total)
+	if !failed {
+		return nil
+	}
+
+	// The summary has been printed, so the usage would only add noise
+	if cmd != nil {
+		cmd.SilenceUsage = true
+	}
+	return &exitError{
+		code:    exitInvalid,
+		message: fmt.Sprintf("%d of %d values are invalid", summary.Invalid, summary.Total),
+	}
+}
+
+// bulkWriter writes the results of a bulk validation in an output format
+type bulkWriter struct {
+	out     io.Writer
+	format  string
+	csv     *csv.Writer
+	results []map[string]interface{}
+}
+
+// bulkColumns are the CSV columns of bulk validation results
+var bulkColumns = []string{"line", "input", "valid", "error"}
+
+// newBulkWriter creates a writer for bulk validation results
+func newBulkWriter(out io.Writer, format string) *bulkWriter {
+	return &bulkWriter{out: out, format: format}
+}
+
+// writeResult writes the result for a single line, or keeps it for formats
+// that write a single document
+func (w *bulkWriter) writeResult(result map[string]interface{}) error {
+	switch w.format {
+	case output.FormatJSON, output.FormatYAML:
+		w.results = append(w.results, result)
+		return nil
+	case output.FormatNDJSON:
+		return output.Write(w.out, output.FormatNDJSON, result, nil)
+	case output.FormatCSV:
+		if w.csv == nil {
+			w.csv = csv.NewWriter(w.out)
+			w.csv.Write(bulkColumns)
+		}
+		valid, _ := result["valid"].(bool)
+		input, _ := result["input"].(string)
+		message, _ := result["error"].(string)
+		w.csv.Write([]string{strconv.Itoa(result["line"].(int)), input, strconv.FormatBool(valid), message})
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+
+	input, _ := result["input"].(string)
+	if valid, _ := result["valid"].(bool); valid {
+		_, err := fmt.Fprintf(w.out, "line %d: valid: %s\n", result["line"], input)
+		return err
+	}
+	_, err := fmt.Fprintf(w.out, "line %d: invalid: %s: %s\n", result["line"], input, result["error"])
+	return err
+}
+
+// writeSummary finishes the output with the summary. CSV output holds results
+// only, so the summary is left out there.
+func (w *bulkWriter) writeSummary(summary *tools.BulkSummary) error {
+	switch w.format {
+	case output.FormatJSON, output.FormatYAML:
+		results := w.results
+		if results == nil {
+			results = []map[string]interface{}{}
+		}
+		return output.Write(w.out, w.format, map[string]interface{}{"results": results, "summary": summary}, nil)
+	case output.FormatNDJSON:
+		return output.Write(w.out, output.FormatNDJSON, map[string]interface{}{"summary": summary}, nil)
+	case output.FormatCSV:
+		if w.csv == nil {
+			w.csv = csv.NewWriter(w.out)
+			w.csv.Write(bulkColumns)
+			w.csv.Flush()
+		}
+		return w.csv.Error()
+	}
+
+	fmt.Fprintf(w.out, "\nTotal: %d, valid: %d, invalid: %d\n", summary.Total, summary.Valid, summary.Invalid)
+	for _, message := range errorsByCount(summary.Errors) {
+		fmt.Fprintf(w.out, "  %6d  %s\n", summary.Errors[message], message)
+	}
+	return nil
+}
+
+// errorsByCount returns the error messages of a summary, most frequent first
+func errorsByCount(counts map[string]int) []string {
+	messages := make([]string, 0, len(counts))
+	for message := range counts {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if counts[messages[i]] != counts[messages[j]] {
+			return counts[messages[i]] > counts[messages[j]]
+		}
+		return messages[i] < messages[j]
+	})
+	return messages
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+func TestRunBulkValidation(t *testing.T) {
+	input := "GB82WEST12345698765432\n\nGB82WEST12345698765433\nshort\n"
+
+	tests := []struct {
+		name      string
+		format    string
+		failOn    string
+		operation string
+		exitCode  int
+		wantErr   string
+		check     func(t *testing.T, output string)
+	}{
+		{
+			name:   "text",
+			format: "text",
+			failOn: "never",
+			check: func(t *testing.T, output string) {
+				for _, want := range []string{
+					"line 1: valid: GB82WEST12345698765432\n",
+					"line 3: invalid: GB82WEST12345698765433: invalid check digits\n",
+					"line 4: invalid: short: IBAN must be between 15 and 34 characters\n",
+					"Total: 3, valid: 1, invalid: 2\n",
+				} {
+					if !strings.Contains(output, want) {
+						t.Errorf("Expected output to contain %q, got %q", want, output)
+					}
+				}
+			},
+		},
+		{
+			name:     "ndjson fails on any invalid value",
+			format:   "ndjson",
+			failOn:   "any",
+			exitCode: exitInvalid,
+			check: func(t *testing.T, output string) {
+				lines := strings.Split(strings.TrimSpace(output), "\n")
+				if len(lines) != 4 {
+					t.Fatalf("Expected 3 results and a summary, got %q", output)
+				}
+				var last struct {
+					Summary tools.BulkSummary `json:"summary"`
+				}
+				if err := json.Unmarshal([]byte(lines[3]), &last); err != nil {
+					t.Fatalf("Expected a summary line: %v", err)
+				}
+				if last.Summary.Total != 3 || last.Summary.Invalid != 2 {
+					t.Errorf("Unexpected summary %+v", last.Summary)
+				}
+			},
+		},
+		{
+			name:   "json passes when not all values are invalid",
+			format: "json",
+			failOn: "all",
+			check: func(t *testing.T, output string) {
+				var result struct {
+					Results []map[string]interface{} `json:"results"`
+					Summary tools.BulkSummary        `json:"summary"`
+				}
+				if err := json.Unmarshal([]byte(output), &result); err != nil {
+					t.Fatalf("Expected a JSON document: %v", err)
+				}
+				if len(result.Results) != 3 || result.Results[2]["line"] != 4.0 {
+					t.Errorf("Unexpected results %v", result.Results)
+				}
+				if result.Summary.Valid != 1 {
+					t.Errorf("Unexpected summary %+v", result.Summary)
+				}
+			},
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			failOn: "never",
+			check: func(t *testing.T, output string) {
+				if !strings.HasPrefix(output, "line,input,valid,error\n1,GB82WEST12345698765432,true,\n3,") {
+					t.Errorf("Unexpected CSV output %q", output)
+				}
+			},
+		},
+		{
+			name:      "generate",
+			format:    "text",
+			failOn:    "never",
+			operation: "generate",
+			wantErr:   "--input-file requires the validate operation",
+		},
+		{
+			name:    "invalid policy",
+			format:  "text",
+			failOn:  "sometimes",
+			wantErr: "invalid --fail-on policy: sometimes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFormat = tt.format
+			t.Cleanup(func() { outputFormat = "text" })
+
+			operation := tt.operation
+			if operation == "" {
+				operation = "validate"
+			}
+
+			cmd := &cobra.Command{}
+			cmd.SetIn(strings.NewReader(input))
+			var buf bytes.Buffer
+			err := runBulkValidation(cmd, tools.NewIBANTool(), map[string]interface{}{"operation": operation}, "-", tt.failOn, &buf)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			var exitErr *exitError
+			if tt.exitCode != 0 {
+				if !errors.As(err, &exitErr) || exitErr.code != tt.exitCode {
+					t.Fatalf("Expected exit code %d, got %v", tt.exitCode, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tt.check(t, buf.String())
+		})
+	}
+}
+
+func TestBulkValidationCommands(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name  string
+		run   func(t *testing.T, path string, out *bytes.Buffer) error
+		input string
+		total string
+	}{
+		{
+			name: "imo",
+			run: func(t *testing.T, path string, out *bytes.Buffer) error {
+				imoInputFile, imoFailOn = path, "never"
+				defer func() { imoInputFile = "" }()
+				return runIMO(parseToolFlags(t, tools.NewIMOTool()), nil, out)
+			},
+			input: "9074729\n9074728\n",
+			total: "Total: 2, valid: 1, invalid: 1",
+		},
+		{
+			name: "mmsi",
+			run: func(t *testing.T, path string, out *bytes.Buffer) error {
+				mmsiInputFile, mmsiFailOn = path, "never"
+				defer func() { mmsiInputFile = "" }()
+				return runMMSI(parseToolFlags(t, tools.NewMMSITool()), nil, out)
+			},
+			input: "257123450\n",
+			total: "Total: 1, valid: 1, invalid: 0",
+		},
+		{
+			name: "creditcard",
+			run: func(t *testing.T, path string, out *bytes.Buffer) error {
+				creditCardInputFile, creditCardFailOn = path, "never"
+				defer func() { creditCardInputFile = "" }()
+				return runCreditCard(parseToolFlags(t, tools.NewCreditCardTool()), nil, out)
+			},
+			input: "4111111111111111\n4111111111111112\n",
+			total: "Total: 2, valid: 1, invalid: 1",
+		},
+		{
+			name: "isbn",
+			run: func(t *testing.T, path string, out *bytes.Buffer) error {
+				isbnInputFile, isbnFailOn = path, "never"
+				defer func() { isbnInputFile = "" }()
+				return runISBN(parseToolFlags(t, tools.NewISBNTool()), nil, out)
+			},
+			input: "9780306406157\r\n0306406152\r\n",
+			total: "Total: 2, valid: 2, invalid: 0",
+		},
+		{
+			name: "ean13",
+			run: func(t *testing.T, path string, out *bytes.Buffer) error {
+				ean13InputFile, ean13FailOn = path, "never"
+				defer func() { ean13InputFile = "" }()
+				return runEAN13(parseToolFlags(t, tools.NewEAN13Tool()), nil, out)
+			},
+			input: "\ufeff4006381333931\n",
+			total: "Total: 1, valid: 1, invalid: 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.run(t, write(tt.name+".txt", tt.input), &buf); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.total) {
+				t.Errorf("Expected %q in output, got %q", tt.total, buf.String())
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		ibanInputFile, ibanFailOn = filepath.Join(dir, "missing.txt"), "never"
+		defer func() { ibanInputFile = "" }()
+		err := runIBAN(parseToolFlags(t, tools.NewIBANTool()), nil, &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "failed to open input file") {
+			t.Errorf("Expected an open error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+// Package main provides the call command for mcpipboy
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kluzzebass/mcpipboy/internal/output"
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// callCmd represents the call command
+var callCmd = &cobra.Command{
+	Use:   "call <tool> [key=value...]",
+	Short: "Call any tool with parameters from its input schema",
+	Long: `Call a tool the same way an MCP client does. Every tool has a subcommand whose flags
+are generated from the tool's input schema, so the CLI offers exactly the parameters
+the MCP server publishes.
+
+Parameters can be given as flags, as key=value arguments or as a JSON object with
+--params. When a parameter is given more than once, flags take precedence over
+key=value arguments, which take precedence over --params. Values of key=value
+arguments are converted to the type declared in the schema; arrays are
+comma-separated and objects are JSON.
+
+The parameters are validated against the input schema before the tool runs, and the
+result is printed in the format selected with --output.`,
+	Example: `  mcpipboy call iban --operation generate --country-code DE --count 3
+  mcpipboy call iban operation=generate country-code=DE
+  mcpipboy call mmsi --params '{"operation": "validate", "input": "257123450"}'
+  mcpipboy call random --type float --min 0 --max 1 --count 5 --output json`,
+}
+
+func init() {
+	callCmd.GroupID = "tools"
+	rootCmd.AddCommand(callCmd)
+
+	registry := getAvailableTools()
+	for _, name := range registry.ListTools() {
+		tool, _ := registry.GetTool(name)
+		callCmd.AddCommand(newCallToolCmd(registry, tool))
+	}
+}
+
+// newCallToolCmd creates the call subcommand for a tool, with a flag for each
+// property of the tool's input schema
+func newCallToolCmd(registry *tools.ToolRegistry, tool tools.Tool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   tool.Name() + " [key=value...]",
+		Short: tool.Description(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCall(cmd, args, registry, tool, os.Stdout)
+		},
+	}
+
+	cmd.Flags().String("params", "", "Parameters as a JSON object")
+	registerSchemaFlags(cmd, tool)
+	return cmd
+}
+
+// registerSchemaFlags adds a flag for each property of the tool's input schema
+func registerSchemaFlags(cmd *cobra.Command, tool tools.Tool) {
+	properties := schemaProperties(tool)
+	for _, name := range sortedKeys(properties) {
+		property := properties[name]
+		description, _ := property["description"].(string)
+		if enum := property["enum"]; enum != nil {
+			description += fmt.Sprintf(" (one of: %s)", strings.Join(toStrings(enum), ", "))
+		}
+
+		switch schemaType(property) {
+		case "boolean":
+			cmd.Flags().Bool(name, false, description)
+		case "integer":
+			cmd.Flags().Int64(name, 0, description)
+		case "number":
+			cmd.Flags().Float64(name, 0, description)
+		case "array":
+			cmd.Flags().StringSlice(name, nil, description+" (comma-separated)")
+		case "object":
+			cmd.Flags().String(name, "", description+" (JSON object)")
+		default:
+			cmd.Flags().String(name, "", description)
+			cmd.RegisterFlagCompletionFunc(name, paramCompletionFunc(tool, name))
+		}
+	}
+}
+
+// runCall collects the parameters from --params, key=value arguments and flags,
+// and executes the tool through the registry
+func runCall(cmd *cobra.Command, args []string, registry *tools.ToolRegistry, tool tools.Tool, out io.Writer) error {
+	params, err := callParams(cmd, args, tool)
+	if err != nil {
+		return err
+	}
+
+	result, err := registry.ExecuteTool(tool.Name(), params)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", tool.Name(), err)
+	}
+
+	return output.Write(out, outputFormat, result, tool.GetOutputSchema())
+}
+
+// callParams builds the tool parameters in the form an MCP client sends them.
+// Only flags set on the command line are passed, so the tool's own defaults
+// apply to everything else.
+func callParams(cmd *cobra.Command, args []string, tool tools.Tool) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+
+	if raw, _ := cmd.Flags().GetString("params"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &params); err != nil {
+			return nil, fmt.Errorf("invalid --params: must be a JSON object: %w", err)
+		}
+		if params == nil {
+			params = make(map[string]interface{})
+		}
+	}
+
+	properties := schemaProperties(tool)
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid argument %q: expected key=value", arg)
+		}
+		converted, err := convertParam(properties[key], value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		params[key] = converted
+	}
+
+	if err := flagParams(cmd.Flags(), properties, params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// toolParams returns the parameters of a tool command: the schema flags set on
+// the command line, so that the tool's own defaults apply to everything else
+func toolParams(cmd *cobra.Command, tool tools.Tool) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	if err := flagParams(cmd.Flags(), schemaProperties(tool), params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// flagParams adds the schema flags set on the command line to the parameters.
+// Flags left at their defaults are not passed, so the tool's own defaults apply.
+func flagParams(flags *pflag.FlagSet, properties map[string]map[string]interface{}, params map[string]interface{}) error {
+	for name, property := range properties {
+		if !flags.Changed(name) {
+			continue
+		}
+		var value interface{}
+		var err error
+		switch schemaType(property) {
+		case "boolean":
+			value, err = flags.GetBool(name)
+		case "integer":
+			value, err = flags.GetInt64(name)
+		case "number":
+			value, err = flags.GetFloat64(name)
+		case "array":
+			var items []string
+			items, err = flags.GetStringSlice(name)
+			if err == nil {
+				value, err = convertItems(property, items)
+			}
+		default:
+			var str string
+			str, err = flags.GetString(name)
+			if err == nil {
+				value, err = convertParam(property, str)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value for --%s: %w", name, err)
+		}
+		params[name] = value
+	}
+	return nil
+}
+
+// convertParam converts a command line value to the type declared by a
+// property schema. Numbers become float64, as they would be when decoded from
+// an MCP request, while integers become int64 so that large seeds keep every
+// digit. Values of undeclared parameters are passed as strings so that schema
+// validation can report them.
+func convertParam(property map[string]interface{}, value string) (interface{}, error) {
+	switch schemaType(property) {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	case "array":
+		if strings.HasPrefix(strings.TrimSpace(value), "[") {
+			return decodeJSON(value)
+		}
+		return convertItems(property, strings.Split(value, ","))
+	case "object":
+		return decodeJSON(value)
+	}
+
+	// Properties accepting several types take JSON arrays and objects as well
+	if types := toStrings(property["type"]); len(types) > 1 {
+		trimmed := strings.TrimSpace(value)
+		if (strings.HasPrefix(trimmed, "[") && slices.Contains(types, "array")) ||
+			(strings.HasPrefix(trimmed, "{") && slices.Contains(types, "object")) {
+			return decodeJSON(value)
+		}
+	}
+	return value, nil
+}
+
+// convertItems converts comma-separated array items to the declared item type
+func convertItems(property map[string]interface{}, items []string) ([]interface{}, error) {
+	itemSchema, _ := property["items"].(map[string]interface{})
+	values := make([]interface{}, len(items))
+	for i, item := range items {
+		value, err := convertParam(itemSchema, strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// decodeJSON decodes a JSON value given on the command line
+func decodeJSON(value string) (interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return decoded, nil
+}
+
+// schemaProperties returns the property schemas of a tool's input schema
+func schemaProperties(tool tools.Tool) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{})
+	properties, _ := tool.GetInputSchema()["properties"].(map[string]interface{})
+	for name, property := range properties {
+		if schema, ok := property.(map[string]interface{}); ok {
+			result[name] = schema
+		}
+	}
+	return result
+}
+
+// schemaType returns the single type declared by a property schema, or "" when
+// the property is untyped or accepts several types
+func schemaType(property map[string]interface{}) string {
+	if typ, ok := property["type"].(string); ok {
+		return typ
+	}
+	return ""
+}
+
+// toStrings converts a schema keyword holding strings ([]string or []interface{}) to a string slice
+func toStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			result = append(result, fmt.Sprint(item))
+		}
+		return result
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of the property map in sorted order
+func sortedKeys(properties map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
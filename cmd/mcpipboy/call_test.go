@@ -0,0 +1,412 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func TestRunCall(t *testing.T) {
+	registry := getAvailableTools()
+
+	tests := []struct {
+		name     string
+		tool     string
+		args     []string
+		contains []string
+		lines    int
+		hasError string
+	}{
+		{
+			name:     "flags",
+			tool:     "iban",
+			args:     []string{"--operation", "generate", "--country-code", "DE", "--count", "3"},
+			contains: []string{"DE"},
+			lines:    3,
+		},
+		{
+			name:     "key value arguments",
+			tool:     "iban",
+			args:     []string{"operation=generate", "country-code=NO"},
+			contains: []string{"NO"},
+			lines:    1,
+		},
+		{
+			name:     "params json",
+			tool:     "mmsi",
+			args:     []string{"--params", `{"operation": "validate", "input": "257123450"}`},
+			contains: []string{"valid: true", "country_name: Norway"},
+		},
+		{
+			name:     "flags override params",
+			tool:     "echo",
+			args:     []string{"--params", `{"message": "from params"}`, "message=from argument", "--message", "from flag"},
+			contains: []string{"from flag"},
+			lines:    1,
+		},
+		{
+			name:     "arguments override params",
+			tool:     "echo",
+			args:     []string{"--params", `{"message": "from params"}`, "message=from argument"},
+			contains: []string{"from argument"},
+			lines:    1,
+		},
+		{
+			name:     "number arguments",
+			tool:     "random",
+			args:     []string{"type=integer", "min=5", "max=5", "count=2"},
+			contains: []string{"5"},
+			lines:    2,
+		},
+		{
+			name:     "schema validation",
+			tool:     "iban",
+			args:     []string{"--count", "500"},
+			hasError: "count: must be at most 100, got 500",
+		},
+		{
+			name:     "unknown parameter",
+			tool:     "iban",
+			args:     []string{"country=DE"},
+			hasError: "country: unknown parameter",
+		},
+		{
+			name:     "invalid number",
+			tool:     "iban",
+			args:     []string{"count=many"},
+			hasError: "invalid value for count",
+		},
+		{
+			name:     "missing equals sign",
+			tool:     "iban",
+			args:     []string{"generate"},
+			hasError: `invalid argument "generate": expected key=value`,
+		},
+		{
+			name:     "invalid params json",
+			tool:     "iban",
+			args:     []string{"--params", `["generate"]`},
+			hasError: "invalid --params",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool, _ := registry.GetTool(tt.tool)
+			cmd := newCallToolCmd(registry, tool)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			err := runCall(cmd, cmd.Flags().Args(), registry, tool, &buf)
+			if tt.hasError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.hasError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.hasError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runCall() error = %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+				}
+			}
+			if lines := strings.Count(output, "\n"); tt.lines > 0 && lines != tt.lines {
+				t.Errorf("Expected %d lines of output, got %d:\n%s", tt.lines, lines, output)
+			}
+		})
+	}
+}
+
+func TestCallFlagsMatchSchemas(t *testing.T) {
+	registry := getAvailableTools()
+
+	for _, name := range registry.ListTools() {
+		t.Run(name, func(t *testing.T) {
+			cmd, _, err := callCmd.Find([]string{name})
+			if err != nil || cmd == callCmd {
+				t.Fatalf("Expected a call subcommand for %s", name)
+			}
+
+			tool, _ := registry.GetTool(name)
+			properties := schemaProperties(tool)
+			for property := range properties {
+				if cmd.Flags().Lookup(property) == nil {
+					t.Errorf("Missing flag --%s for schema property %s", property, property)
+				}
+			}
+
+			// Every flag apart from --params must correspond to a schema property
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if _, ok := properties[flag.Name]; !ok && flag.Name != "params" && flag.Name != "help" {
+					t.Errorf("Flag --%s does not correspond to a schema property", flag.Name)
+				}
+			})
+		})
+	}
+}
+
+func TestToolCommandFlagsMatchSchemas(t *testing.T) {
+	// The tool commands take the same flags as their call subcommands, apart
+	// from the bulk validation flags
+	flagTypes := map[string]string{
+		"boolean": "bool",
+		"integer": "int64",
+		"number":  "float64",
+		"array":   "stringSlice",
+	}
+	commands := map[string]tools.Tool{
+		"random":     tools.NewRandomTool(),
+		"iban":       tools.NewIBANTool(),
+		"mmsi":       tools.NewMMSITool(),
+		"creditcard": tools.NewCreditCardTool(),
+		"imo":        tools.NewIMOTool(),
+		"isbn":       tools.NewISBNTool(),
+		"ean13":      tools.NewEAN13Tool(),
+		"uuid":       tools.NewUUIDTool(),
+		"id":         tools.NewIDTool(),
+		"identify":   tools.NewIdentifyTool(),
+	}
+
+	for name, tool := range commands {
+		t.Run(name, func(t *testing.T) {
+			cmd, _, err := rootCmd.Find([]string{name})
+			if err != nil || cmd == rootCmd {
+				t.Fatalf("Expected a %s command", name)
+			}
+
+			properties := schemaProperties(tool)
+			for property, schema := range properties {
+				flag := cmd.Flags().Lookup(property)
+				if flag == nil {
+					t.Errorf("Missing flag --%s for schema property %s", property, property)
+					continue
+				}
+				expected, ok := flagTypes[schemaType(schema)]
+				if !ok {
+					expected = "string"
+				}
+				if flag.Value.Type() != expected {
+					t.Errorf("Expected flag --%s of type %s, got %s", property, expected, flag.Value.Type())
+				}
+			}
+
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				switch flag.Name {
+				case "input-file", "fail-on", "help":
+					return
+				}
+				if _, ok := properties[flag.Name]; !ok && rootCmd.PersistentFlags().Lookup(flag.Name) == nil {
+					t.Errorf("Flag --%s does not correspond to a schema property", flag.Name)
+				}
+			})
+		})
+	}
+}
+
+func TestCallParamsMatchMCP(t *testing.T) {
+	// Parameters built from the command line must equal those decoded from an MCP request
+	registry := getAvailableTools()
+	tool, _ := registry.GetTool("iban")
+	cmd := newCallToolCmd(registry, tool)
+	if err := cmd.ParseFlags([]string{"--operation", "generate", "--count", "2", "country-code=DE"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+
+	params, err := callParams(cmd, cmd.Flags().Args(), tool)
+	if err != nil {
+		t.Fatalf("callParams() error = %v", err)
+	}
+
+	var expected map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"operation": "generate", "count": 2, "country-code": "DE"}`), &expected); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("Expected params %v, got %v", expected, params)
+	}
+	if err := tools.ValidateInput(tool, params); err != nil {
+		t.Errorf("ValidateInput() error = %v", err)
+	}
+}
+
+func TestConvertParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		property map[string]interface{}
+		value    string
+		expected interface{}
+		hasError bool
+	}{
+		{"string", map[string]interface{}{"type": "string"}, "42", "42", false},
+		{"integer", map[string]interface{}{"type": "integer"}, "42", int64(42), false},
+		{"large integer", map[string]interface{}{"type": "integer"}, "9007199254740993", int64(9007199254740993), false},
+		{"invalid integer", map[string]interface{}{"type": "integer"}, "4.2", nil, true},
+		{"number", map[string]interface{}{"type": "number"}, "4.2", 4.2, false},
+		{"boolean", map[string]interface{}{"type": "boolean"}, "true", true, false},
+		{"invalid boolean", map[string]interface{}{"type": "boolean"}, "maybe", nil, true},
+		{"comma-separated array", map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}}, "1, 2", []interface{}{float64(1), float64(2)}, false},
+		{"json array", map[string]interface{}{"type": "array"}, `["a", "b,c"]`, []interface{}{"a", "b,c"}, false},
+		{"object", map[string]interface{}{"type": "object"}, `{"a": 1}`, map[string]interface{}{"a": float64(1)}, false},
+		{"invalid object", map[string]interface{}{"type": "object"}, `{"a"`, nil, true},
+		{"several types with json", map[string]interface{}{"type": []string{"string", "array"}}, `["x"]`, []interface{}{"x"}, false},
+		{"several types with string", map[string]interface{}{"type": []string{"string", "array"}}, "x", "x", false},
+		{"undeclared parameter", nil, "7", "7", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertParam(tt.property, tt.value)
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertParam() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %#v, got %#v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCallSeedPrecision(t *testing.T) {
+	// Seeds above 2^53 must reach the tool unchanged, as they do from the uuid command
+	registry := getAvailableTools()
+	tool, _ := registry.GetTool("uuid")
+	call := func(args ...string) string {
+		cmd := newCallToolCmd(registry, tool)
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("ParseFlags() error = %v", err)
+		}
+		var buf bytes.Buffer
+		if err := runCall(cmd, cmd.Flags().Args(), registry, tool, &buf); err != nil {
+			t.Fatalf("runCall() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	var buf bytes.Buffer
+	if err := runUUID(parseToolFlags(t, tool, "--version", "v4", "--seed", "9007199254740993"), nil, &buf); err != nil {
+		t.Fatalf("runUUID() error = %v", err)
+	}
+	expected := buf.String()
+
+	if got := call("version=v4", "seed=9007199254740993"); got != expected {
+		t.Errorf("Expected key=value seed to give %q, got %q", expected, got)
+	}
+	if got := call("--version", "v4", "--seed", "9007199254740993"); got != expected {
+		t.Errorf("Expected --seed to give %q, got %q", expected, got)
+	}
+	if got := call("version=v4", "seed=9007199254740992"); got == expected {
+		t.Errorf("Expected seeds 9007199254740992 and 9007199254740993 to differ, both gave %q", got)
+	}
+}
+
+// parseToolFlags returns a command with the schema flags of a tool, parsed
+// from the arguments, for calling a command's run function directly
+func parseToolFlags(t *testing.T, tool tools.Tool, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: tool.Name()}
+	registerSchemaFlags(cmd, tool)
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	return cmd
+}
+
+// flagArgs converts flag values to command line arguments, leaving out empty
+// strings and zero numbers so that the tool's defaults apply to them
+func flagArgs(values map[string]interface{}) []string {
+	var args []string
+	for name, value := range values {
+		if value == "" || value == 0 {
+			continue
+		}
+		args = append(args, fmt.Sprintf("--%s=%v", name, value))
+	}
+	return args
+}
+
+func TestSourceParams(t *testing.T) {
+	// --seed and --secure reach the tool as given, so a seed of 0 is honoured
+	tests := []struct {
+		name     string
+		args     []string
+		expected map[string]interface{}
+		wantErr  bool
+	}{
+		{"none", nil, map[string]interface{}{}, false},
+		{"zero", []string{"--seed", "0"}, map[string]interface{}{"seed": int64(0)}, false},
+		{"large", []string{"--seed", "9007199254740993"}, map[string]interface{}{"seed": int64(9007199254740993)}, false},
+		{"negative", []string{"--seed=-5"}, map[string]interface{}{"seed": int64(-5)}, false},
+		{"secure", []string{"--secure"}, map[string]interface{}{"secure": true}, false},
+		{"both", []string{"--seed", "42", "--secure"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := tools.NewIBANTool()
+			params, err := toolParams(parseToolFlags(t, tool, tt.args...), tool)
+			if err == nil {
+				err = tool.ValidateParams(params)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(params, tt.expected) {
+				t.Errorf("Expected params %v, got %v", tt.expected, params)
+			}
+		})
+	}
+}
+
+func TestSeededCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		tool tools.Tool
+		run  func(*cobra.Command, []string, io.Writer) error
+		args []string
+	}{
+		{"random", tools.NewRandomTool(), runRandom, []string{"--type", "integer", "--count", "5", "--seed", "42"}},
+		{"uuid", tools.NewUUIDTool(), runUUID, []string{"--version", "v4", "--count", "3", "--seed", "42"}},
+		{"iban", tools.NewIBANTool(), runIBAN, []string{"--operation", "generate", "--count", "3", "--seed", "42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var first, second bytes.Buffer
+			if err := tt.run(parseToolFlags(t, tt.tool, tt.args...), nil, &first); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err := tt.run(parseToolFlags(t, tt.tool, tt.args...), nil, &second); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if first.String() == "" || first.String() != second.String() {
+				t.Errorf("Expected the same output for the same seed, got %q and %q", first.String(), second.String())
+			}
+		})
+	}
+}
@@ -5,15 +5,14 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
 var (
-	creditCardOperation string
-	creditCardInput     string
-	creditCardType      string
-	creditCardCount     int
+	creditCardInputFile string
+	creditCardFailOn    string
 )
 
 // creditCardCmd represents the creditcard command
@@ -34,17 +33,17 @@ Examples:
   mcpipboy creditcard --operation validate --input "4532015112830366"
   mcpipboy creditcard --operation generate --card-type visa --count 5
   mcpipboy creditcard --operation generate --card-type amex
-  mcpipboy creditcard --operation validate --input "5555 5555 5555 4444"`,
+  mcpipboy creditcard --operation validate --input "5555 5555 5555 4444"
+  mcpipboy creditcard --input-file cards.txt --fail-on any
+  mcpipboy creditcard --operation generate --card-type visa --count 5 --seed 42`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runCreditCard(cmd, args, os.Stdout)
 	},
 }
 
 func init() {
-	creditCardCmd.Flags().StringVar(&creditCardOperation, "operation", "validate", "Operation to perform: validate or generate")
-	creditCardCmd.Flags().StringVar(&creditCardInput, "input", "", "Credit card number to validate")
-	creditCardCmd.Flags().StringVar(&creditCardType, "card-type", "", "Card type for generation: visa, mastercard, amex, discover, diners, jcb")
-	creditCardCmd.Flags().IntVar(&creditCardCount, "count", 1, "Number of credit cards to generate (1-100)")
+	registerSchemaFlags(creditCardCmd, tools.NewCreditCardTool())
+	addBulkFlags(creditCardCmd, &creditCardInputFile, &creditCardFailOn)
 
 	creditCardCmd.GroupID = "tools"
 	rootCmd.AddCommand(creditCardCmd)
@@ -53,18 +52,16 @@ func init() {
 func runCreditCard(cmd *cobra.Command, args []string, out io.Writer) error {
 	tool := tools.NewCreditCardTool()
 
-	// Build parameters map
-	params := make(map[string]interface{})
-	if creditCardOperation != "" {
-		params["operation"] = creditCardOperation
-	}
-	if creditCardInput != "" {
-		params["input"] = creditCardInput
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
-	if creditCardType != "" {
-		params["card-type"] = creditCardType
+
+	// Validate the values of a file, one per line
+	if creditCardInputFile != "" {
+		return runBulkValidation(cmd, tool, params, creditCardInputFile, creditCardFailOn, out)
 	}
-	params["count"] = float64(creditCardCount)
 
 	// Validate parameters
 	if err := tool.ValidateParams(params); err != nil {
@@ -77,35 +74,36 @@ func runCreditCard(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("execution failed: %v", err)
 	}
 
-	// Handle the result based on operation
-	if creditCardOperation == "validate" {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			if valid, ok := resultMap["valid"].(bool); ok {
-				if valid {
-					fmt.Fprintf(out, "Valid credit card: %s\n", resultMap["card"])
-					if cardType, ok := resultMap["type"].(string); ok {
-						fmt.Fprintf(out, "   Type: %s\n", cardType)
-					}
-				} else {
-					fmt.Fprintf(out, "Invalid credit card: %s\n", resultMap["error"])
-					if input, ok := resultMap["input"].(string); ok {
-						fmt.Fprintf(out, "   Input: %s\n", input)
-					}
-				}
-			}
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
+	// Handle the result based on its type
+	switch v := result.(type) {
+	case string:
+		// Single card
+		fmt.Fprintln(out, v)
+	case []string:
+		// Multiple cards
+		for _, card := range v {
+			fmt.Fprintln(out, card)
+		}
+	case map[string]interface{}:
+		// Validation result
+		valid, ok := v["valid"].(bool)
+		if !ok {
+			return output.Write(out, output.FormatText, result, tool.GetOutputSchema())
 		}
-	} else if creditCardOperation == "generate" {
-		if creditCardCount == 1 {
-			// Single card
-			if card, ok := result.(string); ok {
-				fmt.Fprintln(out, card)
+		if valid {
+			fmt.Fprintf(out, "Valid credit card: %s\n", v["card"])
+			if cardType, ok := v["type"].(string); ok {
+				fmt.Fprintf(out, "   Type: %s\n", cardType)
 			}
 		} else {
-			// Multiple cards
-			if cards, ok := result.([]string); ok {
-				for _, card := range cards {
-					fmt.Fprintln(out, card)
-				}
+			fmt.Fprintf(out, "Invalid credit card: %s\n", v["error"])
+			if input, ok := v["input"].(string); ok {
+				fmt.Fprintf(out, "   Input: %s\n", input)
 			}
 		}
 	}
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 )
 
 func TestRunCreditCard(t *testing.T) {
@@ -185,20 +187,19 @@ func TestRunCreditCardUnit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset and set global variables
-			creditCardOperation = tt.operation
-			creditCardInput = tt.input
-			creditCardType = tt.cardType
-			creditCardCount = tt.count
-			if creditCardCount == 0 {
-				creditCardCount = 1
-			}
+			// Parse the flags of the test case
+			cmd := parseToolFlags(t, tools.NewCreditCardTool(), flagArgs(map[string]interface{}{
+				"operation": tt.operation,
+				"input":     tt.input,
+				"card-type": tt.cardType,
+				"count":     tt.count,
+			})...)
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runCreditCard directly
-			err := runCreditCard(nil, nil, &buf)
+			err := runCreditCard(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
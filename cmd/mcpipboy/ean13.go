@@ -5,14 +5,14 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
 var (
-	ean13Operation string
-	ean13Input     string
-	ean13Count     int
+	ean13InputFile string
+	ean13FailOn    string
 )
 
 // ean13Cmd represents the ean13 command
@@ -32,7 +32,9 @@ Examples:
   mcpipboy ean13 --operation generate
 
   # Generate multiple EAN-13s
-  mcpipboy ean13 --operation generate --count 5`,
+  mcpipboy ean13 --operation generate --count 5
+  mcpipboy ean13 --input-file barcodes.txt --fail-on any
+  mcpipboy ean13 --operation generate --count 5 --seed 42`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runEAN13(cmd, args, os.Stdout)
 	},
@@ -42,9 +44,8 @@ func init() {
 	rootCmd.AddCommand(ean13Cmd)
 
 	// Add flags
-	ean13Cmd.Flags().StringVar(&ean13Operation, "operation", "validate", "Operation to perform: validate or generate")
-	ean13Cmd.Flags().StringVar(&ean13Input, "input", "", "EAN-13 number to validate (required for validate operation)")
-	ean13Cmd.Flags().IntVar(&ean13Count, "count", 1, "Number of EAN-13s to generate (1-100, default: 1)")
+	registerSchemaFlags(ean13Cmd, tools.NewEAN13Tool())
+	addBulkFlags(ean13Cmd, &ean13InputFile, &ean13FailOn)
 
 	// Set command group
 	ean13Cmd.GroupID = "tools"
@@ -54,21 +55,21 @@ func runEAN13(cmd *cobra.Command, args []string, out io.Writer) error {
 	// Create the EAN-13 tool
 	tool := tools.NewEAN13Tool()
 
-	// Build parameters
-	params := make(map[string]interface{})
-
-	// Add operation
-	if ean13Operation != "" {
-		params["operation"] = ean13Operation
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
 
-	// Add input for validation
-	if ean13Input != "" {
-		params["input"] = ean13Input
+	// Validate the values of a file, one per line
+	if ean13InputFile != "" {
+		return runBulkValidation(cmd, tool, params, ean13InputFile, ean13FailOn, out)
 	}
 
-	// Add count (always add, even if 0, so validation can handle it)
-	params["count"] = float64(ean13Count)
+	// Validate parameters
+	if err := tool.ValidateParams(params); err != nil {
+		return fmt.Errorf("parameter validation failed: %v", err)
+	}
 
 	// Execute the tool
 	result, err := tool.Execute(params)
@@ -76,44 +77,37 @@ func runEAN13(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("EAN-13 tool execution failed: %v", err)
 	}
 
-	// Handle the result based on operation
-	if ean13Operation == "validate" {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			if valid, ok := resultMap["valid"].(bool); ok {
-				if valid {
-					fmt.Fprintf(out, "Valid EAN-13: %s\n", resultMap["ean13"])
-				} else {
-					fmt.Fprintf(out, "Invalid EAN-13: %s\n", resultMap["error"])
-					if input, ok := resultMap["input"].(string); ok {
-						fmt.Fprintf(out, "   Input: %s\n", input)
-					}
-				}
-			} else {
-				fmt.Fprintf(out, "EAN-13 validation result: %v\n", result)
-			}
-		} else {
-			fmt.Fprintf(out, "EAN-13 validation result: %v\n", result)
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
+	// Handle the result based on its type
+	switch v := result.(type) {
+	case string:
+		// Single EAN-13
+		fmt.Fprintf(out, "Generated EAN-13: %s\n", v)
+	case []string:
+		// Multiple EAN-13s
+		fmt.Fprintf(out, "Generated %d EAN-13s:\n", len(v))
+		for i, ean13 := range v {
+			fmt.Fprintf(out, "  %d. %s\n", i+1, ean13)
 		}
-	} else if ean13Operation == "generate" {
-		if ean13Count == 1 {
-			// Single EAN-13
-			if ean13, ok := result.(string); ok {
-				fmt.Fprintf(out, "Generated EAN-13: %s\n", ean13)
-			} else {
-				fmt.Fprintf(out, "Generated EAN-13: %v\n", result)
-			}
+	case map[string]interface{}:
+		// Validation result
+		valid, ok := v["valid"].(bool)
+		if !ok {
+			return output.Write(out, output.FormatText, result, tool.GetOutputSchema())
+		}
+		if valid {
+			fmt.Fprintf(out, "Valid EAN-13: %s\n", v["ean13"])
 		} else {
-			// Multiple EAN-13s
-			if ean13s, ok := result.([]string); ok {
-				fmt.Fprintf(out, "Generated %d EAN-13s:\n", len(ean13s))
-				for i, ean13 := range ean13s {
-					fmt.Fprintf(out, "  %d. %s\n", i+1, ean13)
-				}
-			} else {
-				fmt.Fprintf(out, "Generated EAN-13s: %v\n", result)
+			fmt.Fprintf(out, "Invalid EAN-13: %s\n", v["error"])
+			if input, ok := v["input"].(string); ok {
+				fmt.Fprintf(out, "   Input: %s\n", input)
 			}
 		}
-	} else {
+	default:
 		fmt.Fprintf(out, "EAN-13 result: %v\n", result)
 	}
 
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 )
 
 func TestRunEAN13(t *testing.T) {
@@ -155,19 +157,18 @@ func TestRunEAN13Unit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset and set global variables
-			ean13Operation = tt.operation
-			ean13Input = tt.input
-			ean13Count = tt.count
-			if ean13Count == 0 {
-				ean13Count = 1
-			}
+			// Parse the flags of the test case
+			cmd := parseToolFlags(t, tools.NewEAN13Tool(), flagArgs(map[string]interface{}{
+				"operation": tt.operation,
+				"input":     tt.input,
+				"count":     tt.count,
+			})...)
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runEAN13 directly
-			err := runEAN13(nil, nil, &buf)
+			err := runEAN13(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
@@ -48,7 +49,6 @@ func runEcho(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("echo execution failed: %v", err)
 	}
 
-	// Output the result
-	fmt.Fprintln(out, result)
-	return nil
+	// Print the result in the selected output format
+	return output.Write(out, outputFormat, result, echoTool.GetOutputSchema())
 }
@@ -5,15 +5,14 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
 var (
-	ibanOperation   string
-	ibanInput       string
-	ibanCountryCode string
-	ibanCount       int
+	ibanInputFile string
+	ibanFailOn    string
 )
 
 // ibanCmd represents the iban command
@@ -58,17 +57,18 @@ Examples:
   mcpipboy iban --operation validate --input "GB82WEST12345698765432"
   mcpipboy iban --operation generate --country-code "GB" --count 5
   mcpipboy iban --operation generate --country-code "DE"
-  mcpipboy iban --operation validate --input "DE89 3704 0044 0532 0130 00"`,
+  mcpipboy iban --operation validate --input "DE89 3704 0044 0532 0130 00"
+  mcpipboy iban --input-file ibans.txt --fail-on any
+  cat ibans.txt | mcpipboy iban --input-file - --output ndjson
+  mcpipboy iban --operation generate --country-code "DE" --count 5 --seed 42`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runIBAN(cmd, args, os.Stdout)
 	},
 }
 
 func init() {
-	ibanCmd.Flags().StringVar(&ibanOperation, "operation", "validate", "Operation to perform: validate or generate")
-	ibanCmd.Flags().StringVar(&ibanInput, "input", "", "IBAN number to validate")
-	ibanCmd.Flags().StringVar(&ibanCountryCode, "country-code", "", "Country code for generation (ISO 3166-1 alpha-2, e.g., 'GB', 'DE', 'FR')")
-	ibanCmd.Flags().IntVar(&ibanCount, "count", 1, "Number of IBANs to generate (1-100)")
+	registerSchemaFlags(ibanCmd, tools.NewIBANTool())
+	addBulkFlags(ibanCmd, &ibanInputFile, &ibanFailOn)
 
 	ibanCmd.GroupID = "tools"
 	rootCmd.AddCommand(ibanCmd)
@@ -77,18 +77,16 @@ func init() {
 func runIBAN(cmd *cobra.Command, args []string, out io.Writer) error {
 	tool := tools.NewIBANTool()
 
-	// Build parameters map
-	params := make(map[string]interface{})
-	if ibanOperation != "" {
-		params["operation"] = ibanOperation
-	}
-	if ibanInput != "" {
-		params["input"] = ibanInput
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
-	if ibanCountryCode != "" {
-		params["country-code"] = ibanCountryCode
+
+	// Validate the values of a file, one per line
+	if ibanInputFile != "" {
+		return runBulkValidation(cmd, tool, params, ibanInputFile, ibanFailOn, out)
 	}
-	params["count"] = float64(ibanCount)
 
 	// Validate parameters
 	if err := tool.ValidateParams(params); err != nil {
@@ -101,35 +99,36 @@ func runIBAN(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("execution failed: %v", err)
 	}
 
-	// Handle the result based on operation
-	if ibanOperation == "validate" {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			if valid, ok := resultMap["valid"].(bool); ok {
-				if valid {
-					fmt.Fprintf(out, "Valid IBAN: %s\n", resultMap["iban"])
-					if country, ok := resultMap["country"].(string); ok {
-						fmt.Fprintf(out, "   Country: %s\n", country)
-					}
-				} else {
-					fmt.Fprintf(out, "Invalid IBAN: %s\n", resultMap["error"])
-					if input, ok := resultMap["input"].(string); ok {
-						fmt.Fprintf(out, "   Input: %s\n", input)
-					}
-				}
-			}
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
+	// Handle the result based on its type
+	switch v := result.(type) {
+	case string:
+		// Single IBAN
+		fmt.Fprintln(out, v)
+	case []string:
+		// Multiple IBANs
+		for _, iban := range v {
+			fmt.Fprintln(out, iban)
+		}
+	case map[string]interface{}:
+		// Validation result
+		valid, ok := v["valid"].(bool)
+		if !ok {
+			return output.Write(out, output.FormatText, result, tool.GetOutputSchema())
 		}
-	} else if ibanOperation == "generate" {
-		if ibanCount == 1 {
-			// Single IBAN
-			if iban, ok := result.(string); ok {
-				fmt.Fprintln(out, iban)
+		if valid {
+			fmt.Fprintf(out, "Valid IBAN: %s\n", v["iban"])
+			if country, ok := v["country"].(string); ok {
+				fmt.Fprintf(out, "   Country: %s\n", country)
 			}
 		} else {
-			// Multiple IBANs
-			if ibans, ok := result.([]string); ok {
-				for _, iban := range ibans {
-					fmt.Fprintln(out, iban)
-				}
+			fmt.Fprintf(out, "Invalid IBAN: %s\n", v["error"])
+			if input, ok := v["input"].(string); ok {
+				fmt.Fprintf(out, "   Input: %s\n", input)
 			}
 		}
 	}
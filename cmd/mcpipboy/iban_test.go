@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 )
 
 func TestRunIBAN(t *testing.T) {
@@ -146,13 +148,13 @@ func TestIBANCmd_Flags(t *testing.T) {
 		}
 	}
 
-	// Test flag defaults
-	if cmd.Flags().Lookup("operation").DefValue != "validate" {
-		t.Errorf("Expected default operation to be 'validate', got '%s'", cmd.Flags().Lookup("operation").DefValue)
+	// Test that flags not given leave the tool's defaults
+	params, err := toolParams(cmd, tools.NewIBANTool())
+	if err != nil {
+		t.Fatalf("toolParams() error = %v", err)
 	}
-
-	if cmd.Flags().Lookup("count").DefValue != "1" {
-		t.Errorf("Expected default count to be '1', got '%s'", cmd.Flags().Lookup("count").DefValue)
+	if len(params) != 0 {
+		t.Errorf("Expected no parameters without flags, got %v", params)
 	}
 }
 
@@ -233,20 +235,19 @@ func TestRunIBANUnit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset global variables
-			ibanOperation = tt.operation
-			ibanInput = tt.input
-			ibanCountryCode = tt.countryCode
-			ibanCount = tt.count
-			if ibanCount == 0 {
-				ibanCount = 1
-			}
+			// Parse the flags of the test case
+			cmd := parseToolFlags(t, tools.NewIBANTool(), flagArgs(map[string]interface{}{
+				"operation":    tt.operation,
+				"input":        tt.input,
+				"country-code": tt.countryCode,
+				"count":        tt.count,
+			})...)
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runIBAN directly
-			err := runIBAN(nil, nil, &buf)
+			err := runIBAN(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
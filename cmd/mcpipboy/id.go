@@ -0,0 +1,84 @@
+// Package main provides the id command for mcpipboy
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kluzzebass/mcpipboy/internal/output"
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var (
+	idInputFile string
+	idFailOn    string
+)
+
+// idCmd represents the id command
+var idCmd = &cobra.Command{
+	Use:   "id",
+	Short: "Generate, validate and decode ULIDs, KSUIDs, NanoIDs, Snowflake IDs and TypeIDs",
+	Long: `Generate, validate and decode the sortable and compact IDs used in place of UUIDs:
+
+- ULID: a millisecond timestamp and 80 random bits in 26 Crockford base32 characters
+- KSUID: a timestamp in seconds and a 128-bit payload in 27 base62 characters
+- NanoID: random characters from a custom alphabet, 21 URL-safe ones by default
+- Snowflake: Twitter's and Discord's 64-bit IDs of a timestamp since an epoch,
+  a worker ID and a sequence number
+- TypeID: a type prefix and a UUIDv7 in base32, e.g. user_01h455vb4pex5vsknk084sn02q
+
+Validation decodes the embedded timestamp and other fields. The convert operation
+turns a ULID into a UUIDv7, overwriting 6 random bits with the version and variant,
+and a UUID into the ULID with the same bits; a UUIDv7 and its ULID carry the same
+timestamp. IDs draw from crypto/rand unless --seed is given.`,
+	Example: `  mcpipboy id
+  mcpipboy id --format ksuid --count 5
+  mcpipboy id --format nanoid --alphabet 0123456789abcdef --size 12
+  mcpipboy id --format snowflake --epoch discord --worker 7 --count 3
+  mcpipboy id --format typeid --prefix user
+  mcpipboy id --operation validate --format snowflake --epoch discord --input 175928847299117063
+  mcpipboy id --operation convert --input 01ARZ3NDEKTSV4RRFFQ69G5FAV
+  mcpipboy id --operation validate --format ulid --input-file ids.txt --fail-on any`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runID(cmd, args, os.Stdout)
+	},
+}
+
+func init() {
+	registerSchemaFlags(idCmd, tools.NewIDTool())
+	addBulkFlags(idCmd, &idInputFile, &idFailOn)
+
+	idCmd.GroupID = "tools"
+	rootCmd.AddCommand(idCmd)
+}
+
+func runID(cmd *cobra.Command, args []string, out io.Writer) error {
+	tool := tools.NewIDTool()
+
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
+	}
+
+	// Validate the values of a file, one per line
+	if idInputFile != "" {
+		return runBulkValidation(cmd, tool, params, idInputFile, idFailOn, out)
+	}
+
+	// Validate parameters
+	if err := tool.ValidateParams(params); err != nil {
+		return fmt.Errorf("parameter validation failed: %v", err)
+	}
+
+	// Execute the tool
+	result, err := tool.Execute(params)
+	if err != nil {
+		return fmt.Errorf("ID tool execution failed: %v", err)
+	}
+
+	// Print the result in the selected output format
+	return output.Write(out, outputFormat, result, tool.GetOutputSchema())
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+)
+
+func TestRunID(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(file, []byte("01ARZ3NDEKTSV4RRFFQ69G5FAV\nnot-a-ulid\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		operation   string
+		format      string
+		input       string
+		count       int
+		alphabet    string
+		size        int
+		epoch       string
+		worker      int
+		prefix      string
+		inputFile   string
+		output      string
+		lines       int
+		contains    []string
+		expectError bool
+	}{
+		{
+			name:      "generate ulids",
+			operation: "generate",
+			format:    "ulid",
+			count:     3,
+			output:    "text",
+			lines:     3,
+		},
+		{
+			name:      "generate nanoid with alphabet",
+			operation: "generate",
+			format:    "nanoid",
+			alphabet:  "01",
+			count:     1,
+			output:    "text",
+			lines:     1,
+		},
+		{
+			name:      "validate nanoid of another size",
+			operation: "validate",
+			format:    "nanoid",
+			size:      10,
+			input:     "Q9RfC-zR",
+			output:    "text",
+			contains:  []string{"valid: false"},
+		},
+		{
+			name:      "generate typeid",
+			operation: "generate",
+			format:    "typeid",
+			prefix:    "user",
+			count:     1,
+			output:    "text",
+			contains:  []string{"user_0"},
+		},
+		{
+			name:      "validate snowflake",
+			operation: "validate",
+			format:    "snowflake",
+			epoch:     "discord",
+			input:     "175928847299117063",
+			count:     1,
+			output:    "text",
+			contains:  []string{"timestamp: 2016-04-30T11:18:25.796Z", "worker: 32", "sequence: 7", "valid: true"},
+		},
+		{
+			name:      "convert ulid",
+			operation: "convert",
+			format:    "ulid",
+			input:     "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+			count:     1,
+			output:    "json",
+			contains:  []string{`"uuid": "01563e3a-b5d3-7676-8c61-efb99302bd5b"`},
+		},
+		{
+			name:      "bulk validation",
+			operation: "validate",
+			format:    "ulid",
+			inputFile: file,
+			count:     1,
+			output:    "text",
+			contains:  []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "not-a-ulid"},
+		},
+		{
+			name:      "bulk validation defaults to validate",
+			format:    "ulid",
+			inputFile: file,
+			output:    "text",
+			contains:  []string{"Total: 2, valid: 1, invalid: 1"},
+		},
+		{
+			name:        "invalid worker",
+			operation:   "generate",
+			format:      "snowflake",
+			worker:      1024,
+			count:       1,
+			output:      "text",
+			expectError: true,
+		},
+		{
+			name:        "convert without input",
+			operation:   "convert",
+			format:      "ulid",
+			count:       1,
+			output:      "text",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idInputFile, idFailOn, outputFormat = tt.inputFile, "never", tt.output
+			t.Cleanup(func() {
+				idInputFile, idFailOn, outputFormat = "", "never", "text"
+			})
+			cmd := parseToolFlags(t, tools.NewIDTool(), flagArgs(map[string]interface{}{
+				"operation": tt.operation,
+				"format":    tt.format,
+				"input":     tt.input,
+				"count":     tt.count,
+				"alphabet":  tt.alphabet,
+				"size":      tt.size,
+				"epoch":     tt.epoch,
+				"worker":    tt.worker,
+				"prefix":    tt.prefix,
+			})...)
+
+			var buf bytes.Buffer
+			err := runID(cmd, nil, &buf)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			if tt.lines > 0 {
+				if lines := strings.Split(strings.TrimSpace(output), "\n"); len(lines) != tt.lines {
+					t.Errorf("Expected %d lines, got %q", tt.lines, output)
+				}
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got %q", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestIDCmdFlags(t *testing.T) {
+	for _, name := range []string{"operation", "format", "input", "count", "alphabet", "size", "epoch", "worker", "prefix", "seed", "secure", "input-file", "fail-on"} {
+		if idCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected flag --%s", name)
+		}
+	}
+	if idCmd.GroupID != "tools" {
+		t.Errorf("Expected group tools, got %q", idCmd.GroupID)
+	}
+}
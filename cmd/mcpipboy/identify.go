@@ -0,0 +1,88 @@
+// Package main provides the identify command for mcpipboy
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// identifyCmd represents the identify command
+var identifyCmd = &cobra.Command{
+	Use:   "identify",
+	Short: "Identify an unknown identifier",
+	Long: `Identify an unknown identifier by running it through every validator: IBAN, ISBN-10,
+ISBN-13, EAN-13, IMO, MMSI, credit card and UUID.
+
+Every interpretation whose validator accepts the input is listed with a confidence
+between 0 and 1, most confident first, together with the fields the validator extracted.
+The confidence reflects how strong the checks are: an IBAN's MOD-97 checksum is hard to
+pass by accident, while any 9-digit number with a known MID looks like an MMSI.`,
+	Example: `  mcpipboy identify --input 9780306406157
+  mcpipboy identify --input "GB82 WEST 1234 5698 7654 32"
+  mcpipboy identify --input 550e8400-e29b-41d4-a716-446655440000 --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runIdentify(cmd, args, os.Stdout)
+	},
+}
+
+func init() {
+	registerSchemaFlags(identifyCmd, tools.NewIdentifyTool())
+
+	identifyCmd.GroupID = "tools"
+	rootCmd.AddCommand(identifyCmd)
+}
+
+func runIdentify(cmd *cobra.Command, args []string, out io.Writer) error {
+	tool := tools.NewIdentifyTool()
+
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
+	}
+
+	if err := tool.ValidateParams(params); err != nil {
+		return fmt.Errorf("parameter validation failed: %v", err)
+	}
+
+	result, err := tool.Execute(params)
+	if err != nil {
+		return fmt.Errorf("execution failed: %v", err)
+	}
+
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
+	resultMap := result.(map[string]interface{})
+	matches := resultMap["matches"].([]map[string]interface{})
+	if len(matches) == 0 {
+		fmt.Fprintf(out, "No known identifier matches %s\n", resultMap["input"])
+		return nil
+	}
+
+	for i, match := range matches {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "%s (confidence %.2f): %s\n", match["kind"], match["confidence"], match["reason"])
+
+		fields := match["fields"].(map[string]interface{})
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(out, "   %s: %v\n", name, fields[name])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+)
+
+func TestRunIdentify(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		format      string
+		contains    []string
+		expectError bool
+	}{
+		{
+			name:     "isbn ranked before ean",
+			args:     []string{"--input", "9780306406157"},
+			format:   "text",
+			contains: []string{"isbn-13 (confidence 0.90)", "   isbn: 9780306406157\n", "ean-13 (confidence 0.70)"},
+		},
+		{
+			name:     "no match",
+			args:     []string{"--input", "hello"},
+			format:   "text",
+			contains: []string{"No known identifier matches hello"},
+		},
+		{
+			name:     "json",
+			args:     []string{"--input", "GB82WEST12345698765432"},
+			format:   "json",
+			contains: []string{`"kind": "iban"`, `"country": "GB"`},
+		},
+		{
+			name:        "empty input",
+			args:        []string{"--input", ""},
+			format:      "text",
+			expectError: true,
+		},
+		{
+			name:        "missing input",
+			format:      "text",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFormat = tt.format
+			t.Cleanup(func() { outputFormat = "text" })
+
+			var buf bytes.Buffer
+			err := runIdentify(parseToolFlags(t, tools.NewIdentifyTool(), tt.args...), nil, &buf)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got %q", want, output)
+				}
+			}
+			if strings.Index(output, "isbn-13") > strings.Index(output, "ean-13") {
+				t.Errorf("Expected isbn-13 to be listed before ean-13, got %q", output)
+			}
+		})
+	}
+}
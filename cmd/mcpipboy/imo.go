@@ -5,14 +5,14 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
 var (
-	imoOperation string
-	imoInput     string
-	imoCount     int
+	imoInputFile string
+	imoFailOn    string
 )
 
 // imoCmd represents the imo command
@@ -27,7 +27,9 @@ The check digit is calculated as: (7×d1 + 6×d2 + 5×d3 + 4×d4 + 3×d5 + 2×d6
 Examples:
   mcpipboy imo --operation validate --input "1234567"
   mcpipboy imo --operation generate --count 5
-  mcpipboy imo --operation generate`,
+  mcpipboy imo --operation generate
+  mcpipboy imo --input-file imos.txt --fail-on any
+  mcpipboy imo --operation generate --count 5 --seed 42`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runIMO(cmd, args, os.Stdout)
 	},
@@ -38,30 +40,23 @@ func init() {
 	imoCmd.GroupID = "tools"
 
 	// Add flags
-	imoCmd.Flags().StringVar(&imoOperation, "operation", "validate", "Operation to perform: 'validate' or 'generate'")
-	imoCmd.Flags().StringVar(&imoInput, "input", "", "IMO number to validate (required for validation)")
-	imoCmd.Flags().IntVar(&imoCount, "count", 1, "Number of IMO numbers to generate (max: 100)")
-
-	// Mark input as required only for validation
-	// We'll handle this in the runIMO function
+	registerSchemaFlags(imoCmd, tools.NewIMOTool())
+	addBulkFlags(imoCmd, &imoInputFile, &imoFailOn)
 }
 
 func runIMO(cmd *cobra.Command, args []string, out io.Writer) error {
 	// Create the IMO tool
 	tool := tools.NewIMOTool()
 
-	// Build parameters
-	params := map[string]interface{}{
-		"operation": imoOperation,
-		"count":     imoCount,
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
 
-	// Add input for validation
-	if imoOperation == "validate" {
-		if imoInput == "" {
-			return fmt.Errorf("input is required for validation operation")
-		}
-		params["input"] = imoInput
+	// Validate the values of a file, one per line
+	if imoInputFile != "" {
+		return runBulkValidation(cmd, tool, params, imoInputFile, imoFailOn, out)
 	}
 
 	// Validate parameters
@@ -75,6 +70,11 @@ func runIMO(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("IMO tool execution failed: %v", err)
 	}
 
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
 	// Handle different result types
 	switch v := result.(type) {
 	case string:
@@ -97,8 +97,8 @@ func runIMO(cmd *cobra.Command, args []string, out io.Writer) error {
 				}
 			}
 		} else {
-			// Fallback for unexpected result format
-			fmt.Fprintf(out, "%+v\n", v)
+			// Bulk validation results and a summary
+			return output.Write(out, output.FormatText, result, tool.GetOutputSchema())
 		}
 	default:
 		return fmt.Errorf("unexpected result type: %T", result)
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 )
 
 func TestRunIMO(t *testing.T) {
@@ -156,19 +158,18 @@ func TestRunIMOUnit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset and set global variables
-			imoOperation = tt.operation
-			imoInput = tt.input
-			imoCount = tt.count
-			if imoCount == 0 {
-				imoCount = 1
-			}
+			// Parse the flags of the test case
+			cmd := parseToolFlags(t, tools.NewIMOTool(), flagArgs(map[string]interface{}{
+				"operation": tt.operation,
+				"input":     tt.input,
+				"count":     tt.count,
+			})...)
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runIMO directly
-			err := runIMO(nil, nil, &buf)
+			err := runIMO(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
@@ -5,15 +5,14 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
 var (
-	isbnOperation string
-	isbnInput     string
-	isbnFormat    string
-	isbnCount     int
+	isbnInputFile string
+	isbnFailOn    string
 )
 
 // isbnCmd represents the isbn command
@@ -39,7 +38,9 @@ Examples:
   mcpipboy isbn --operation generate --format "isbn10" --count 3
 
   # Generate multiple ISBN-13s
-  mcpipboy isbn --operation generate --format "isbn13" --count 5`,
+  mcpipboy isbn --operation generate --format "isbn13" --count 5
+  mcpipboy isbn --input-file isbns.txt --fail-on any
+  mcpipboy isbn --operation generate --count 5 --seed 42`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runISBN(cmd, args, os.Stdout)
 	},
@@ -49,10 +50,8 @@ func init() {
 	rootCmd.AddCommand(isbnCmd)
 
 	// Add flags
-	isbnCmd.Flags().StringVar(&isbnOperation, "operation", "validate", "Operation to perform: validate or generate")
-	isbnCmd.Flags().StringVar(&isbnInput, "input", "", "ISBN number to validate (required for validate operation)")
-	isbnCmd.Flags().StringVar(&isbnFormat, "format", "", "ISBN format: isbn10, isbn13, or auto (default: auto for validation, isbn13 for generation)")
-	isbnCmd.Flags().IntVar(&isbnCount, "count", 1, "Number of ISBNs to generate (1-100, default: 1)")
+	registerSchemaFlags(isbnCmd, tools.NewISBNTool())
+	addBulkFlags(isbnCmd, &isbnInputFile, &isbnFailOn)
 
 	// Set command group
 	isbnCmd.GroupID = "tools"
@@ -62,74 +61,62 @@ func runISBN(cmd *cobra.Command, args []string, out io.Writer) error {
 	// Create the ISBN tool
 	tool := tools.NewISBNTool()
 
-	// Build parameters
-	params := make(map[string]interface{})
-
-	// Add operation
-	if isbnOperation != "" {
-		params["operation"] = isbnOperation
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
 
-	// Add input for validation
-	if isbnInput != "" {
-		params["input"] = isbnInput
+	// Validate the values of a file, one per line
+	if isbnInputFile != "" {
+		return runBulkValidation(cmd, tool, params, isbnInputFile, isbnFailOn, out)
 	}
 
-	// Add format
-	if isbnFormat != "" {
-		params["format"] = isbnFormat
+	// Validate parameters
+	if err := tool.ValidateParams(params); err != nil {
+		return fmt.Errorf("parameter validation failed: %v", err)
 	}
 
-	// Add count (always add, even if 0, so validation can handle it)
-	params["count"] = float64(isbnCount)
-
 	// Execute the tool
 	result, err := tool.Execute(params)
 	if err != nil {
 		return fmt.Errorf("ISBN tool execution failed: %v", err)
 	}
 
-	// Handle the result based on operation
-	if isbnOperation == "validate" {
-		if resultMap, ok := result.(map[string]interface{}); ok {
-			if valid, ok := resultMap["valid"].(bool); ok {
-				if valid {
-					fmt.Fprintf(out, "Valid ISBN: %s\n", resultMap["isbn"])
-					if format, ok := resultMap["format"].(string); ok {
-						fmt.Fprintf(out, "   Format: %s\n", format)
-					}
-				} else {
-					fmt.Fprintf(out, "Invalid ISBN: %s\n", resultMap["error"])
-					if input, ok := resultMap["input"].(string); ok {
-						fmt.Fprintf(out, "   Input: %s\n", input)
-					}
-				}
-			} else {
-				fmt.Fprintf(out, "ISBN validation result: %v\n", result)
-			}
-		} else {
-			fmt.Fprintf(out, "ISBN validation result: %v\n", result)
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
+	// Handle the result based on its type
+	switch v := result.(type) {
+	case string:
+		// Single ISBN
+		fmt.Fprintf(out, "Generated ISBN: %s\n", v)
+	case []string:
+		// Multiple ISBNs
+		fmt.Fprintf(out, "Generated %d ISBNs:\n", len(v))
+		for i, isbn := range v {
+			fmt.Fprintf(out, "  %d. %s\n", i+1, isbn)
+		}
+	case map[string]interface{}:
+		// Validation result
+		valid, ok := v["valid"].(bool)
+		if !ok {
+			return output.Write(out, output.FormatText, result, tool.GetOutputSchema())
 		}
-	} else if isbnOperation == "generate" {
-		if isbnCount == 1 {
-			// Single ISBN
-			if isbn, ok := result.(string); ok {
-				fmt.Fprintf(out, "Generated ISBN: %s\n", isbn)
-			} else {
-				fmt.Fprintf(out, "Generated ISBN: %v\n", result)
+		if valid {
+			fmt.Fprintf(out, "Valid ISBN: %s\n", v["isbn"])
+			if format, ok := v["format"].(string); ok {
+				fmt.Fprintf(out, "   Format: %s\n", format)
 			}
 		} else {
-			// Multiple ISBNs
-			if isbns, ok := result.([]string); ok {
-				fmt.Fprintf(out, "Generated %d ISBNs:\n", len(isbns))
-				for i, isbn := range isbns {
-					fmt.Fprintf(out, "  %d. %s\n", i+1, isbn)
-				}
-			} else {
-				fmt.Fprintf(out, "Generated ISBNs: %v\n", result)
+			fmt.Fprintf(out, "Invalid ISBN: %s\n", v["error"])
+			if input, ok := v["input"].(string); ok {
+				fmt.Fprintf(out, "   Input: %s\n", input)
 			}
 		}
-	} else {
+	default:
 		fmt.Fprintf(out, "ISBN result: %v\n", result)
 	}
 
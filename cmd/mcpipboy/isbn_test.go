@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 )
 
 func TestRunISBN(t *testing.T) {
@@ -172,20 +174,19 @@ func TestRunISBNUnit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset and set global variables
-			isbnOperation = tt.operation
-			isbnInput = tt.input
-			isbnFormat = tt.format
-			isbnCount = tt.count
-			if isbnCount == 0 {
-				isbnCount = 1
-			}
+			// Parse the flags of the test case
+			cmd := parseToolFlags(t, tools.NewISBNTool(), flagArgs(map[string]interface{}{
+				"operation": tt.operation,
+				"input":     tt.input,
+				"format":    tt.format,
+				"count":     tt.count,
+			})...)
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runISBN directly
-			err := runISBN(nil, nil, &buf)
+			err := runISBN(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
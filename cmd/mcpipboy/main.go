@@ -2,9 +2,16 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/kluzzebass/mcpipboy"
+	"github.com/kluzzebass/mcpipboy/internal/output"
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
@@ -26,12 +33,22 @@ with essential tools for common tasks they struggle with, including UUID generat
 checksummed identifier verification/generation (IMO, MMSI, credit card numbers, ISBN, etc.),
 and other utility functions.`,
 	Version: getVersionInfo(),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(output.Formats(), outputFormat) {
+			return fmt.Errorf("invalid output format: %s. Available formats: %s", outputFormat, strings.Join(output.Formats(), ", "))
+		}
+		return nil
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -69,7 +86,22 @@ func init() {
 		Title: "Tool Commands:",
 	})
 
-	// Add global flags here if needed
+	// Add global flags
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.FormatText, "Output format: "+strings.Join(output.Formats(), ", "))
+	rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(output.Formats(), cobra.ShellCompDirectiveNoFileComp))
+}
+
+// outputFormat is the format selected with --output
+var outputFormat = output.FormatText
+
+// writeOutput writes a tool result in the format selected with --output. It
+// returns false without writing anything for the text format, in which the
+// command prints its own human-readable output.
+func writeOutput(out io.Writer, tool tools.Tool, result interface{}) (bool, error) {
+	if outputFormat == output.FormatText {
+		return false, nil
+	}
+	return true, output.Write(out, outputFormat, result, tool.GetOutputSchema())
 }
 
 func main() {
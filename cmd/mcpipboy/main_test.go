@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+)
+
+func TestOutputFormats(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		operation string
+		input     string
+		count     int
+		check     func(t *testing.T, output string)
+	}{
+		{
+			name:      "json list",
+			format:    "json",
+			operation: "generate",
+			count:     50,
+			check: func(t *testing.T, output string) {
+				var ibans []string
+				if err := json.Unmarshal([]byte(output), &ibans); err != nil {
+					t.Fatalf("Expected a JSON array of strings: %v", err)
+				}
+				if len(ibans) != 50 {
+					t.Errorf("Expected 50 IBANs, got %d", len(ibans))
+				}
+			},
+		},
+		{
+			name:      "json object",
+			format:    "json",
+			operation: "validate",
+			input:     "GB82WEST12345698765432",
+			count:     1,
+			check: func(t *testing.T, output string) {
+				var result map[string]interface{}
+				if err := json.Unmarshal([]byte(output), &result); err != nil {
+					t.Fatalf("Expected a JSON object: %v", err)
+				}
+				if result["valid"] != true {
+					t.Errorf("Expected a valid result, got %v", result)
+				}
+			},
+		},
+		{
+			name:      "ndjson",
+			format:    "ndjson",
+			operation: "generate",
+			count:     3,
+			check: func(t *testing.T, output string) {
+				lines := strings.Split(strings.TrimSpace(output), "\n")
+				if len(lines) != 3 {
+					t.Fatalf("Expected 3 lines, got %q", output)
+				}
+				for _, line := range lines {
+					var iban string
+					if err := json.Unmarshal([]byte(line), &iban); err != nil {
+						t.Errorf("Expected a JSON string per line, got %q", line)
+					}
+				}
+			},
+		},
+		{
+			name:      "csv",
+			format:    "csv",
+			operation: "validate",
+			input:     "GB82WEST12345698765432",
+			count:     1,
+			check: func(t *testing.T, output string) {
+				if !strings.HasPrefix(output, "country,iban,input,valid\nGB,") {
+					t.Errorf("Unexpected CSV output %q", output)
+				}
+			},
+		},
+		{
+			name:      "yaml",
+			format:    "yaml",
+			operation: "validate",
+			input:     "GB82WEST12345698765432",
+			count:     1,
+			check: func(t *testing.T, output string) {
+				if !strings.Contains(output, "valid: true\n") {
+					t.Errorf("Unexpected YAML output %q", output)
+				}
+			},
+		},
+		{
+			name:      "text keeps the command's output",
+			format:    "text",
+			operation: "validate",
+			input:     "GB82WEST12345698765432",
+			count:     1,
+			check: func(t *testing.T, output string) {
+				if !strings.HasPrefix(output, "Valid IBAN: GB82WEST12345698765432\n") {
+					t.Errorf("Unexpected text output %q", output)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFormat = tt.format
+			t.Cleanup(func() { outputFormat = "text" })
+			cmd := parseToolFlags(t, tools.NewIBANTool(), flagArgs(map[string]interface{}{
+				"operation": tt.operation,
+				"input":     tt.input,
+				"count":     tt.count,
+			})...)
+
+			var buf bytes.Buffer
+			if err := runIBAN(cmd, nil, &buf); err != nil {
+				t.Fatalf("runIBAN() error = %v", err)
+			}
+			tt.check(t, buf.String())
+		})
+	}
+}
+
+func TestInvalidOutputFormat(t *testing.T) {
+	outputFormat = "xml"
+	t.Cleanup(func() { outputFormat = "text" })
+
+	err := rootCmd.PersistentPreRunE(rootCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid output format: xml") {
+		t.Errorf("Expected an invalid output format error, got %v", err)
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"slices"
 	"strings"
+	"syscall"
 
+	"github.com/kluzzebass/mcpipboy/internal/config"
 	"github.com/kluzzebass/mcpipboy/internal/server"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
@@ -18,17 +21,52 @@ var mcpCmd = &cobra.Command{
 	Use:   "mcp",
 	Short: "Start the MCP server",
 	Long: `Start the MCP (Model Context Protocol) server that provides tools to AI agents.
-The server communicates via stdin/stdout and can be configured to enable or disable specific tools.
+By default the server communicates via stdin/stdout. With --transport http or sse it
+listens on --listen and serves any number of concurrent MCP sessions, shutting down
+gracefully on SIGINT/SIGTERM. The server can be configured to enable or disable specific tools.
 
-You can also toggle tools on/off using the tools manager in your MCP client.`,
+With --tool-manager the server also offers a built-in "tools" tool that lets the agent
+enable and disable tools during a session; clients are notified through
+notifications/tools/list_changed, so tools disabled at startup can be enabled on demand.
+
+Settings can also come from named profiles in a JSON config file, read from --config or
+$XDG_CONFIG_HOME/mcpipboy/config.json (default: ~/.config/mcpipboy/config.json). A profile
+sets the enabled tools, per-tool default parameters, transport and logging; flags given on
+the command line take precedence. The file is validated against the available tools at startup.
+
+The server also offers MCP prompts: parameterized templates for common workflows such as
+generating test customer records, auditing vessel identifiers and converting meeting times.
+A prompt is only listed while all the tools it uses are enabled.
+
+With --seed, every call to a generating tool that does not pass its own seed parameter
+generates as if it had passed the given seed, so identical calls return identical values.
+With --secure, calls that pass neither a seed nor a secure parameter draw from the
+cryptographically secure crypto/rand instead.
+Every result of a generating tool reports the random source it was drawn from.`,
+	Example: `  mcpipboy mcp
+  mcpipboy mcp --enable uuid,iban
+  mcpipboy mcp --enable echo --tool-manager
+  mcpipboy mcp --transport http --listen 0.0.0.0:8080
+  mcpipboy mcp --transport sse --listen localhost:9000
+  mcpipboy mcp --profile maritime
+  mcpipboy mcp --config ./mcpipboy.json --profile finance
+  mcpipboy mcp --seed 42
+  mcpipboy mcp --secure`,
 	RunE: runMCP,
 }
 
 var (
-	enableTools  []string
-	disableTools []string
-	debugMode    bool
-	logFile      string
+	enableTools    []string
+	disableTools   []string
+	debugMode      bool
+	logFile        string
+	mcpTransport   string
+	mcpListenAddr  string
+	mcpToolManager bool
+	mcpConfigFile  string
+	mcpProfile     string
+	mcpSeed        seedFlag
+	mcpSecure      bool
 )
 
 func init() {
@@ -42,13 +80,23 @@ func init() {
 	mcpCmd.Flags().StringSliceVar(&disableTools, "disable", []string{}, "Comma-separated list of tools to disable (mutually exclusive with --enable)")
 	mcpCmd.Flags().BoolVar(&debugMode, "debug", false, "Enable debug logging for MCP protocol messages")
 	mcpCmd.Flags().StringVar(&logFile, "log-file", "", "File to write debug logs to (default: stderr)")
+	mcpCmd.Flags().StringVar(&mcpTransport, "transport", server.TransportStdio, "Transport to serve MCP over: "+strings.Join(server.Transports(), ", "))
+	mcpCmd.Flags().StringVar(&mcpListenAddr, "listen", server.DefaultListenAddr, "Address to listen on for the http and sse transports")
+	mcpCmd.Flags().StringVar(&mcpConfigFile, "config", "", "Config file with named profiles (default: $XDG_CONFIG_HOME/mcpipboy/config.json)")
+	mcpCmd.Flags().StringVar(&mcpProfile, "profile", "", "Config profile to use (default: the config file's defaultProfile)")
+	mcpCmd.Flags().BoolVar(&mcpToolManager, "tool-manager", false, "Offer a built-in \"tools\" tool that enables and disables tools at runtime")
+	mcpCmd.Flags().Var(&mcpSeed, "seed", "Default seed for the generating tools, making every call reproducible (default: random)")
+	mcpCmd.Flags().BoolVar(&mcpSecure, "secure", false, "Make the generating tools draw from the cryptographically secure crypto/rand by default")
 
 	// Mark flags as mutually exclusive
 	mcpCmd.MarkFlagsMutuallyExclusive("enable", "disable")
+	mcpCmd.MarkFlagsMutuallyExclusive("seed", "secure")
 
 	// Add dynamic completion for tool names
 	mcpCmd.RegisterFlagCompletionFunc("enable", toolCompletionFunc)
 	mcpCmd.RegisterFlagCompletionFunc("disable", toolCompletionFunc)
+	mcpCmd.RegisterFlagCompletionFunc("transport", cobra.FixedCompletions(server.Transports(), cobra.ShellCompDirectiveNoFileComp))
+	mcpCmd.RegisterFlagCompletionFunc("profile", profileCompletionFunc)
 }
 
 // getAvailableTools returns a registry with all available tools registered
@@ -61,12 +109,15 @@ func getAvailableTools() *tools.ToolRegistry {
 	registry.RegisterTool(tools.NewTimeTool())
 	registry.RegisterTool(tools.NewRandomTool())
 	registry.RegisterTool(tools.NewUUIDTool())
+	registry.RegisterTool(tools.NewIDTool())
 	registry.RegisterTool(tools.NewIMOTool())
 	registry.RegisterTool(tools.NewMMSITool())
 	registry.RegisterTool(tools.NewCreditCardTool())
 	registry.RegisterTool(tools.NewISBNTool())
 	registry.RegisterTool(tools.NewEAN13Tool())
 	registry.RegisterTool(tools.NewIBANTool())
+	registry.RegisterTool(tools.NewIdentifyTool())
+	registry.RegisterTool(tools.NewScanTool())
 	// TODO: Add more tools as they are implemented
 
 	return registry
@@ -78,15 +129,104 @@ func toolCompletionFunc(cmd *cobra.Command, args []string, toComplete string) ([
 	return registry.ListTools(), cobra.ShellCompDirectiveNoFileComp
 }
 
+// paramCompletionFunc provides shell completion for a flag from the candidate
+// values of the corresponding tool parameter
+func paramCompletionFunc(tool tools.Tool, param string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return tools.CompleteParam(tool, param, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// profileCompletionFunc provides shell completion for profile names
+func profileCompletionFunc(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, _, err := loadConfig()
+	if err != nil || cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cfg.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// loadConfig loads the config file given by --config, or the one at the XDG
+// location. It returns a nil config when no --config is given and the default file does not exist.
+func loadConfig() (*config.Config, string, error) {
+	if mcpConfigFile != "" {
+		cfg, err := config.Load(mcpConfigFile)
+		return cfg, mcpConfigFile, err
+	}
+	return config.LoadDefault()
+}
+
+// loadProfile loads and validates the config file and returns the selected profile, if any
+func loadProfile(registry *tools.ToolRegistry) (*config.Profile, error) {
+	cfg, path, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		if mcpProfile != "" {
+			return nil, fmt.Errorf("profile %s requested but no config file found at %s", mcpProfile, path)
+		}
+		return nil, nil
+	}
+
+	if err := cfg.Validate(registry); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	profile, err := cfg.Profile(mcpProfile)
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	return profile, nil
+}
+
+// applyProfile copies the profile's settings into the mcp flags that were not set on the command line
+func applyProfile(cmd *cobra.Command, profile *config.Profile) {
+	flags := cmd.Flags()
+	if !flags.Changed("enable") && !flags.Changed("disable") {
+		enableTools = profile.Enable
+		disableTools = profile.Disable
+	}
+	if !flags.Changed("transport") && profile.Transport != "" {
+		mcpTransport = profile.Transport
+	}
+	if !flags.Changed("listen") && profile.Listen != "" {
+		mcpListenAddr = profile.Listen
+	}
+	if !flags.Changed("debug") && profile.Debug {
+		debugMode = true
+	}
+	if !flags.Changed("log-file") && profile.LogFile != "" {
+		logFile = profile.LogFile
+	}
+	if !flags.Changed("tool-manager") && profile.ToolManager {
+		mcpToolManager = true
+	}
+}
+
 func runMCP(cmd *cobra.Command, args []string) error {
+	// Get available tools for validation
+	registry := getAvailableTools()
+	availableTools := registry.ListTools()
+
+	// Apply the selected config profile, if any
+	profile, err := loadProfile(registry)
+	if err != nil {
+		return err
+	}
+	if profile != nil {
+		applyProfile(cmd, profile)
+	}
+
 	// Validate that enable and disable are not both used
 	if len(enableTools) > 0 && len(disableTools) > 0 {
 		return fmt.Errorf("--enable and --disable flags are mutually exclusive")
 	}
 
-	// Get available tools for validation
-	registry := getAvailableTools()
-	availableTools := registry.ListTools()
+	// Validate transport
+	if !slices.Contains(server.Transports(), mcpTransport) {
+		return fmt.Errorf("invalid transport: %s. Available transports: %s", mcpTransport, strings.Join(server.Transports(), ", "))
+	}
 
 	// Validate enable tools
 	if len(enableTools) > 0 {
@@ -128,6 +268,12 @@ func runMCP(cmd *cobra.Command, args []string) error {
 
 	// Create MCP server
 	srv := server.NewServer()
+	srv.SetTransport(mcpTransport)
+	srv.SetListenAddr(mcpListenAddr)
+	if mcpSeed.set {
+		srv.SetSeed(mcpSeed.value)
+	}
+	srv.SetSecure(mcpSecure)
 
 	// Configure debug mode if requested
 	if debugMode {
@@ -150,15 +296,29 @@ func runMCP(cmd *cobra.Command, args []string) error {
 		srv.SetLogWriter(logWriter)
 	}
 
-	// Register only the enabled tools with the MCP server
-	for _, toolName := range enabledTools {
-		if tool, exists := registry.GetTool(toolName); exists {
-			srv.RegisterTool(tool)
+	// Register all tools with the MCP server, offering only the enabled ones.
+	// The others stay available to the tool manager.
+	for _, toolName := range availableTools {
+		tool, _ := registry.GetTool(toolName)
+		if profile != nil {
+			// Fill in the profile's default parameters for this tool
+			tool = tools.WithDefaults(tool, profile.Defaults[toolName])
+		}
+		srv.RegisterTool(tool)
+		if !slices.Contains(enabledTools, toolName) {
+			if err := srv.SetToolEnabled(toolName, false); err != nil {
+				return err
+			}
 		}
 	}
+	srv.SetToolManager(mcpToolManager)
 
-	// Start the MCP server
-	ctx := context.Background()
+	// Offer the workflow prompts that combine several tools; each is listed only
+	// while the tools it uses are enabled
+	srv.RegisterPromptProvider(tools.NewWorkflowPrompts())
+
+	// Start the MCP server, stopping gracefully on interrupt or termination
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	return srv.Start(ctx)
 }
-
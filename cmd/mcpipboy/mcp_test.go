@@ -2,7 +2,14 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/config"
+	"github.com/spf13/cobra"
 )
 
 func TestMCPCommand(t *testing.T) {
@@ -27,6 +34,42 @@ func TestMCPCommandFlags(t *testing.T) {
 	if disableFlag == nil {
 		t.Error("MCP command should have --disable flag")
 	}
+
+	transportFlag := mcpCmd.Flag("transport")
+	if transportFlag == nil {
+		t.Error("MCP command should have --transport flag")
+	} else if transportFlag.DefValue != "stdio" {
+		t.Errorf("Expected default transport to be 'stdio', got '%s'", transportFlag.DefValue)
+	}
+
+	if mcpCmd.Flag("listen") == nil {
+		t.Error("MCP command should have --listen flag")
+	}
+
+	if mcpCmd.Flag("config") == nil {
+		t.Error("MCP command should have --config flag")
+	}
+
+	if mcpCmd.Flag("profile") == nil {
+		t.Error("MCP command should have --profile flag")
+	}
+
+	toolManagerFlag := mcpCmd.Flag("tool-manager")
+	if toolManagerFlag == nil {
+		t.Error("MCP command should have --tool-manager flag")
+	} else if toolManagerFlag.DefValue != "false" {
+		t.Errorf("Expected tool manager to be off by default, got '%s'", toolManagerFlag.DefValue)
+	}
+}
+
+func TestRunMCPInvalidTransport(t *testing.T) {
+	mcpTransport = "carrier-pigeon"
+	defer func() { mcpTransport = "stdio" }()
+
+	err := runMCP(mcpCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid transport") {
+		t.Errorf("Expected invalid transport error, got %v", err)
+	}
 }
 
 func TestRunMCP(t *testing.T) {
@@ -50,3 +93,153 @@ func TestRunMCP(t *testing.T) {
 	}
 }
 
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	os.WriteFile(configPath, []byte(`{
+		"profiles": {
+			"maritime": {"enable": ["imo", "mmsi"], "defaults": {"mmsi": {"country-code": "NO"}}}
+		}
+	}`), 0644)
+	invalidPath := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalidPath, []byte(`{"profiles": {"maritime": {"enable": ["imoo"]}}}`), 0644)
+
+	// Keep the user's own config file out of the test
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	defer func() {
+		mcpConfigFile = ""
+		mcpProfile = ""
+	}()
+
+	tests := []struct {
+		name        string
+		configFile  string
+		profile     string
+		expectNil   bool
+		expectedErr string
+	}{
+		{
+			name:      "no config file",
+			expectNil: true,
+		},
+		{
+			name:        "profile without config file",
+			profile:     "maritime",
+			expectedErr: "profile maritime requested but no config file found",
+		},
+		{
+			name:       "named profile",
+			configFile: configPath,
+			profile:    "maritime",
+		},
+		{
+			name:        "unknown profile",
+			configFile:  configPath,
+			profile:     "finance",
+			expectedErr: `profile "finance" not found`,
+		},
+		{
+			name:        "invalid config file",
+			configFile:  invalidPath,
+			profile:     "maritime",
+			expectedErr: `invalid config file ` + invalidPath + `: profile "maritime": enable: invalid tool: imoo`,
+		},
+		{
+			name:        "missing config file",
+			configFile:  filepath.Join(dir, "missing.json"),
+			expectedErr: "failed to read config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcpConfigFile = tt.configFile
+			mcpProfile = tt.profile
+
+			profile, err := loadProfile(getAvailableTools())
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("loadProfile() error = %v, want error containing %q", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadProfile() error = %v", err)
+			}
+			if (profile == nil) != tt.expectNil {
+				t.Errorf("loadProfile() = %v, expected nil: %v", profile, tt.expectNil)
+			}
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	defer func() {
+		enableTools, disableTools = []string{}, []string{}
+		mcpTransport, mcpListenAddr = "stdio", "localhost:8080"
+		debugMode, logFile, mcpToolManager = false, "", false
+	}()
+
+	profile := &config.Profile{
+		Enable:      []string{"imo", "mmsi"},
+		Transport:   "http",
+		Listen:      "localhost:9000",
+		Debug:       true,
+		LogFile:     "mcpipboy.log",
+		ToolManager: true,
+	}
+
+	// Settings from the profile fill in flags that were not given
+	applyProfile(&cobra.Command{}, profile)
+	if !slices.Equal(enableTools, []string{"imo", "mmsi"}) || mcpTransport != "http" || mcpListenAddr != "localhost:9000" ||
+		!debugMode || logFile != "mcpipboy.log" || !mcpToolManager {
+		t.Errorf("Profile settings were not applied")
+	}
+
+	// Flags given on the command line take precedence
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSliceVar(&disableTools, "disable", []string{}, "")
+	cmd.Flags().StringVar(&mcpTransport, "transport", "stdio", "")
+	cmd.Flags().Set("disable", "echo")
+	cmd.Flags().Set("transport", "sse")
+	enableTools = []string{}
+
+	applyProfile(cmd, profile)
+	if len(enableTools) != 0 || !slices.Equal(disableTools, []string{"echo"}) {
+		t.Errorf("Expected --disable to override the profile's tools, got enable %v, disable %v", enableTools, disableTools)
+	}
+	if mcpTransport != "sse" {
+		t.Errorf("Expected --transport to override the profile, got %s", mcpTransport)
+	}
+}
+
+func TestFlagCompletion(t *testing.T) {
+	tests := []struct {
+		name       string
+		cmd        *cobra.Command
+		flag       string
+		toComplete string
+		want       []string
+	}{
+		{"iban country code", ibanCmd, "country-code", "n", []string{"NL", "NO"}},
+		{"mmsi type", mmsiCmd, "type", "sh", []string{"ship"}},
+		{"credit card type", creditCardCmd, "card-type", "m", []string{"mastercard"}},
+		{"time format", timeCmd, "format", "r", []string{"rfc3339"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completionFunc, ok := tt.cmd.GetFlagCompletionFunc(tt.flag)
+			if !ok {
+				t.Fatalf("Expected a completion function for --%s", tt.flag)
+			}
+			got, directive := completionFunc(tt.cmd, nil, tt.toComplete)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected completions %v, got %v", tt.want, got)
+			}
+			if directive != cobra.ShellCompDirectiveNoFileComp {
+				t.Errorf("Expected ShellCompDirectiveNoFileComp, got %v", directive)
+			}
+		})
+	}
+}
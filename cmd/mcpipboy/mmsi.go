@@ -6,16 +6,14 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
 var (
-	mmsiOperation   string
-	mmsiInput       string
-	mmsiType        string
-	mmsiCountryCode string
-	mmsiCount       int
+	mmsiInputFile string
+	mmsiFailOn    string
 )
 
 // mmsiCmd represents the mmsi command
@@ -41,7 +39,9 @@ Examples:
   mcpipboy mmsi --operation generate --type us-federal
 
   # Generate multiple coast stations for UK
-  mcpipboy mmsi --operation generate --type coast --country-code "GB" --count 5`,
+  mcpipboy mmsi --operation generate --type coast --country-code "GB" --count 5
+  mcpipboy mmsi --input-file mmsis.txt --fail-on all
+  mcpipboy mmsi --operation generate --country-code "NO" --count 5 --seed 42`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runMMSI(cmd, args, os.Stdout)
 	},
@@ -52,36 +52,24 @@ func init() {
 	mmsiCmd.GroupID = "tools"
 
 	// Add flags
-	mmsiCmd.Flags().StringVar(&mmsiOperation, "operation", "validate", "Operation to perform: 'validate' or 'generate'")
-	mmsiCmd.Flags().StringVar(&mmsiInput, "input", "", "MMSI number to validate (required for validation operation)")
-	mmsiCmd.Flags().StringVar(&mmsiType, "type", "", "MMSI type to generate (optional for generation)")
-	mmsiCmd.Flags().StringVar(&mmsiCountryCode, "country-code", "US", "Country code for generation (e.g., US, GB, DE, FR, etc.)")
-	mmsiCmd.Flags().IntVar(&mmsiCount, "count", 1, "Number of MMSI numbers to generate (max: 100)")
+	registerSchemaFlags(mmsiCmd, tools.NewMMSITool())
+	addBulkFlags(mmsiCmd, &mmsiInputFile, &mmsiFailOn)
 }
 
 func runMMSI(cmd *cobra.Command, args []string, out io.Writer) error {
-	// Build parameters map
-	params := make(map[string]interface{})
+	// Create the MMSI tool
+	tool := tools.NewMMSITool()
 
-	// Add parameters if they were set
-	if mmsiOperation != "" {
-		params["operation"] = mmsiOperation
-	}
-	if mmsiInput != "" {
-		params["input"] = mmsiInput
-	}
-	if mmsiType != "" {
-		params["type"] = mmsiType
-	}
-	if mmsiCountryCode != "" {
-		params["country-code"] = mmsiCountryCode
-	}
-	if mmsiCount > 0 {
-		params["count"] = mmsiCount
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
 
-	// Create and execute the MMSI tool
-	tool := tools.NewMMSITool()
+	// Validate the values of a file, one per line
+	if mmsiInputFile != "" {
+		return runBulkValidation(cmd, tool, params, mmsiInputFile, mmsiFailOn, out)
+	}
 
 	// Validate parameters
 	if err := tool.ValidateParams(params); err != nil {
@@ -94,6 +82,11 @@ func runMMSI(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("MMSI tool execution failed: %v", err)
 	}
 
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
 	// Display results
 	switch v := result.(type) {
 	case string:
@@ -106,17 +99,19 @@ func runMMSI(cmd *cobra.Command, args []string, out io.Writer) error {
 		}
 	case map[string]interface{}:
 		// Validation result
-		if valid, ok := v["valid"].(bool); ok {
-			if valid {
-				fmt.Fprintf(out, "Valid MMSI: %s\n", v["mmsi"])
-				if countryName, ok := v["country_name"].(string); ok {
-					fmt.Fprintf(out, "   Country: %s\n", countryName)
-				}
-			} else {
-				fmt.Fprintf(out, "Invalid MMSI: %s\n", v["error"])
-				if input, ok := v["input"].(string); ok {
-					fmt.Fprintf(out, "   Input: %s\n", input)
-				}
+		valid, ok := v["valid"].(bool)
+		if !ok {
+			return output.Write(out, output.FormatText, result, tool.GetOutputSchema())
+		}
+		if valid {
+			fmt.Fprintf(out, "Valid MMSI: %s\n", v["mmsi"])
+			if countryName, ok := v["country_name"].(string); ok {
+				fmt.Fprintf(out, "   Country: %s\n", countryName)
+			}
+		} else {
+			fmt.Fprintf(out, "Invalid MMSI: %s\n", v["error"])
+			if input, ok := v["input"].(string); ok {
+				fmt.Fprintf(out, "   Input: %s\n", input)
 			}
 		}
 	default:
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 )
 
 func TestRunMMSI(t *testing.T) {
@@ -173,23 +175,19 @@ func TestRunMMSIUnit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset and set global variables
-			mmsiOperation = tt.operation
-			mmsiInput = tt.input
-			mmsiCountryCode = tt.countryCode
-			if mmsiCountryCode == "" {
-				mmsiCountryCode = "US"
-			}
-			mmsiCount = tt.count
-			if mmsiCount == 0 {
-				mmsiCount = 1
-			}
+			// Parse the flags of the test case
+			cmd := parseToolFlags(t, tools.NewMMSITool(), flagArgs(map[string]interface{}{
+				"operation":    tt.operation,
+				"input":        tt.input,
+				"country-code": tt.countryCode,
+				"count":        tt.count,
+			})...)
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runMMSI directly
-			err := runMMSI(nil, nil, &buf)
+			err := runMMSI(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
@@ -6,71 +6,89 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
 
 var randomCmd = &cobra.Command{
 	Use:   "random [flags]",
-	Short: "Generate random numbers with various types and distributions",
+	Short: "Generate random numbers, strings, passwords, dates and durations, sample lists, or roll dice",
 	Long: `Random number generator provides comprehensive random number functionality including:
 
 - Integer generation with min/max range
 - Float generation with min/max range and precision control
 - Boolean generation
+- Random strings and passwords with length, character classes, minimum
+  counts per class, ambiguous characters left out, or a custom alphabet
+- Diceware-style passphrases from the EFF large wordlist
+- Entropy estimate in bits for every string, password and passphrase
+- Normal, log-normal, exponential, Poisson, binomial and weighted
+  categorical distributions
+- Shuffling a list, sampling k items with or without replacement, and
+  weighted choice
+- Dice notation with keep/drop highest and lowest, exploding dice,
+  rerolls, modifiers and multiple groups, e.g. "4d6 drop lowest + 2"
+- Random bytes for API keys, nonces and salts in hex, base64, base64url,
+  base32, base58 or Crockford base32, optionally after a prefix
+- Instants and dates within a range, optionally on business days or
+  given weekdays only, in the time command's formats and timezones
+- Durations within a range in Go, ISO 8601 or seconds format
 - Batch generation with count parameter
 
+Passwords, passphrases and bytes draw from crypto/rand unless --seed is given.
+
 Examples:
   mcpipboy random --type integer --min 1 --max 100
   mcpipboy random --type float --min 0 --max 1 --precision 3 --count 5
-  mcpipboy random --type boolean --count 10`,
+  mcpipboy random --type boolean --count 10
+  mcpipboy random --type integer --min 1 --max 6 --count 10 --seed 42
+  mcpipboy random --type string --length 10
+  mcpipboy random --type password --length 20 --min-digits 2 --min-symbols 2 --exclude-ambiguous
+  mcpipboy random --type password --classes lower,digits
+  mcpipboy random --type string --length 8 --alphabet 0123456789abcdef --count 5
+  mcpipboy random --type passphrase --words 5 --separator " " --capitalize
+  mcpipboy random --type normal --mean 170 --stddev 10 --precision 1 --count 5
+  mcpipboy random --type poisson --lambda 4 --count 24
+  mcpipboy random --type categorical --categories 200,404,500 --weights 90,8,2 --count 10
+  mcpipboy random --type shuffle --items alice,bob,carol,dave
+  mcpipboy random --type sample --items alice,bob,carol,dave --k 2
+  mcpipboy random --type choice --items small,medium,large --weights 1,2,4 --k 5
+  mcpipboy random --type dice --notation 4d6dl1 --count 6
+  mcpipboy random --type dice --notation "2d20kh1 + 5"
+  mcpipboy random --type dice --notation "3d6r1! + 1d4 - 1"
+  mcpipboy random --type bytes --bytes 24 --encoding base58 --prefix sk_live_
+  mcpipboy random --type bytes --length 43 --encoding base64url
+  mcpipboy random --type datetime --start 2025-01-01 --end 2025-12-31 --business-days --timezone Europe/Oslo --count 5
+  mcpipboy random --type date --start 2025-06-01 --end 2025-08-31 --weekdays saturday,sunday
+  mcpipboy random --type duration --min-duration 30s --max-duration 15m --format iso --count 10`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runRandom(cmd, args, os.Stdout)
 	},
 }
 
-var (
-	randomType      string
-	randomCount     int
-	randomMin       float64
-	randomMax       float64
-	randomPrecision int
-)
-
 func init() {
 	// Set group ID for random command
 	randomCmd.GroupID = "tools"
 
-	randomCmd.Flags().StringVar(&randomType, "type", "integer", "Type of random number: integer, float, boolean")
-	randomCmd.Flags().IntVar(&randomCount, "count", 1, "Number of random values to generate (1-1000)")
-	randomCmd.Flags().Float64Var(&randomMin, "min", 0, "Minimum value (for integer/float types)")
-	randomCmd.Flags().Float64Var(&randomMax, "max", 100, "Maximum value (for integer/float types)")
-	randomCmd.Flags().IntVar(&randomPrecision, "precision", 2, "Decimal places for float values (0-10)")
+	// Add a flag for each parameter of the tool, so that the command offers
+	// exactly the parameters the MCP server publishes
+	registerSchemaFlags(randomCmd, tools.NewRandomTool())
 
 	// Add command to root
 	rootCmd.AddCommand(randomCmd)
 }
 
 func runRandom(cmd *cobra.Command, args []string, out io.Writer) error {
-	// Build parameters map
-	params := make(map[string]interface{})
+	// Create the random tool
+	tool := tools.NewRandomTool()
 
-	// Add parameters if they were set
-	if randomType != "" {
-		params["type"] = randomType
-	}
-	params["count"] = float64(randomCount)
-	if randomMin != 0 || randomMax != 100 {
-		params["min"] = randomMin
-		params["max"] = randomMax
-	}
-	if randomPrecision != 2 {
-		params["precision"] = float64(randomPrecision)
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
 
-	// Create and execute the random tool
-	tool := tools.NewRandomTool()
-
 	// Validate parameters
 	if err := tool.ValidateParams(params); err != nil {
 		return fmt.Errorf("parameter validation failed: %v", err)
@@ -82,7 +100,6 @@ func runRandom(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("random tool execution failed: %v", err)
 	}
 
-	// Print the result
-	fmt.Fprintln(out, result)
-	return nil
+	// Print the result in the selected output format
+	return output.Write(out, outputFormat, result, tool.GetOutputSchema())
 }
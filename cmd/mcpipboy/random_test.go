@@ -5,6 +5,9 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
 )
 
 func TestRunRandom(t *testing.T) {
@@ -56,6 +59,174 @@ func TestRunRandom(t *testing.T) {
 			expected: "", // Will be 3 random booleans
 			hasError: false,
 		},
+		{
+			name:     "generate_string",
+			args:     []string{"--type", "string", "--length", "10"},
+			expected: "", // Will be a 10-character string with its entropy
+			hasError: false,
+		},
+		{
+			name:     "generate_password_with_policy",
+			args:     []string{"--type", "password", "--length", "20", "--min-digits", "2", "--min-symbols", "2", "--exclude-ambiguous"},
+			expected: "", // Will be a 20-character password
+			hasError: false,
+		},
+		{
+			name:     "generate_password_with_classes",
+			args:     []string{"--type", "password", "--classes", "lower,digits", "--count", "3"},
+			expected: "", // Will be 3 passwords of lowercase letters and digits
+			hasError: false,
+		},
+		{
+			name:     "generate_string_with_alphabet",
+			args:     []string{"--type", "string", "--alphabet", "0123456789abcdef", "--length", "8"},
+			expected: "", // Will be an 8-character hexadecimal string
+			hasError: false,
+		},
+		{
+			name:     "generate_passphrase",
+			args:     []string{"--type", "passphrase", "--words", "5", "--separator", " ", "--capitalize"},
+			expected: "", // Will be a 5-word passphrase
+			hasError: false,
+		},
+		{
+			name:     "generate_normal",
+			args:     []string{"--type", "normal", "--mean", "170", "--stddev", "10", "--count", "5"},
+			expected: "", // Will be 5 normally distributed floats
+			hasError: false,
+		},
+		{
+			name:     "generate_poisson",
+			args:     []string{"--type", "poisson", "--lambda", "4", "--count", "3"},
+			expected: "", // Will be 3 event counts
+			hasError: false,
+		},
+		{
+			name:     "generate_categorical",
+			args:     []string{"--type", "categorical", "--categories", "200,404,500", "--weights", "90,8,2", "--count", "5"},
+			expected: "", // Will be 5 status codes
+			hasError: false,
+		},
+		{
+			name:     "shuffle_items",
+			args:     []string{"--type", "shuffle", "--items", "a,b,c,d"},
+			expected: "", // Will be the items in random order
+			hasError: false,
+		},
+		{
+			name:     "sample_items",
+			args:     []string{"--type", "sample", "--items", "a,b,c,d", "--k", "2"},
+			expected: "", // Will be 2 distinct items
+			hasError: false,
+		},
+		{
+			name:     "weighted_choice_without_replacement",
+			args:     []string{"--type", "choice", "--items", "s,m,l", "--weights", "1,2,4", "--k", "3", "--replacement=false"},
+			expected: "", // Will be the 3 items in weighted order
+			hasError: false,
+		},
+		{
+			name:     "sample_exceeds_items",
+			args:     []string{"--type", "sample", "--items", "a,b", "--k", "3"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "categorical_without_weights",
+			args:     []string{"--type", "categorical", "--categories", "a,b"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "roll_dice",
+			args:     []string{"--type", "dice", "--notation", "4d6 drop lowest + 2"},
+			expected: "", // Will be the rolls, kept dice and total
+			hasError: false,
+		},
+		{
+			name:     "roll_dice_groups",
+			args:     []string{"--type", "dice", "--notation", "3d6r1!+1d4-1", "--count", "3", "--seed", "42"},
+			expected: "", // Will be 3 reproducible rolls
+			hasError: false,
+		},
+		{
+			name:     "dice_without_notation",
+			args:     []string{"--type", "dice"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "dice_invalid_notation",
+			args:     []string{"--type", "dice", "--notation", "1d1!"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "generate_bytes_with_prefix",
+			args:     []string{"--type", "bytes", "--bytes", "24", "--encoding", "base58", "--prefix", "sk_live_"},
+			expected: "", // Will be an API key such as sk_live_4PeeQq...
+			hasError: false,
+		},
+		{
+			name:     "generate_bytes_by_length",
+			args:     []string{"--type", "bytes", "--length", "43", "--encoding", "base64url", "--count", "2"},
+			expected: "", // Will be 2 tokens of 32 bytes
+			hasError: false,
+		},
+		{
+			name:     "bytes_length_does_not_fit_encoding",
+			args:     []string{"--type", "bytes", "--length", "41", "--encoding", "base64url"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "generate_business_datetimes",
+			args:     []string{"--type", "datetime", "--start", "2025-01-01", "--end", "2025-12-31", "--business-days", "--timezone", "Europe/Oslo", "--count", "3"},
+			expected: "", // Will be 3 instants on weekdays in 2025
+			hasError: false,
+		},
+		{
+			name:     "generate_weekend_dates",
+			args:     []string{"--type", "date", "--start", "2025-06-01", "--end", "2025-08-31", "--weekdays", "saturday,sunday"},
+			expected: "", // Will be a summer weekend date
+			hasError: false,
+		},
+		{
+			name:     "generate_iso_durations",
+			args:     []string{"--type", "duration", "--min-duration", "30s", "--max-duration", "15m", "--format", "iso", "--count", "2"},
+			expected: "", // Will be 2 durations such as PT4M12S
+			hasError: false,
+		},
+		{
+			name:     "date_start_after_end",
+			args:     []string{"--type", "date", "--start", "2025-12-31", "--end", "2025-01-01"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "duration_min_greater_than_max",
+			args:     []string{"--type", "duration", "--min-duration", "2h", "--max-duration", "1h"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "password_minimums_exceed_length",
+			args:     []string{"--type", "password", "--length", "4", "--min-digits", "4"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "password_invalid_class",
+			args:     []string{"--type", "password", "--classes", "emoji"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "passphrase_too_many_words",
+			args:     []string{"--type", "passphrase", "--words", "65"},
+			expected: "",
+			hasError: true,
+		},
 		{
 			name:     "invalid_type",
 			args:     []string{"--type", "invalid"},
@@ -139,6 +310,13 @@ func TestRandomCmdFlags(t *testing.T) {
 	if randomCmd.Flags().Lookup("precision") == nil {
 		t.Error("--precision flag not found")
 	}
+	for _, name := range []string{"length", "classes", "min-lower", "min-upper", "min-digits", "min-symbols", "exclude-ambiguous", "alphabet", "words", "separator", "capitalize",
+		"mean", "stddev", "mu", "sigma", "rate", "lambda", "trials", "probability", "categories", "weights", "items", "k", "replacement", "notation", "bytes", "encoding", "prefix",
+		"start", "end", "timezone", "format", "weekdays", "business-days", "min-duration", "max-duration", "resolution"} {
+		if randomCmd.Flags().Lookup(name) == nil {
+			t.Errorf("--%s flag not found", name)
+		}
+	}
 }
 
 func TestRandomCmdHelp(t *testing.T) {
@@ -165,63 +343,60 @@ func TestRandomCmdGroup(t *testing.T) {
 func TestRunRandomUnit(t *testing.T) {
 	tests := []struct {
 		name        string
-		randType    string
-		count       int
-		min         float64
-		max         float64
-		precision   int
+		args        []string
 		expectError bool
 	}{
 		{
 			name:        "generate integer",
-			randType:    "integer",
-			count:       1,
-			min:         1,
-			max:         100,
+			args:        []string{"--type", "integer", "--min", "1", "--max", "100"},
 			expectError: false,
 		},
 		{
 			name:        "generate float",
-			randType:    "float",
-			count:       1,
-			min:         0,
-			max:         1,
-			precision:   3,
+			args:        []string{"--type", "float", "--min", "0", "--max", "1", "--precision", "3"},
 			expectError: false,
 		},
 		{
 			name:        "generate boolean",
-			randType:    "boolean",
-			count:       1,
+			args:        []string{"--type", "boolean"},
 			expectError: false,
 		},
 		{
 			name:        "generate multiple integers",
-			randType:    "integer",
-			count:       5,
-			min:         1,
-			max:         100,
+			args:        []string{"--type", "integer", "--count", "5", "--min", "1", "--max", "100"},
 			expectError: false,
 		},
+		{
+			name:        "min without max",
+			args:        []string{"--type", "integer", "--min", "50"},
+			expectError: false,
+		},
+		{
+			name:        "max of zero",
+			args:        []string{"--type", "integer", "--min", "-10", "--max", "0"},
+			expectError: false,
+		},
+		{
+			name:        "min above default max",
+			args:        []string{"--type", "float", "--min", "5"},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset and set global variables
-			randomType = tt.randType
-			randomCount = tt.count
-			randomMin = tt.min
-			randomMax = tt.max
-			randomPrecision = tt.precision
-			if randomCount == 0 {
-				randomCount = 1
+			// Parse the flags into a command of its own
+			cmd := &cobra.Command{Use: "random"}
+			registerSchemaFlags(cmd, tools.NewRandomTool())
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
 			}
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runRandom directly
-			err := runRandom(nil, nil, &buf)
+			err := runRandom(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
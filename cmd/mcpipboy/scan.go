@@ -0,0 +1,146 @@
+// Package main provides the scan command for mcpipboy
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanInputFile string
+	scanTypes     []string
+	scanMask      bool
+)
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan [text]",
+	Short: "Find and validate identifiers in free text",
+	Long: `Find candidate identifiers in free text, such as an email or the text extracted from a PDF,
+and validate each one with the corresponding tool. IBANs, credit card numbers, ISBNs, IMO
+and MMSI numbers and UUIDs are recognised in their usual formatting, e.g. "IMO 9176187" or
+"GB82 WEST 1234 5698 7654 32". IMO and MMSI numbers need their label to be recognised.
+
+Every candidate is listed with its character offsets, type, normalized value and validity.
+With --mask, card numbers are masked except for their last four digits, and the masked
+text is printed as well.`,
+	Example: `  mcpipboy scan "Vessel IMO 9176187, paid from GB82 WEST 1234 5698 7654 32"
+  mcpipboy scan --input-file email.txt --types iban,creditcard
+  pdftotext invoice.pdf - | mcpipboy scan --input-file - --mask --output json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScan(cmd, args, os.Stdout)
+	},
+}
+
+func init() {
+	scanCmd.Flags().StringVar(&scanInputFile, "input-file", "", "File with the text to scan, or - for stdin")
+	scanCmd.Flags().StringSliceVar(&scanTypes, "types", nil, "Comma-separated kinds of identifier to look for (default: all)")
+	scanCmd.Flags().BoolVar(&scanMask, "mask", false, "Mask card numbers except for their last four digits")
+	scanCmd.RegisterFlagCompletionFunc("types", scanTypesCompletionFunc)
+
+	scanCmd.GroupID = "tools"
+	rootCmd.AddCommand(scanCmd)
+}
+
+// scanTypesCompletionFunc completes the last element of the comma-separated
+// --types list, keeping the elements typed before it
+func scanTypesCompletionFunc(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	head := toComplete[:strings.LastIndex(toComplete, ",")+1]
+	values := tools.CompleteParam(tools.NewScanTool(), "types", toComplete[len(head):])
+	for i, value := range values {
+		values[i] = head + value
+	}
+	return values, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runScan(cmd *cobra.Command, args []string, out io.Writer) error {
+	tool := tools.NewScanTool()
+
+	text, err := scanText(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	params := map[string]interface{}{
+		"text": text,
+	}
+	if len(scanTypes) > 0 {
+		params["types"] = scanTypes
+	}
+	if scanMask {
+		params["mask"] = true
+	}
+
+	if err := tool.ValidateParams(params); err != nil {
+		return fmt.Errorf("parameter validation failed: %v", err)
+	}
+
+	result, err := tool.Execute(params)
+	if err != nil {
+		return fmt.Errorf("execution failed: %v", err)
+	}
+
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
+	resultMap := result.(map[string]interface{})
+	matches := resultMap["matches"].([]map[string]interface{})
+	if len(matches) == 0 {
+		fmt.Fprintln(out, "No identifiers found")
+	}
+	for _, match := range matches {
+		status := "valid"
+		if valid, _ := match["valid"].(bool); !valid {
+			status = fmt.Sprintf("invalid (%s)", match["error"])
+		}
+		fmt.Fprintf(out, "%d-%d %s %s: %s\n", match["start"], match["end"], match["type"], match["value"], status)
+	}
+
+	if masked, ok := resultMap["masked_text"].(string); ok {
+		fmt.Fprintf(out, "\nMasked text:\n%s\n", masked)
+	}
+
+	return nil
+}
+
+// scanText returns the text to scan: the argument, or the contents of --input-file
+func scanText(cmd *cobra.Command, args []string) (string, error) {
+	if len(args) > 0 && scanInputFile != "" {
+		return "", fmt.Errorf("give the text as an argument or with --input-file, not both")
+	}
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if scanInputFile == "" {
+		return "", fmt.Errorf("text argument or --input-file is required")
+	}
+
+	var in io.Reader
+	if scanInputFile == "-" {
+		in = os.Stdin
+		if cmd != nil {
+			in = cmd.InOrStdin()
+		}
+	} else {
+		file, err := os.Open(scanInputFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer file.Close()
+		in = file
+	}
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input file: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunScan(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "email.txt")
+	if err := os.WriteFile(file, []byte("Please pay GB82 WEST 1234 5698 7654 32 for IMO 9176187."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		inputFile   string
+		types       []string
+		mask        bool
+		format      string
+		contains    []string
+		excludes    []string
+		expectError bool
+	}{
+		{
+			name:     "text argument",
+			args:     []string{"Vessel IMO 9176187"},
+			format:   "text",
+			contains: []string{"11-18 imo 9176187: valid"},
+		},
+		{
+			name:      "input file",
+			inputFile: file,
+			format:    "text",
+			contains:  []string{"iban GB82WEST12345698765432: valid", "imo 9176187: valid"},
+		},
+		{
+			name:      "types filter",
+			inputFile: file,
+			types:     []string{"imo"},
+			format:    "text",
+			contains:  []string{"imo 9176187: valid"},
+			excludes:  []string{"iban"},
+		},
+		{
+			name:     "mask",
+			args:     []string{"card 4111 1111 1111 1112"},
+			mask:     true,
+			format:   "text",
+			contains: []string{"creditcard ************1112: invalid (invalid check digit)", "Masked text:\ncard **** **** **** 1112\n"},
+			excludes: []string{"4111"},
+		},
+		{
+			name:     "json",
+			args:     []string{"nothing here"},
+			format:   "json",
+			contains: []string{`"count": 0`},
+		},
+		{
+			name:        "no text",
+			format:      "text",
+			expectError: true,
+		},
+		{
+			name:        "text and input file",
+			args:        []string{"IMO 9176187"},
+			inputFile:   file,
+			format:      "text",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanInputFile, scanTypes, scanMask, outputFormat = tt.inputFile, tt.types, tt.mask, tt.format
+			t.Cleanup(func() {
+				scanInputFile, scanTypes, scanMask, outputFormat = "", nil, false, "text"
+			})
+
+			var buf bytes.Buffer
+			err := runScan(nil, tt.args, &buf)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, got %q", want, output)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("Expected output not to contain %q, got %q", unwanted, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Package main provides the --seed flag of the mcp command
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// seedFlag is the value of a --seed flag. Unlike a plain int flag it records
+// whether the flag was given, so that a seed of 0 is honoured.
+type seedFlag struct {
+	value int64
+	set   bool
+}
+
+// String returns the seed, or an empty string when no seed was given
+func (f *seedFlag) String() string {
+	if !f.set {
+		return ""
+	}
+	return strconv.FormatInt(f.value, 10)
+}
+
+// Set parses the seed from the command line
+func (f *seedFlag) Set(s string) error {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("seed must be an integer")
+	}
+	f.value, f.set = value, true
+	return nil
+}
+
+// Type returns the type name shown in the flag usage
+func (f *seedFlag) Type() string {
+	return "int"
+}
+
+// apply adds the seed parameter when the flag was given
+func (f *seedFlag) apply(params map[string]interface{}) {
+	if f.set {
+		params["seed"] = f.value
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestSeedFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected map[string]interface{}
+		wantErr  bool
+	}{
+		{"not given", nil, map[string]interface{}{}, false},
+		{"zero", []string{"--seed", "0"}, map[string]interface{}{"seed": int64(0)}, false},
+		{"large", []string{"--seed", "9007199254740993"}, map[string]interface{}{"seed": int64(9007199254740993)}, false},
+		{"negative", []string{"--seed=-5"}, map[string]interface{}{"seed": int64(-5)}, false},
+		{"not an integer", []string{"--seed", "abc"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seed seedFlag
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.Var(&seed, "seed", "")
+			err := flags.Parse(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			params := map[string]interface{}{}
+			seed.apply(params)
+			if len(params) != len(tt.expected) || params["seed"] != tt.expected["seed"] {
+				t.Errorf("Expected params %v, got %v", tt.expected, params)
+			}
+		})
+	}
+}
@@ -56,6 +56,10 @@ func init() {
 	timeCmd.Flags().StringVar(&timeTo, "to", "", "End timestamp for relative calculations")
 	timeCmd.Flags().StringVar(&timeOffset, "offset", "", "Time offset (e.g., +1h, -2d, +30m)")
 
+	// Add completion for values the tool knows
+	timeCmd.RegisterFlagCompletionFunc("format", paramCompletionFunc(tools.NewTimeTool(), "format"))
+	timeCmd.RegisterFlagCompletionFunc("timezone", paramCompletionFunc(tools.NewTimeTool(), "timezone"))
+
 	// Validation is handled in runTime function
 
 	// Add command to root
@@ -102,6 +106,11 @@ func runTime(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("time tool execution failed: %v", err)
 	}
 
+	// Print machine-readable output when requested
+	if handled, err := writeOutput(out, tool, result); handled {
+		return err
+	}
+
 	// Output the result
 	if resultMap, ok := result.(map[string]interface{}); ok {
 		// Handle relative time results
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
@@ -16,66 +17,53 @@ var uuidCmd = &cobra.Command{
 	Long: `UUID generator and validator provides comprehensive UUID functionality including:
 
 - UUID v1 (time-based) generation
+- UUID v3 (name-based MD5) generation
 - UUID v4 (random) generation  
 - UUID v5 (name-based SHA-1) generation
-- UUID v7 (time-ordered) generation
-- UUID validation for any version
+- UUID v6 (reordered time) generation
+- UUID v7 (time-ordered) generation, in order within a millisecond and
+  across calls by a 12-bit counter
+- Custom timestamps for v6 and v7 to backfill historical data
+- UUID v8 (custom) generation from your own bits
+- UUID validation for any version, decoding the timestamp, clock
+  sequence and node of v1, v6 and v7 UUIDs
 - Batch generation with count parameter
 
 Examples:
   mcpipboy uuid --version v4
   mcpipboy uuid --version v7 --count 10
   mcpipboy uuid --version v5 --namespace "6ba7b810-9dad-11d1-80b4-00c04fd430c8" --name "example"
-  mcpipboy uuid --version validate --input "550e8400-e29b-41d4-a716-446655440000"`,
+  mcpipboy uuid --version v3 --namespace "6ba7b810-9dad-11d1-80b4-00c04fd430c8" --name "example.com"
+  mcpipboy uuid --version v6 --count 5
+  mcpipboy uuid --version v7 --timestamp 2020-03-01T12:00:00Z --count 3
+  mcpipboy uuid --version v8 --custom 0123456789abcdef0123456789abcdef
+  mcpipboy uuid --version validate --input "550e8400-e29b-41d4-a716-446655440000"
+  mcpipboy uuid --version v4 --count 3 --seed 42`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runUUID(cmd, args, os.Stdout)
 	},
 }
 
-var (
-	uuidVersion   string
-	uuidCount     int
-	uuidNamespace string
-	uuidName      string
-	uuidInput     string
-)
-
 func init() {
 	// Set group ID for uuid command
 	uuidCmd.GroupID = "tools"
 
-	uuidCmd.Flags().StringVar(&uuidVersion, "version", "v4", "UUID version: v1, v4, v5, v7, validate")
-	uuidCmd.Flags().IntVar(&uuidCount, "count", 1, "Number of UUIDs to generate (1-1000)")
-	uuidCmd.Flags().StringVar(&uuidNamespace, "namespace", "", "Namespace UUID for v5 generation")
-	uuidCmd.Flags().StringVar(&uuidName, "name", "", "Name for v5 generation")
-	uuidCmd.Flags().StringVar(&uuidInput, "input", "", "UUID string to validate")
+	registerSchemaFlags(uuidCmd, tools.NewUUIDTool())
 
 	// Add command to root
 	rootCmd.AddCommand(uuidCmd)
 }
 
 func runUUID(cmd *cobra.Command, args []string, out io.Writer) error {
-	// Build parameters map
-	params := make(map[string]interface{})
+	// Create the UUID tool
+	tool := tools.NewUUIDTool()
 
-	// Add parameters if they were set
-	if uuidVersion != "" {
-		params["version"] = uuidVersion
-	}
-	params["count"] = float64(uuidCount)
-	if uuidNamespace != "" {
-		params["namespace"] = uuidNamespace
-	}
-	if uuidName != "" {
-		params["name"] = uuidName
-	}
-	if uuidInput != "" {
-		params["input"] = uuidInput
+	// Build parameters from the flags given on the command line
+	params, err := toolParams(cmd, tool)
+	if err != nil {
+		return err
 	}
 
-	// Create and execute the UUID tool
-	tool := tools.NewUUIDTool()
-
 	// Validate parameters
 	if err := tool.ValidateParams(params); err != nil {
 		return fmt.Errorf("parameter validation failed: %v", err)
@@ -87,7 +75,6 @@ func runUUID(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("UUID tool execution failed: %v", err)
 	}
 
-	// Print the result
-	fmt.Fprintln(out, result)
-	return nil
+	// Print the result in the selected output format
+	return output.Write(out, outputFormat, result, tool.GetOutputSchema())
 }
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
 )
 
 func TestRunUUID(t *testing.T) {
@@ -44,6 +46,36 @@ func TestRunUUID(t *testing.T) {
 			expected: "", // Will be a UUID v7
 			hasError: false,
 		},
+		{
+			name:     "generate_v3_with_namespace_and_name",
+			args:     []string{"--version", "v3", "--namespace", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "--name", "example.com"},
+			expected: "", // Will be a UUID v3
+			hasError: false,
+		},
+		{
+			name:     "generate_v6",
+			args:     []string{"--version", "v6", "--count", "3"},
+			expected: "", // Will be 3 UUID v6
+			hasError: false,
+		},
+		{
+			name:     "generate_v8_with_custom",
+			args:     []string{"--version", "v8", "--custom", "0123456789abcdef0123456789abcdef"},
+			expected: "", // Will be 01234567-89ab-8def-8123-456789abcdef
+			hasError: false,
+		},
+		{
+			name:     "generate_v7_with_timestamp",
+			args:     []string{"--version", "v7", "--timestamp", "2020-03-01T12:00:00Z", "--count", "3"},
+			expected: "", // Will be 3 UUID v7 of 2020-03-01, in order
+			hasError: false,
+		},
+		{
+			name:     "generate_v6_with_sub_second_timestamp",
+			args:     []string{"--version", "v6", "--timestamp", "1999-12-31T23:59:59.9999999Z"},
+			expected: "", // Will be a UUID v6 of the last tick of 1999
+			hasError: false,
+		},
 		{
 			name:     "validate_valid_uuid",
 			args:     []string{"--version", "validate", "--input", "550e8400-e29b-41d4-a716-446655440000"},
@@ -86,6 +118,18 @@ func TestRunUUID(t *testing.T) {
 			expected: "",
 			hasError: true,
 		},
+		{
+			name:     "v8_without_custom",
+			args:     []string{"--version", "v8"},
+			expected: "",
+			hasError: true,
+		},
+		{
+			name:     "v4_with_timestamp",
+			args:     []string{"--version", "v4", "--timestamp", "2020-03-01T12:00:00Z"},
+			expected: "",
+			hasError: true,
+		},
 		{
 			name:     "validate_without_input",
 			args:     []string{"--version", "validate"},
@@ -139,6 +183,12 @@ func TestUUIDCmdFlags(t *testing.T) {
 	if uuidCmd.Flags().Lookup("input") == nil {
 		t.Error("--input flag not found")
 	}
+	if uuidCmd.Flags().Lookup("custom") == nil {
+		t.Error("--custom flag not found")
+	}
+	if uuidCmd.Flags().Lookup("timestamp") == nil {
+		t.Error("--timestamp flag not found")
+	}
 }
 
 func TestUUIDCmdHelp(t *testing.T) {
@@ -205,21 +255,20 @@ func TestRunUUIDUnit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset and set global variables
-			uuidVersion = tt.version
-			uuidCount = tt.count
-			if uuidCount == 0 {
-				uuidCount = 1
-			}
-			uuidNamespace = tt.namespace
-			uuidName = tt.uuidName
-			uuidInput = tt.input
+			// Parse the flags of the test case
+			cmd := parseToolFlags(t, tools.NewUUIDTool(), flagArgs(map[string]interface{}{
+				"version":   tt.version,
+				"count":     tt.count,
+				"namespace": tt.namespace,
+				"name":      tt.uuidName,
+				"input":     tt.input,
+			})...)
 
 			// Create a buffer to capture output
 			var buf bytes.Buffer
 
 			// Call runUUID directly
-			err := runUUID(nil, nil, &buf)
+			err := runUUID(cmd, nil, &buf)
 
 			if tt.expectError {
 				if err == nil {
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/kluzzebass/mcpipboy/internal/output"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/spf13/cobra"
 )
@@ -45,7 +46,6 @@ func runVersion(cmd *cobra.Command, args []string, out io.Writer) error {
 		return fmt.Errorf("version execution failed: %v", err)
 	}
 
-	// Output the result
-	fmt.Fprintln(out, result)
-	return nil
+	// Print the result in the selected output format
+	return output.Write(out, outputFormat, result, versionTool.GetOutputSchema())
 }
@@ -3,17 +3,17 @@ module github.com/kluzzebass/mcpipboy
 go 1.25.1
 
 require (
+	github.com/google/jsonschema-go v0.3.0
 	github.com/google/uuid v1.6.0
 	github.com/ijt/go-anytime v1.9.2
 	github.com/ijt/go-anytime/v2 v2.1.1
 	github.com/modelcontextprotocol/go-sdk v1.0.0
 	github.com/spf13/cobra v1.10.1
+	github.com/spf13/pflag v1.0.10
 )
 
 require (
-	github.com/google/jsonschema-go v0.3.0 // indirect
 	github.com/ijt/goparsify v0.0.0-20221203142333-3a5276334b8d // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.10 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 )
@@ -0,0 +1,194 @@
+// Package config provides the mcpipboy configuration file and its named profiles
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kluzzebass/mcpipboy/internal/server"
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+)
+
+// Config is the contents of a mcpipboy configuration file
+type Config struct {
+	// DefaultProfile is used when no profile is selected on the command line
+	DefaultProfile string `json:"defaultProfile,omitempty"`
+
+	// Profiles maps profile names to server configurations
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+// Profile is a named server configuration
+type Profile struct {
+	// Enable lists the tools to offer (mutually exclusive with Disable)
+	Enable []string `json:"enable,omitempty"`
+
+	// Disable lists the tools not to offer (mutually exclusive with Enable)
+	Disable []string `json:"disable,omitempty"`
+
+	// Defaults maps tool names to default parameters, e.g. {"iban": {"country-code": "DE"}}
+	Defaults map[string]map[string]interface{} `json:"defaults,omitempty"`
+
+	// Transport is the MCP transport to serve (stdio, http or sse)
+	Transport string `json:"transport,omitempty"`
+
+	// Listen is the address the HTTP-based transports listen on
+	Listen string `json:"listen,omitempty"`
+
+	// Debug enables debug logging of MCP protocol messages
+	Debug bool `json:"debug,omitempty"`
+
+	// LogFile is the file debug logs are written to (default: stderr)
+	LogFile string `json:"logFile,omitempty"`
+
+	// ToolManager offers the built-in tool manager
+	ToolManager bool `json:"toolManager,omitempty"`
+}
+
+// DefaultPath returns the XDG location of the configuration file:
+// $XDG_CONFIG_HOME/mcpipboy/config.json, falling back to ~/.config/mcpipboy/config.json
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine config directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "mcpipboy", "config.json"), nil
+}
+
+// Load reads and parses the configuration file at path. Unknown fields are
+// rejected so that typos are reported instead of silently ignored.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config Config
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// LoadDefault loads the configuration file from the XDG location. It returns
+// a nil config and no error when the file does not exist.
+func LoadDefault() (*Config, string, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	config, err := Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, path, nil
+	}
+	return config, path, err
+}
+
+// Profile returns the named profile, or the default profile when name is empty.
+// It returns nil when no profile is selected and the config has no default.
+func (c *Config) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+		if name == "" {
+			return nil, nil
+		}
+	}
+
+	profile, exists := c.Profiles[name]
+	if !exists {
+		return nil, fmt.Errorf("profile %q not found. Available profiles: %s", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	return profile, nil
+}
+
+// ProfileNames returns the sorted names of all profiles
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks every profile against the tools in the registry
+func (c *Config) Validate(registry *tools.ToolRegistry) error {
+	if c.DefaultProfile != "" {
+		if _, exists := c.Profiles[c.DefaultProfile]; !exists {
+			return fmt.Errorf("defaultProfile: profile %q not found. Available profiles: %s", c.DefaultProfile, strings.Join(c.ProfileNames(), ", "))
+		}
+	}
+
+	for _, name := range c.ProfileNames() {
+		profile := c.Profiles[name]
+		if profile == nil {
+			return fmt.Errorf("profile %q: must be an object", name)
+		}
+		if err := profile.Validate(registry); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the profile against the tools in the registry
+func (p *Profile) Validate(registry *tools.ToolRegistry) error {
+	availableTools := registry.ListTools()
+	sort.Strings(availableTools)
+
+	if len(p.Enable) > 0 && len(p.Disable) > 0 {
+		return fmt.Errorf("enable and disable are mutually exclusive")
+	}
+
+	for _, name := range p.Enable {
+		if !slices.Contains(availableTools, name) {
+			return fmt.Errorf("enable: invalid tool: %s. Available tools: %s", name, strings.Join(availableTools, ", "))
+		}
+	}
+	for _, name := range p.Disable {
+		if !slices.Contains(availableTools, name) {
+			return fmt.Errorf("disable: invalid tool: %s. Available tools: %s", name, strings.Join(availableTools, ", "))
+		}
+	}
+
+	toolNames := make([]string, 0, len(p.Defaults))
+	for name := range p.Defaults {
+		toolNames = append(toolNames, name)
+	}
+	sort.Strings(toolNames)
+
+	for _, name := range toolNames {
+		tool, exists := registry.GetTool(name)
+		if !exists {
+			return fmt.Errorf("defaults: invalid tool: %s. Available tools: %s", name, strings.Join(availableTools, ", "))
+		}
+		if err := tools.ValidateSchema(tool.GetInputSchema(), p.Defaults[name]); err != nil {
+			var validationErr *tools.ValidationError
+			if errors.As(err, &validationErr) && validationErr.Path != "" {
+				return fmt.Errorf("defaults.%s.%s: %s", name, validationErr.Path, validationErr.Message)
+			}
+			return fmt.Errorf("defaults.%s: %w", name, err)
+		}
+	}
+
+	if p.Transport != "" && !slices.Contains(server.Transports(), p.Transport) {
+		return fmt.Errorf("transport: invalid transport: %s. Available transports: %s", p.Transport, strings.Join(server.Transports(), ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+)
+
+// testRegistry returns a registry with a few real tools
+func testRegistry() *tools.ToolRegistry {
+	registry := tools.NewToolRegistry()
+	registry.RegisterTool(tools.NewEchoTool())
+	registry.RegisterTool(tools.NewTimeTool())
+	registry.RegisterTool(tools.NewIMOTool())
+	registry.RegisterTool(tools.NewMMSITool())
+	registry.RegisterTool(tools.NewIBANTool())
+	return registry
+}
+
+// writeConfig writes a config file to a temporary directory and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `{
+		"defaultProfile": "maritime",
+		"profiles": {
+			"maritime": {
+				"enable": ["imo", "mmsi"],
+				"defaults": {"mmsi": {"country-code": "NO"}},
+				"transport": "http",
+				"listen": "localhost:9000",
+				"debug": true,
+				"logFile": "/tmp/mcpipboy.log",
+				"toolManager": true
+			},
+			"finance": {
+				"disable": ["imo", "mmsi"],
+				"defaults": {"iban": {"country-code": "DE"}, "time": {"timezone": "Europe/Berlin"}}
+			}
+		}
+	}`)
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := config.Validate(testRegistry()); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if names := config.ProfileNames(); !slices.Equal(names, []string{"finance", "maritime"}) {
+		t.Errorf("Expected profiles [finance maritime], got %v", names)
+	}
+
+	profile, err := config.Profile("maritime")
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if !slices.Equal(profile.Enable, []string{"imo", "mmsi"}) {
+		t.Errorf("Expected enabled tools [imo mmsi], got %v", profile.Enable)
+	}
+	if profile.Defaults["mmsi"]["country-code"] != "NO" {
+		t.Errorf("Expected mmsi default country NO, got %v", profile.Defaults["mmsi"])
+	}
+	if profile.Transport != "http" || profile.Listen != "localhost:9000" || !profile.Debug || profile.LogFile != "/tmp/mcpipboy.log" || !profile.ToolManager {
+		t.Errorf("Unexpected profile settings: %+v", profile)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "malformed JSON",
+			content:     `{"profiles": `,
+			expectedErr: "invalid config file",
+		},
+		{
+			name:        "unknown field",
+			content:     `{"profiles": {"default": {"enabled": ["echo"]}}}`,
+			expectedErr: `unknown field "enabled"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("Load() error = %v, want error containing %q", err, tt.expectedErr)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() should fail for a missing file")
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() error = %v", err)
+	}
+	if expected := filepath.Join(dir, "mcpipboy", "config.json"); path != expected {
+		t.Errorf("Expected %s, got %s", expected, path)
+	}
+
+	// A missing default config file is not an error
+	config, _, err := LoadDefault()
+	if err != nil || config != nil {
+		t.Errorf("LoadDefault() = %v, %v, want nil, nil", config, err)
+	}
+
+	os.MkdirAll(filepath.Dir(path), 0755)
+	os.WriteFile(path, []byte(`{"profiles": {"default": {}}}`), 0644)
+	config, loadedPath, err := LoadDefault()
+	if err != nil || config == nil || loadedPath != path {
+		t.Errorf("LoadDefault() = %v, %s, %v, want config from %s", config, loadedPath, err, path)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	config := &Config{
+		DefaultProfile: "all",
+		Profiles: map[string]*Profile{
+			"all":      {},
+			"maritime": {Enable: []string{"imo", "mmsi"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		profile     string
+		expected    *Profile
+		expectedErr string
+	}{
+		{"default profile", "", config.Profiles["all"], ""},
+		{"named profile", "maritime", config.Profiles["maritime"], ""},
+		{"unknown profile", "finance", nil, `profile "finance" not found. Available profiles: all, maritime`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := config.Profile(tt.profile)
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("Profile() error = %v, want %q", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Profile() error = %v", err)
+			}
+			if profile != tt.expected {
+				t.Errorf("Profile() = %+v, want %+v", profile, tt.expected)
+			}
+		})
+	}
+
+	// Without a default profile nothing is selected
+	config.DefaultProfile = ""
+	if profile, err := config.Profile(""); profile != nil || err != nil {
+		t.Errorf("Profile() = %v, %v, want nil, nil", profile, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *Config
+		expectedErr string
+	}{
+		{
+			name:        "unknown default profile",
+			config:      &Config{DefaultProfile: "missing", Profiles: map[string]*Profile{"all": {}}},
+			expectedErr: `defaultProfile: profile "missing" not found`,
+		},
+		{
+			name:        "empty profile",
+			config:      &Config{Profiles: map[string]*Profile{"broken": nil}},
+			expectedErr: `profile "broken": must be an object`,
+		},
+		{
+			name:        "enable and disable",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Enable: []string{"echo"}, Disable: []string{"imo"}}}},
+			expectedErr: `profile "p": enable and disable are mutually exclusive`,
+		},
+		{
+			name:        "unknown enabled tool",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Enable: []string{"imoo"}}}},
+			expectedErr: `profile "p": enable: invalid tool: imoo. Available tools: echo, iban, imo, mmsi, time`,
+		},
+		{
+			name:        "unknown disabled tool",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Disable: []string{"teleport"}}}},
+			expectedErr: `profile "p": disable: invalid tool: teleport`,
+		},
+		{
+			name:        "defaults for unknown tool",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Defaults: map[string]map[string]interface{}{"bban": {}}}}},
+			expectedErr: `profile "p": defaults: invalid tool: bban`,
+		},
+		{
+			name:        "unknown default parameter",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Defaults: map[string]map[string]interface{}{"iban": {"country": "DE"}}}}},
+			expectedErr: `profile "p": defaults.iban.country: unknown parameter`,
+		},
+		{
+			name:        "default parameter out of range",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Defaults: map[string]map[string]interface{}{"iban": {"count": float64(500)}}}}},
+			expectedErr: `profile "p": defaults.iban.count: must be at most 100, got 500`,
+		},
+		{
+			name:        "default parameter of wrong type",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Defaults: map[string]map[string]interface{}{"time": {"timezone": float64(2)}}}}},
+			expectedErr: `profile "p": defaults.time.timezone: must be a string, got number 2`,
+		},
+		{
+			name:        "invalid transport",
+			config:      &Config{Profiles: map[string]*Profile{"p": {Transport: "carrier-pigeon"}}},
+			expectedErr: `profile "p": transport: invalid transport: carrier-pigeon`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(testRegistry())
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Package output formats tool results for the command line
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported output formats
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatYAML   = "yaml"
+)
+
+// Formats returns the list of supported output format names
+func Formats() []string {
+	return []string{FormatText, FormatJSON, FormatNDJSON, FormatCSV, FormatYAML}
+}
+
+// Write formats a tool result and writes it to w. The result is first
+// normalized through JSON, so every format sees the same values an MCP client
+// receives. The output schema, as returned by GetOutputSchema, supplies the
+// CSV columns; it may be nil.
+//
+// The JSON format is the stable contract for scripts: it is the tool result
+// exactly as returned in the "result" property of the MCP structured content.
+func Write(w io.Writer, format string, result interface{}, schema map[string]interface{}) error {
+	if !slices.Contains(Formats(), format) {
+		return fmt.Errorf("unsupported output format: %s. Available formats: %s", format, strings.Join(Formats(), ", "))
+	}
+
+	value, err := normalize(result)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, value)
+	case FormatNDJSON:
+		return writeNDJSON(w, value)
+	case FormatCSV:
+		return writeCSV(w, value, schema)
+	case FormatYAML:
+		return writeYAML(w, value)
+	default:
+		return writeText(w, value)
+	}
+}
+
+// normalize converts a result to the generic JSON representation (maps,
+// slices, strings, json.Number, booleans and nil)
+func normalize(result interface{}) (interface{}, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	return value, nil
+}
+
+// newEncoder creates a JSON encoder that writes <, > and & as they are, since
+// the output is not embedded in HTML and dice notation such as 2d6r<3 should
+// stay readable
+func newEncoder(w io.Writer) *json.Encoder {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder
+}
+
+// writeJSON writes the result as indented JSON
+func writeJSON(w io.Writer, value interface{}) error {
+	encoder := newEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// writeNDJSON writes each element of a list result as a compact JSON line, or
+// any other result as a single line
+func writeNDJSON(w io.Writer, value interface{}) error {
+	encoder := newEncoder(w)
+	items, ok := value.([]interface{})
+	if !ok {
+		return encoder.Encode(value)
+	}
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes the result as CSV with a header row. Lists of objects give a
+// row per object and a column per property, single objects a single row, and
+// scalars and lists of scalars a single "result" column.
+func writeCSV(w io.Writer, value interface{}, schema map[string]interface{}) error {
+	rows, isList := value.([]interface{})
+	if !isList {
+		rows = []interface{}{value}
+	}
+
+	// Objects become rows with a column per property; anything else a single column
+	objects := len(rows) > 0
+	for _, row := range rows {
+		if _, ok := row.(map[string]interface{}); !ok {
+			objects = false
+			break
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if !objects {
+		writer.Write([]string{"result"})
+		for _, row := range rows {
+			writer.Write([]string{formatScalar(row)})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	columns := csvColumns(rows, schema)
+	writer.Write(columns)
+	for _, row := range rows {
+		object := row.(map[string]interface{})
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			if cell, ok := object[column]; ok {
+				record[i] = formatScalar(cell)
+			}
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvColumns returns the sorted union of the properties declared for the
+// result in the output schema and the properties present in the rows
+func csvColumns(rows []interface{}, schema map[string]interface{}) []string {
+	set := make(map[string]bool)
+	for _, name := range resultProperties(schema) {
+		set[name] = true
+	}
+	for _, row := range rows {
+		for name := range row.(map[string]interface{}) {
+			set[name] = true
+		}
+	}
+
+	columns := make([]string, 0, len(set))
+	for name := range set {
+		columns = append(columns, name)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// resultProperties returns the property names the output schema declares for
+// the result object, or for the items of a result list
+func resultProperties(schema map[string]interface{}) []string {
+	properties, _ := schema["properties"].(map[string]interface{})
+	result, _ := properties["result"].(map[string]interface{})
+	if items, ok := result["items"].(map[string]interface{}); ok {
+		result = items
+	}
+
+	declared, _ := result["properties"].(map[string]interface{})
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	return names
+}
+
+// writeText writes the result for people: scalars as is, a line per list
+// element and a "key: value" line per object property, with a blank line
+// between the objects of a list
+func writeText(w io.Writer, value interface{}) error {
+	items, isList := value.([]interface{})
+	if !isList {
+		items = []interface{}{value}
+	}
+
+	for i, item := range items {
+		object, isObject := item.(map[string]interface{})
+		if !isObject {
+			if _, err := fmt.Fprintln(w, formatScalar(item)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		for _, key := range sortedKeys(object) {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", key, formatScalar(object[key])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// formatScalar formats a value for a single text line or CSV cell. Strings are
+// written as is, and lists and objects as compact JSON.
+func formatScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	var buf bytes.Buffer
+	newEncoder(&buf).Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// sortedKeys returns the keys of an object in sorted order
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
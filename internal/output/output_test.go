@@ -0,0 +1,105 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	validation := map[string]interface{}{"valid": true, "iban": "GB82WEST12345698765432", "length": 22}
+	listSchema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"result": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"valid": map[string]interface{}{"type": "boolean"},
+						"error": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		result   interface{}
+		schema   map[string]interface{}
+		expected string
+	}{
+		{"text string", FormatText, "hello", nil, "hello\n"},
+		{"text list", FormatText, []string{"a", "b"}, nil, "a\nb\n"},
+		{"text numbers", FormatText, []int64{1, 20}, nil, "1\n20\n"},
+		{"text object", FormatText, validation, nil, "iban: GB82WEST12345698765432\nlength: 22\nvalid: true\n"},
+		{"text list of objects", FormatText, []map[string]interface{}{{"a": 1}, {"a": 2}}, nil, "a: 1\n\na: 2\n"},
+		{"text nested", FormatText, map[string]interface{}{"list": []string{"x"}}, nil, "list: [\"x\"]\n"},
+		{"json string", FormatJSON, "hello", nil, "\"hello\"\n"},
+		{"json list", FormatJSON, []string{"a", "b"}, nil, "[\n  \"a\",\n  \"b\"\n]\n"},
+		{"json keeps integers", FormatJSON, int64(1736430600), nil, "1736430600\n"},
+		{"ndjson list", FormatNDJSON, []interface{}{"a", map[string]interface{}{"b": 1}}, nil, "\"a\"\n{\"b\":1}\n"},
+		{"ndjson object", FormatNDJSON, map[string]interface{}{"b": 1}, nil, "{\"b\":1}\n"},
+		{"csv scalars", FormatCSV, []string{"a", "b,c"}, nil, "result\na\n\"b,c\"\n"},
+		{"csv scalar", FormatCSV, 42, nil, "result\n42\n"},
+		{"csv object", FormatCSV, validation, nil, "iban,length,valid\nGB82WEST12345698765432,22,true\n"},
+		{
+			name:     "csv columns from schema",
+			format:   FormatCSV,
+			result:   []map[string]interface{}{{"valid": true}, {"valid": false, "error": "bad checksum"}},
+			schema:   listSchema,
+			expected: "error,valid\n,true\nbad checksum,false\n",
+		},
+		{"yaml scalar", FormatYAML, "hello", nil, "hello\n"},
+		{"yaml list", FormatYAML, []string{"a", "b"}, nil, "- a\n- b\n"},
+		{"yaml object", FormatYAML, validation, nil, "iban: GB82WEST12345698765432\nlength: 22\nvalid: true\n"},
+		{
+			name:     "yaml list of objects",
+			format:   FormatYAML,
+			result:   []map[string]interface{}{{"a": 1, "b": []string{"x", "y"}}, {"a": 2, "b": []string{}}},
+			expected: "- a: 1\n  b:\n    - x\n    - \"y\"\n- a: 2\n  b: []\n",
+		},
+		{
+			name:     "yaml nested object",
+			format:   FormatYAML,
+			result:   map[string]interface{}{"outer": map[string]interface{}{"inner": nil}, "empty": map[string]interface{}{}},
+			expected: "empty: {}\nouter:\n  inner: null\n",
+		},
+		{
+			name:     "yaml quoting",
+			format:   FormatYAML,
+			result:   []string{"257123450", "true", "", "2025-01-09", "a: b", "- x", "ship"},
+			expected: "- \"257123450\"\n- \"true\"\n- \"\"\n- \"2025-01-09\"\n- \"a: b\"\n- \"- x\"\n- ship\n",
+		},
+		{"yaml empty list", FormatYAML, []string{}, nil, "[]\n"},
+		{"json keeps html characters", FormatJSON, "2d6r<3 & >1", nil, "\"2d6r<3 & >1\"\n"},
+		{"ndjson keeps html characters", FormatNDJSON, []string{"a<b"}, nil, "\"a<b\"\n"},
+		{"text keeps html characters", FormatText, map[string]interface{}{"rolls": []string{"<", "&"}}, nil, "rolls: [\"<\",\"&\"]\n"},
+		{"csv keeps html characters", FormatCSV, []interface{}{[]string{"a>b"}}, nil, "result\n\"[\"\"a>b\"\"]\"\n"},
+		{"yaml keeps html characters", FormatYAML, []string{"a: <b>"}, nil, "- \"a: <b>\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, tt.result, tt.schema); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("Expected:\n%q\ngot:\n%q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "xml", "hello", nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported output format: xml") {
+		t.Errorf("Expected an unsupported format error, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output, got %q", buf.String())
+	}
+}
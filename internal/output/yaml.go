@@ -0,0 +1,146 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writeYAML writes the result as a YAML document
+func writeYAML(w io.Writer, value interface{}) error {
+	var sb strings.Builder
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			sb.WriteString("{}\n")
+		}
+		writeYAMLMapping(&sb, v, 0)
+	case []interface{}:
+		if len(v) == 0 {
+			sb.WriteString("[]\n")
+		}
+		writeYAMLSequence(&sb, v, 0)
+	default:
+		sb.WriteString(yamlScalar(v))
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeYAMLMapping writes the properties of an object in block style
+func writeYAMLMapping(sb *strings.Builder, object map[string]interface{}, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	for _, key := range sortedKeys(object) {
+		sb.WriteString(prefix + yamlString(key) + ":")
+		writeYAMLValue(sb, object[key], indent)
+	}
+}
+
+// writeYAMLSequence writes the elements of a list in block style
+func writeYAMLSequence(sb *strings.Builder, items []interface{}, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	for _, item := range items {
+		object, isObject := item.(map[string]interface{})
+		if !isObject || len(object) == 0 {
+			sb.WriteString(prefix + "-")
+			writeYAMLValue(sb, item, indent)
+			continue
+		}
+
+		// Start the object on the dash line and indent its other properties below
+		var nested strings.Builder
+		writeYAMLMapping(&nested, object, indent+1)
+		sb.WriteString(prefix + "- " + strings.TrimPrefix(nested.String(), prefix+"  "))
+	}
+}
+
+// writeYAMLValue writes the value following a mapping key or sequence dash:
+// scalars and empty collections on the same line, other collections indented
+// on the following lines
+func writeYAMLValue(sb *strings.Builder, value interface{}, indent int) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			sb.WriteString(" {}\n")
+			return
+		}
+		sb.WriteString("\n")
+		writeYAMLMapping(sb, v, indent+1)
+	case []interface{}:
+		if len(v) == 0 {
+			sb.WriteString(" []\n")
+			return
+		}
+		sb.WriteString("\n")
+		writeYAMLSequence(sb, v, indent+1)
+	default:
+		sb.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+// yamlScalar formats a scalar value
+func yamlScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return yamlString(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return yamlString(fmt.Sprint(value))
+}
+
+// yamlString formats a string, quoting it when it would otherwise be read as
+// another type or is not a valid plain scalar
+func yamlString(s string) string {
+	if needsYAMLQuotes(s) {
+		// A JSON string is a valid double-quoted YAML scalar
+		var buf bytes.Buffer
+		newEncoder(&buf).Encode(s)
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+	return s
+}
+
+// needsYAMLQuotes reports whether a string cannot be written as a plain scalar
+func needsYAMLQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n", ".inf", "-.inf", ".nan":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return true
+	}
+
+	// Dates and times would be read as timestamps
+	if len(s) >= 5 && s[4] == '-' && strings.Trim(s[:4], "0123456789") == "" {
+		return true
+	}
+
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
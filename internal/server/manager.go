@@ -0,0 +1,184 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+)
+
+// ToolManagerName is the name of the built-in tool manager
+const ToolManagerName = "tools"
+
+// toolManagerTool is the built-in tool that lets clients list, enable and disable
+// the server's tools at runtime, keeping the agent's tool list small
+type toolManagerTool struct {
+	server *Server
+}
+
+// Name returns the tool's name
+func (m *toolManagerTool) Name() string {
+	return ToolManagerName
+}
+
+// Description returns the tool's description
+func (m *toolManagerTool) Description() string {
+	return "List, enable and disable the tools offered by this server. Disabled tools are hidden from the tool list until they are enabled again."
+}
+
+// Annotations returns the tool's behavioural hints for clients
+func (m *toolManagerTool) Annotations() tools.ToolAnnotations {
+	return tools.ToolAnnotations{
+		Title:       "Tool Manager",
+		ReadOnly:    false,
+		Destructive: false,
+		Idempotent:  true,
+		OpenWorld:   false,
+		Tags:        []string{"management"},
+	}
+}
+
+// Execute runs the tool manager
+func (m *toolManagerTool) Execute(params map[string]interface{}) (interface{}, error) {
+	if err := m.ValidateParams(params); err != nil {
+		return nil, err
+	}
+
+	operation, _ := params["operation"].(string)
+	if operation == "" {
+		operation = "list"
+	}
+
+	var changed []string
+	if operation == "enable" || operation == "disable" {
+		enabled, _ := m.server.ToolStates()
+		for _, name := range toolNames(params["tools"]) {
+			if slices.Contains(enabled, name) == (operation == "enable") {
+				continue // Already in the requested state
+			}
+			if err := m.server.SetToolEnabled(name, operation == "enable"); err != nil {
+				return nil, err
+			}
+			changed = append(changed, name)
+		}
+	}
+
+	enabled, disabled := m.server.ToolStates()
+	result := map[string]interface{}{
+		"enabled":  enabled,
+		"disabled": disabled,
+	}
+	if operation != "list" {
+		if changed == nil {
+			changed = []string{}
+		}
+		result["changed"] = changed
+	}
+	return result, nil
+}
+
+// ValidateParams validates the input parameters
+func (m *toolManagerTool) ValidateParams(params map[string]interface{}) error {
+	operation, _ := params["operation"].(string)
+	if operation != "" && operation != "list" && operation != "enable" && operation != "disable" {
+		return fmt.Errorf("operation must be 'list', 'enable' or 'disable'")
+	}
+
+	if operation != "enable" && operation != "disable" {
+		return nil
+	}
+
+	names := toolNames(params["tools"])
+	if len(names) == 0 {
+		return fmt.Errorf("tools parameter is required for %s operation", operation)
+	}
+
+	enabled, disabled := m.server.ToolStates()
+	available := append(enabled, disabled...)
+	slices.Sort(available)
+	for _, name := range names {
+		if name == ToolManagerName {
+			return fmt.Errorf("the tool manager cannot %s itself", operation)
+		}
+		if !slices.Contains(available, name) {
+			return fmt.Errorf("invalid tool: %s. Available tools: %s", name, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// toolNames extracts the tool names from the tools parameter
+func toolNames(value interface{}) []string {
+	var names []string
+	switch v := value.(type) {
+	case []string:
+		names = v
+	case []interface{}:
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// GetInputSchema returns the JSON schema for tool input parameters
+func (m *toolManagerTool) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"operation": map[string]interface{}{
+				"type":        "string",
+				"description": "Operation to perform: 'list' (default), 'enable' or 'disable'",
+				"enum":        []string{"list", "enable", "disable"},
+			},
+			"tools": map[string]interface{}{
+				"type":        "array",
+				"description": "Names of the tools to enable or disable (required for enable and disable)",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			},
+		},
+		"required":             []string{},
+		"additionalProperties": false,
+	}
+}
+
+// GetOutputSchema returns the JSON schema for tool output
+func (m *toolManagerTool) GetOutputSchema() map[string]interface{} {
+	names := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "array",
+			"description": description,
+			"items":       map[string]interface{}{"type": "string"},
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"result": map[string]interface{}{
+				"type":        "object",
+				"description": "The state of the server's tools after the operation",
+				"properties": map[string]interface{}{
+					"enabled":  names("Tools currently offered to clients"),
+					"disabled": names("Tools currently hidden from clients"),
+					"changed":  names("Tools whose state was changed by the operation"),
+				},
+			},
+		},
+	}
+}
+
+// GetResources returns the list of resources this tool provides
+func (m *toolManagerTool) GetResources() []tools.Resource {
+	return []tools.Resource{}
+}
+
+// ReadResource reads a specific resource by URI
+func (m *toolManagerTool) ReadResource(uri string) (string, error) {
+	return "", fmt.Errorf("unknown resource: %s", uri)
+}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// listToolNames returns the sorted names of the tools listed by the server
+func listToolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+
+	list, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// callToolManager calls the tool manager and decodes its result
+func callToolManager(t *testing.T, session *mcp.ClientSession, arguments map[string]interface{}) map[string][]string {
+	t.Helper()
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: ToolManagerName, Arguments: arguments})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Unexpected tool error: %v", result.Content)
+	}
+
+	var decoded struct {
+		Result map[string][]string `json:"result"`
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+		t.Fatalf("Failed to parse result %q: %v", text, err)
+	}
+	return decoded.Result
+}
+
+func TestToolManager(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewEchoTool())
+	server.RegisterTool(tools.NewIBANTool())
+	if err := server.SetToolEnabled("iban", false); err != nil {
+		t.Fatalf("SetToolEnabled() error = %v", err)
+	}
+	server.SetToolManager(true)
+
+	var mu sync.Mutex
+	listChanged := 0
+	session := connectTestClientWithOptions(t, server, &mcp.ClientOptions{
+		ToolListChangedHandler: func(ctx context.Context, request *mcp.ToolListChangedRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			listChanged++
+		},
+	})
+
+	// Disabled tools are not offered
+	if names := listToolNames(t, session); !slices.Equal(names, []string{"echo", "tools"}) {
+		t.Errorf("Expected tools [echo tools], got %v", names)
+	}
+
+	state := callToolManager(t, session, map[string]interface{}{})
+	if !slices.Equal(state["enabled"], []string{"echo"}) || !slices.Equal(state["disabled"], []string{"iban"}) {
+		t.Errorf("Unexpected tool states: %v", state)
+	}
+
+	// Enabling a tool publishes it together with its resources
+	state = callToolManager(t, session, map[string]interface{}{"operation": "enable", "tools": []string{"iban"}})
+	if !slices.Equal(state["changed"], []string{"iban"}) {
+		t.Errorf("Expected iban to be changed, got %v", state["changed"])
+	}
+	if names := listToolNames(t, session); !slices.Equal(names, []string{"echo", "iban", "tools"}) {
+		t.Errorf("Expected tools [echo iban tools], got %v", names)
+	}
+	if _, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "iban://countries"}); err != nil {
+		t.Errorf("Expected resources of enabled tool to be readable: %v", err)
+	}
+
+	// Enabling it again changes nothing
+	state = callToolManager(t, session, map[string]interface{}{"operation": "enable", "tools": []string{"iban"}})
+	if len(state["changed"]) != 0 {
+		t.Errorf("Expected no changes, got %v", state["changed"])
+	}
+
+	// Disabling a tool withdraws it
+	callToolManager(t, session, map[string]interface{}{"operation": "disable", "tools": []string{"echo", "iban"}})
+	if names := listToolNames(t, session); !slices.Equal(names, []string{"tools"}) {
+		t.Errorf("Expected tools [tools], got %v", names)
+	}
+	if _, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "echo", Arguments: map[string]interface{}{"message": "hi"}}); err == nil {
+		t.Error("Expected calling a disabled tool to fail")
+	}
+	if _, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: "iban://countries"}); err == nil {
+		t.Error("Expected resources of disabled tool to be withdrawn")
+	}
+
+	// Clients are told that the tool list changed
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		count := listChanged
+		mu.Unlock()
+		if count > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected notifications/tools/list_changed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestToolManagerInvalidParams(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewEchoTool())
+	server.SetToolManager(true)
+
+	session := connectTestClient(t, server)
+
+	tests := []struct {
+		name         string
+		arguments    map[string]interface{}
+		expectedText string
+	}{
+		{
+			name:         "unknown operation",
+			arguments:    map[string]interface{}{"operation": "toggle"},
+			expectedText: "operation: must be one of",
+		},
+		{
+			name:         "missing tools",
+			arguments:    map[string]interface{}{"operation": "enable"},
+			expectedText: "tools parameter is required",
+		},
+		{
+			name:         "unknown tool",
+			arguments:    map[string]interface{}{"operation": "enable", "tools": []string{"teleport"}},
+			expectedText: "invalid tool: teleport",
+		},
+		{
+			name:         "tool manager itself",
+			arguments:    map[string]interface{}{"operation": "disable", "tools": []string{"tools"}},
+			expectedText: "cannot disable itself",
+		},
+		{
+			name:         "tool names must be strings",
+			arguments:    map[string]interface{}{"operation": "disable", "tools": []interface{}{42}},
+			expectedText: "tools[0]: must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: ToolManagerName, Arguments: tt.arguments})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if !result.IsError {
+				t.Fatalf("Expected a tool error, got %v", result.Content)
+			}
+			text := result.Content[0].(*mcp.TextContent).Text
+			if !strings.Contains(text, tt.expectedText) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectedText, text)
+			}
+		})
+	}
+}
+
+func TestToolManagerDisabledByDefault(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewEchoTool())
+	server.RegisterTool(tools.NewIBANTool())
+	if err := server.SetToolEnabled("iban", false); err != nil {
+		t.Fatalf("SetToolEnabled() error = %v", err)
+	}
+
+	session := connectTestClient(t, server)
+	if names := listToolNames(t, session); !slices.Equal(names, []string{"echo"}) {
+		t.Errorf("Expected tools [echo], got %v", names)
+	}
+}
+
+func TestServerSetToolEnabled(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewEchoTool())
+
+	if err := server.SetToolEnabled("missing", false); err == nil {
+		t.Error("Expected an error for an unknown tool")
+	}
+
+	if err := server.SetToolEnabled("echo", false); err != nil {
+		t.Fatalf("SetToolEnabled() error = %v", err)
+	}
+	enabled, disabled := server.ToolStates()
+	if len(enabled) != 0 || !slices.Equal(disabled, []string{"echo"}) {
+		t.Errorf("Unexpected tool states: enabled %v, disabled %v", enabled, disabled)
+	}
+
+	if err := server.SetToolEnabled("echo", true); err != nil {
+		t.Fatalf("SetToolEnabled() error = %v", err)
+	}
+	enabled, disabled = server.ToolStates()
+	if !slices.Equal(enabled, []string{"echo"}) || len(disabled) != 0 {
+		t.Errorf("Unexpected tool states: enabled %v, disabled %v", enabled, disabled)
+	}
+}
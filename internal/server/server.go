@@ -3,30 +3,76 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/kluzzebass/mcpipboy/internal/tools"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// Supported transports for the MCP server
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+	TransportSSE   = "sse"
+)
+
+// DefaultListenAddr is the address used by the HTTP-based transports when none is set
+const DefaultListenAddr = "localhost:8080"
+
+// maxCompletions is the maximum number of values returned by completion/complete
+const maxCompletions = 100
+
+// shutdownTimeout bounds how long in-flight HTTP requests may take to finish on shutdown
+const shutdownTimeout = 5 * time.Second
+
 // Server represents the MCP server
 type Server struct {
-	server    *mcp.Server
-	tools     map[string]tools.Tool
-	debugMode bool
-	logWriter io.Writer
+	server      *mcp.Server
+	tools       map[string]tools.Tool
+	disabled    map[string]bool
+	providers   []tools.PromptProvider
+	prompts     map[string]tools.Prompt
+	toolManager bool
+	debugMode   bool
+	logWriter   io.Writer
+	transport   string
+	listenAddr  string
+	seed        *int64
+	secure      bool
+
+	// mu guards the enabled state of the tools once the server is running
+	mu sync.Mutex
 }
 
 // NewServer creates a new MCP server instance
 func NewServer() *Server {
 	return &Server{
-		tools:     make(map[string]tools.Tool),
-		debugMode: false,
-		logWriter: nil,
+		tools:       make(map[string]tools.Tool),
+		disabled:    make(map[string]bool),
+		prompts:     make(map[string]tools.Prompt),
+		toolManager: false,
+		debugMode:   false,
+		logWriter:   nil,
+		transport:   TransportStdio,
+		listenAddr:  DefaultListenAddr,
 	}
 }
 
+// Transports returns the list of supported transport names
+func Transports() []string {
+	return []string{TransportStdio, TransportHTTP, TransportSSE}
+}
+
 // SetDebugMode enables or disables debug logging
 func (s *Server) SetDebugMode(enabled bool) {
 	s.debugMode = enabled
@@ -37,6 +83,28 @@ func (s *Server) SetLogWriter(w io.Writer) {
 	s.logWriter = w
 }
 
+// SetTransport sets the transport used to serve MCP sessions (stdio, http or sse)
+func (s *Server) SetTransport(transport string) {
+	s.transport = transport
+}
+
+// SetListenAddr sets the address the HTTP-based transports listen on
+func (s *Server) SetListenAddr(addr string) {
+	s.listenAddr = addr
+}
+
+// SetSeed makes every tool call without a seed parameter generate as if it
+// had passed the given seed, so that generated values are reproducible
+func (s *Server) SetSeed(seed int64) {
+	s.seed = &seed
+}
+
+// SetSecure makes every tool call without a seed or secure parameter draw
+// from crypto/rand
+func (s *Server) SetSecure(secure bool) {
+	s.secure = secure
+}
+
 // RegisterTool registers a tool with the server
 func (s *Server) RegisterTool(tool tools.Tool) {
 	if tool == nil {
@@ -45,6 +113,72 @@ func (s *Server) RegisterTool(tool tools.Tool) {
 	s.tools[tool.Name()] = tool
 }
 
+// RegisterPromptProvider registers a provider of prompts that are not tied to a
+// single tool, such as workflows combining several tools. Tools that implement
+// tools.PromptProvider contribute their prompts without being registered here.
+func (s *Server) RegisterPromptProvider(provider tools.PromptProvider) {
+	if provider == nil {
+		return // Ignore nil providers
+	}
+	s.providers = append(s.providers, provider)
+}
+
+// SetToolManager enables or disables the built-in tool manager, which lets clients
+// list, enable and disable the registered tools during a session
+func (s *Server) SetToolManager(enabled bool) {
+	s.toolManager = enabled
+}
+
+// SetToolEnabled enables or disables a registered tool. Disabled tools are not
+// offered to clients. When the server is running, the change applies to all
+// sessions and clients receive notifications/tools/list_changed.
+func (s *Server) SetToolEnabled(name string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tool, exists := s.tools[name]
+	if !exists {
+		return fmt.Errorf("tool %s not found", name)
+	}
+	if s.disabled[name] == !enabled {
+		return nil // Nothing changes
+	}
+
+	if enabled {
+		delete(s.disabled, name)
+	} else {
+		s.disabled[name] = true
+	}
+
+	if s.server != nil {
+		if enabled {
+			s.addTool(tool)
+		} else {
+			s.removeTool(tool)
+		}
+		s.syncPrompts()
+	}
+	return nil
+}
+
+// ToolStates returns the sorted names of the enabled and disabled tools
+func (s *Server) ToolStates() (enabled, disabled []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enabled, disabled = []string{}, []string{}
+	for name := range s.tools {
+		if s.disabled[name] {
+			disabled = append(disabled, name)
+		} else {
+			enabled = append(enabled, name)
+		}
+	}
+	slices.Sort(enabled)
+	slices.Sort(disabled)
+	return enabled, disabled
+}
+
 // Start starts the MCP server
 func (s *Server) Start(ctx context.Context) error {
 	if s.debugMode && s.logWriter != nil {
@@ -52,31 +186,22 @@ func (s *Server) Start(ctx context.Context) error {
 		log.Println("Starting MCP server...")
 	}
 
-	// Create MCP server
-	s.server = mcp.NewServer(&mcp.Implementation{Name: "mcpipboy"}, nil)
+	if !slices.Contains(Transports(), s.transport) {
+		return fmt.Errorf("unsupported transport: %s", s.transport)
+	}
 
-	// Register tools
-	for _, tool := range s.tools {
-		mcp.AddTool(s.server, &mcp.Tool{
-			Name:        tool.Name(),
-			Description: tool.Description(),
-			InputSchema: tool.GetInputSchema(),
-		}, func(ctx context.Context, request *mcp.CallToolRequest, input map[string]interface{}) (*mcp.CallToolResult, map[string]interface{}, error) {
-			// Execute the tool
-			result, err := tool.Execute(input)
-			if err != nil {
-				return nil, nil, err
-			}
+	if err := s.setupServer(); err != nil {
+		return err
+	}
 
-			// Convert result to map
-			resultMap := map[string]interface{}{
-				"result": result,
-			}
-			return nil, resultMap, nil
-		})
+	if s.transport == TransportStdio {
+		return s.serveStdio(ctx)
 	}
+	return s.serveHTTP(ctx)
+}
 
-	// Start the server with stdio transport
+// serveStdio serves a single MCP session over stdin/stdout
+func (s *Server) serveStdio(ctx context.Context) error {
 	// Only use LoggingTransport if debug mode is enabled
 	var transport mcp.Transport
 	if s.debugMode && s.logWriter != nil {
@@ -87,6 +212,403 @@ func (s *Server) Start(ctx context.Context) error {
 	return s.server.Run(ctx, transport)
 }
 
+// serveHTTP serves MCP sessions over HTTP until the context is cancelled,
+// then shuts down gracefully
+func (s *Server) serveHTTP(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.listenAddr, err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           s.httpHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if s.debugMode && s.logWriter != nil {
+		log.Printf("Serving MCP over %s on %s", s.transport, listener.Addr())
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	// Stop accepting new sessions and give in-flight requests a chance to finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		// Long-lived streams (e.g. SSE) may still be open; force them closed
+		httpServer.Close()
+	}
+	return nil
+}
+
+// httpHandler returns the HTTP handler for the configured HTTP-based transport.
+// Every incoming session is served by the same underlying MCP server.
+func (s *Server) httpHandler() http.Handler {
+	getServer := func(*http.Request) *mcp.Server {
+		return s.server
+	}
+
+	var handler http.Handler
+	if s.transport == TransportSSE {
+		handler = mcp.NewSSEHandler(getServer, nil)
+	} else {
+		handler = mcp.NewStreamableHTTPHandler(getServer, nil)
+	}
+
+	if s.debugMode && s.logWriter != nil {
+		next := handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log.Printf("%s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+			next.ServeHTTP(w, r)
+		})
+	}
+	return handler
+}
+
+// setupServer creates the underlying MCP server and registers all enabled tools, their resources and prompts
+func (s *Server) setupServer() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Create MCP server. With the tool manager, tools may all be disabled at
+	// first, so the capabilities are advertised regardless.
+	s.server = mcp.NewServer(&mcp.Implementation{Name: "mcpipboy"}, &mcp.ServerOptions{
+		HasTools:          s.toolManager,
+		HasResources:      s.toolManager,
+		HasPrompts:        s.toolManager,
+		CompletionHandler: s.complete,
+	})
+
+	// Register tools
+	for name, tool := range s.tools {
+		if !s.disabled[name] {
+			s.addTool(tool)
+		}
+	}
+
+	if s.toolManager {
+		s.addTool(&toolManagerTool{server: s})
+	}
+
+	// Register the prompts whose tools are enabled
+	s.prompts = make(map[string]tools.Prompt)
+	s.syncPrompts()
+
+	return nil
+}
+
+// addTool publishes a tool and its resources on the MCP server
+func (s *Server) addTool(tool tools.Tool) {
+	mcpTool := &mcp.Tool{
+		Name:        tool.Name(),
+		Description: tool.Description(),
+		InputSchema: tool.GetInputSchema(),
+	}
+	if outputSchema := tool.GetOutputSchema(); outputSchema != nil {
+		mcpTool.OutputSchema = withSourceProperty(tool.GetInputSchema(), outputSchema)
+	}
+
+	// Publish behavioural hints so clients can e.g. auto-approve read-only tools
+	annotations := tools.GetAnnotations(tool)
+	mcpTool.Title = annotations.Title
+	mcpTool.Annotations = toolAnnotations(annotations)
+	if len(annotations.Tags) > 0 {
+		mcpTool.Meta = mcp.Meta{"tags": annotations.Tags}
+	}
+	s.server.AddTool(mcpTool, s.toolHandler(tool))
+
+	// Register resources provided by the tool
+	for _, resource := range tool.GetResources() {
+		s.server.AddResource(&mcp.Resource{
+			Name:     resource.Name,
+			URI:      resource.URI,
+			MIMEType: resource.MIMEType,
+		}, resourceHandler(tool))
+	}
+}
+
+// removeTool withdraws a tool and its resources from the MCP server
+func (s *Server) removeTool(tool tools.Tool) {
+	s.server.RemoveTools(tool.Name())
+
+	var uris []string
+	for _, resource := range tool.GetResources() {
+		uris = append(uris, resource.URI)
+	}
+	if len(uris) > 0 {
+		s.server.RemoveResources(uris...)
+	}
+}
+
+// syncPrompts publishes the prompts whose tools are all enabled and withdraws
+// the others. Prompts come from the enabled tools and the registered prompt
+// providers. The caller must hold s.mu.
+func (s *Server) syncPrompts() {
+	offered := make(map[string]bool)
+	publish := func(provider tools.PromptProvider, prompt tools.Prompt) {
+		for _, name := range prompt.Tools {
+			if _, exists := s.tools[name]; !exists || s.disabled[name] {
+				return // A required tool is unavailable
+			}
+		}
+		offered[prompt.Name] = true
+		if _, published := s.prompts[prompt.Name]; !published {
+			s.server.AddPrompt(mcpPrompt(prompt), promptHandler(provider, prompt))
+			s.prompts[prompt.Name] = prompt
+		}
+	}
+
+	for name, tool := range s.tools {
+		provider, ok := tool.(tools.PromptProvider)
+		if !ok || s.disabled[name] {
+			continue
+		}
+		for _, prompt := range provider.GetPrompts() {
+			publish(provider, prompt)
+		}
+	}
+	for _, provider := range s.providers {
+		for _, prompt := range provider.GetPrompts() {
+			publish(provider, prompt)
+		}
+	}
+
+	var withdrawn []string
+	for name := range s.prompts {
+		if !offered[name] {
+			withdrawn = append(withdrawn, name)
+			delete(s.prompts, name)
+		}
+	}
+	if len(withdrawn) > 0 {
+		s.server.RemovePrompts(withdrawn...)
+	}
+}
+
+// complete handles completion/complete requests. Prompt arguments that take
+// the values of a tool parameter are completed with the candidates the tool
+// provides; everything else has no completions.
+func (s *Server) complete(ctx context.Context, request *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	values := s.completeArgument(request.Params)
+	result := &mcp.CompleteResult{
+		Completion: mcp.CompletionResultDetails{
+			Values: values,
+			Total:  len(values),
+		},
+	}
+	if len(values) > maxCompletions {
+		result.Completion.Values = values[:maxCompletions]
+		result.Completion.HasMore = true
+	}
+	return result, nil
+}
+
+// completeArgument returns all completions for the argument in a completion request
+func (s *Server) completeArgument(params *mcp.CompleteParams) []string {
+	if params.Ref == nil || params.Ref.Type != "ref/prompt" {
+		return []string{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prompt, published := s.prompts[params.Ref.Name]
+	if !published {
+		return []string{}
+	}
+	for _, argument := range prompt.Arguments {
+		if argument.Name != params.Argument.Name || argument.Param == "" {
+			continue
+		}
+		tool, exists := s.tools[argument.Tool]
+		if !exists || s.disabled[argument.Tool] {
+			break
+		}
+		if argument.List {
+			return tools.CompleteList(tool, argument.Param, params.Argument.Value)
+		}
+		return tools.CompleteParam(tool, argument.Param, params.Argument.Value)
+	}
+	return []string{}
+}
+
+// mcpPrompt converts a prompt to its MCP representation
+func mcpPrompt(prompt tools.Prompt) *mcp.Prompt {
+	mcpPrompt := &mcp.Prompt{
+		Name:        prompt.Name,
+		Title:       prompt.Title,
+		Description: prompt.Description,
+	}
+	for _, argument := range prompt.Arguments {
+		mcpPrompt.Arguments = append(mcpPrompt.Arguments, &mcp.PromptArgument{
+			Name:        argument.Name,
+			Description: argument.Description,
+			Required:    argument.Required,
+		})
+	}
+	return mcpPrompt
+}
+
+// toolAnnotations converts a tool's annotations to their MCP representation
+func toolAnnotations(annotations tools.ToolAnnotations) *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		Title:           annotations.Title,
+		ReadOnlyHint:    annotations.ReadOnly,
+		DestructiveHint: &annotations.Destructive,
+		IdempotentHint:  annotations.Idempotent,
+		OpenWorldHint:   &annotations.OpenWorld,
+	}
+}
+
+// toolHandler returns an MCP tool handler that executes the given tool.
+// Invalid arguments and execution failures are reported as tool errors (isError)
+// so the calling agent can see what went wrong and correct itself, while successful
+// results are returned as structured content wrapped in a "result" property,
+// matching the tool's output schema. Tools that draw random values use the
+// server's default seed or secure mode, and the source they drew from is
+// reported in a "source" property next to the result.
+func (s *Server) toolHandler(tool tools.Tool) mcp.ToolHandler {
+	return func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var params map[string]interface{}
+		if len(request.Params.Arguments) > 0 {
+			if err := json.Unmarshal(request.Params.Arguments, &params); err != nil {
+				return toolErrorResult(fmt.Errorf("invalid arguments: %w", err)), nil
+			}
+		}
+		if params == nil {
+			params = make(map[string]interface{})
+		}
+
+		// Check the arguments against the input schema and the tool's own rules
+		if err := tools.ValidateInput(tool, params); err != nil {
+			return toolErrorResult(fmt.Errorf("invalid arguments: %w", err)), nil
+		}
+
+		// Forward progress updates to the client when it asked for them
+		if token := request.Params.GetProgressToken(); token != nil {
+			ctx = tools.WithProgress(ctx, progressNotifier(ctx, request.Session, token))
+		}
+
+		if s.seed != nil {
+			ctx = tools.WithSeed(ctx, *s.seed)
+		}
+		if s.secure {
+			ctx = tools.WithSecure(ctx)
+		}
+		var source string
+		ctx = tools.WithSourceReport(ctx, func(name string) { source = name })
+
+		// Execute the tool; the context is cancelled when the client cancels the request
+		result, err := tools.AsContextTool(tool).ExecuteContext(ctx, params)
+		if err != nil {
+			return toolErrorResult(err), nil
+		}
+
+		content := map[string]interface{}{
+			"result": result,
+		}
+		if source != "" {
+			content["source"] = source
+		}
+		data, err := json.Marshal(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result of tool %s: %w", tool.Name(), err)
+		}
+
+		return &mcp.CallToolResult{
+			Content:           []mcp.Content{&mcp.TextContent{Text: string(data)}},
+			StructuredContent: json.RawMessage(data),
+		}, nil
+	}
+}
+
+// withSourceProperty adds the "source" property reported by toolHandler to the
+// output schema of tools that take a secure parameter
+func withSourceProperty(inputSchema, outputSchema map[string]interface{}) map[string]interface{} {
+	inputProperties, _ := inputSchema["properties"].(map[string]interface{})
+	outputProperties, _ := outputSchema["properties"].(map[string]interface{})
+	if _, ok := inputProperties["secure"]; !ok || outputProperties == nil {
+		return outputSchema
+	}
+
+	properties := maps.Clone(outputProperties)
+	properties["source"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Random source the values were drawn from: crypto/rand, math/rand, or math/rand with the seed",
+	}
+	schema := maps.Clone(outputSchema)
+	schema["properties"] = properties
+	return schema
+}
+
+// progressNotifier returns a progress reporter that sends notifications/progress
+// messages for the given progress token to the client session
+func progressNotifier(ctx context.Context, session *mcp.ServerSession, token any) tools.ProgressFunc {
+	return func(progress, total float64, message string) {
+		// Progress is best effort; a failed notification must not fail the tool call
+		_ = session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		})
+	}
+}
+
+// toolErrorResult creates a tool result reporting the given error to the client
+func toolErrorResult(err error) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+	}
+}
+
+// resourceHandler returns an MCP resource handler that reads resources from the given tool
+func resourceHandler(tool tools.Tool) mcp.ResourceHandler {
+	return func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := request.Params.URI
+		content, err := tool.ReadResource(uri)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read resource %s: %w", uri, err)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{URI: uri, Text: content},
+			},
+		}, nil
+	}
+}
+
+// promptHandler returns an MCP prompt handler that renders the prompt from the given provider
+func promptHandler(provider tools.PromptProvider, prompt tools.Prompt) mcp.PromptHandler {
+	return func(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		text, err := provider.GetPrompt(prompt.Name, request.Params.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get prompt %s: %w", prompt.Name, err)
+		}
+
+		return &mcp.GetPromptResult{
+			Description: prompt.Description,
+			Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{Text: text}},
+			},
+		}, nil
+	}
+}
+
 // Stop stops the MCP server
 func (s *Server) Stop() error {
 	if s.debugMode && s.logWriter != nil {
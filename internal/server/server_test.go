@@ -3,11 +3,19 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/kluzzebass/mcpipboy/internal/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // MockTool implements the tools.Tool interface for testing
@@ -18,6 +26,7 @@ type MockTool struct {
 	outputSchema map[string]interface{}
 	executeFunc  func(params map[string]interface{}) (interface{}, error)
 	validateFunc func(params map[string]interface{}) error
+	resources    map[string]string
 }
 
 func (m *MockTool) Name() string {
@@ -51,13 +60,66 @@ func (m *MockTool) GetOutputSchema() map[string]interface{} {
 }
 
 func (m *MockTool) GetResources() []tools.Resource {
-	return []tools.Resource{}
+	resources := []tools.Resource{}
+	for uri := range m.resources {
+		resources = append(resources, tools.Resource{
+			Name:     "Mock " + uri,
+			URI:      uri,
+			MIMEType: "application/json",
+		})
+	}
+	return resources
 }
 
 func (m *MockTool) ReadResource(uri string) (string, error) {
+	if content, ok := m.resources[uri]; ok {
+		return content, nil
+	}
 	return "", fmt.Errorf("no resources available for mock tool")
 }
 
+// ContextMockTool is a MockTool that also implements tools.ContextTool
+type ContextMockTool struct {
+	MockTool
+	executeContextFunc func(ctx context.Context, params map[string]interface{}) (interface{}, error)
+}
+
+func (m *ContextMockTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return m.executeContextFunc(ctx, params)
+}
+
+// connectTestClient sets up the server and connects an in-memory MCP client to it
+func connectTestClient(t *testing.T, server *Server) *mcp.ClientSession {
+	t.Helper()
+	return connectTestClientWithOptions(t, server, nil)
+}
+
+// connectTestClientWithOptions is like connectTestClient but lets the test configure the client
+func connectTestClientWithOptions(t *testing.T, server *Server, options *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+
+	if err := server.setupServer(); err != nil {
+		t.Fatalf("Failed to set up server: %v", err)
+	}
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	t.Cleanup(func() { serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, options)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { clientSession.Close() })
+
+	return clientSession
+}
+
 func TestNewServer(t *testing.T) {
 	server := NewServer()
 	if server == nil {
@@ -224,3 +286,996 @@ func TestServerStopWithoutStart(t *testing.T) {
 		t.Errorf("Stop() should work without Start(): %v", err)
 	}
 }
+
+func TestServerResources(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(&MockTool{
+		name:        "enabled",
+		description: "Tool with resources",
+		inputSchema: map[string]interface{}{"type": "object"},
+		resources: map[string]string{
+			"enabled://types":    `["a","b"]`,
+			"enabled://examples": `[{"value":"a"}]`,
+		},
+	})
+
+	session := connectTestClient(t, server)
+	ctx := context.Background()
+
+	t.Run("list resources", func(t *testing.T) {
+		result, err := session.ListResources(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListResources() error = %v", err)
+		}
+		if len(result.Resources) != 2 {
+			t.Fatalf("Expected 2 resources, got %d", len(result.Resources))
+		}
+		for _, resource := range result.Resources {
+			if resource.MIMEType != "application/json" {
+				t.Errorf("Expected MIME type application/json for %s, got %s", resource.URI, resource.MIMEType)
+			}
+		}
+	})
+
+	t.Run("read resource", func(t *testing.T) {
+		result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "enabled://types"})
+		if err != nil {
+			t.Fatalf("ReadResource() error = %v", err)
+		}
+		if len(result.Contents) != 1 {
+			t.Fatalf("Expected 1 content item, got %d", len(result.Contents))
+		}
+		content := result.Contents[0]
+		if content.Text != `["a","b"]` {
+			t.Errorf("Expected resource text %q, got %q", `["a","b"]`, content.Text)
+		}
+		if content.URI != "enabled://types" {
+			t.Errorf("Expected URI enabled://types, got %s", content.URI)
+		}
+		if content.MIMEType != "application/json" {
+			t.Errorf("Expected MIME type application/json, got %s", content.MIMEType)
+		}
+	})
+
+	t.Run("unregistered resource", func(t *testing.T) {
+		// Resources of tools that were never registered (i.e. disabled) must not be readable
+		_, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "disabled://types"})
+		if err == nil {
+			t.Error("Expected error reading resource of unregistered tool")
+		}
+	})
+}
+
+func TestServerToolResources(t *testing.T) {
+	// Every real tool resource should be listed and readable through MCP
+	server := NewServer()
+	server.RegisterTool(tools.NewIBANTool())
+	server.RegisterTool(tools.NewTimeTool())
+	server.RegisterTool(tools.NewEchoTool())
+
+	session := connectTestClient(t, server)
+	ctx := context.Background()
+
+	result, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources() error = %v", err)
+	}
+
+	expected := len(tools.NewIBANTool().GetResources()) + len(tools.NewTimeTool().GetResources())
+	if len(result.Resources) != expected {
+		t.Fatalf("Expected %d resources, got %d", expected, len(result.Resources))
+	}
+
+	for _, resource := range result.Resources {
+		read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: resource.URI})
+		if err != nil {
+			t.Errorf("ReadResource(%s) error = %v", resource.URI, err)
+			continue
+		}
+		if len(read.Contents) == 0 || read.Contents[0].Text == "" {
+			t.Errorf("ReadResource(%s) returned no content", resource.URI)
+		}
+	}
+}
+
+func TestServerHTTPTransports(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport string
+		client    func(endpoint string) mcp.Transport
+	}{
+		{
+			name:      "streamable http",
+			transport: TransportHTTP,
+			client: func(endpoint string) mcp.Transport {
+				return &mcp.StreamableClientTransport{Endpoint: endpoint}
+			},
+		},
+		{
+			name:      "sse",
+			transport: TransportSSE,
+			client: func(endpoint string) mcp.Transport {
+				return &mcp.SSEClientTransport{Endpoint: endpoint}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer()
+			server.SetTransport(tt.transport)
+			server.RegisterTool(tools.NewEchoTool())
+			if err := server.setupServer(); err != nil {
+				t.Fatalf("Failed to set up server: %v", err)
+			}
+
+			httpServer := httptest.NewServer(server.httpHandler())
+			defer httpServer.Close()
+
+			ctx := context.Background()
+
+			// Several clients should be able to hold sessions at the same time
+			var wg sync.WaitGroup
+			for i := range 3 {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					client := mcp.NewClient(&mcp.Implementation{Name: fmt.Sprintf("client-%d", i)}, nil)
+					session, err := client.Connect(ctx, tt.client(httpServer.URL), nil)
+					if err != nil {
+						t.Errorf("client %d: Connect() error = %v", i, err)
+						return
+					}
+					defer session.Close()
+
+					message := fmt.Sprintf("hello from %d", i)
+					result, err := session.CallTool(ctx, &mcp.CallToolParams{
+						Name:      "echo",
+						Arguments: map[string]interface{}{"message": message},
+					})
+					if err != nil {
+						t.Errorf("client %d: CallTool() error = %v", i, err)
+						return
+					}
+					if len(result.Content) == 0 {
+						t.Errorf("client %d: expected content in result", i)
+						return
+					}
+					text, ok := result.Content[0].(*mcp.TextContent)
+					if !ok || !strings.Contains(text.Text, message) {
+						t.Errorf("client %d: expected result to contain %q, got %v", i, message, result.Content[0])
+					}
+				}(i)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestServerStartHTTPGracefulShutdown(t *testing.T) {
+	server := NewServer()
+	server.SetTransport(TransportHTTP)
+	server.SetListenAddr("127.0.0.1:0")
+	server.RegisterTool(tools.NewEchoTool())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Start(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start() should return nil after graceful shutdown, got %v", err)
+		}
+	case <-time.After(shutdownTimeout + time.Second):
+		t.Fatal("Server did not shut down after context cancellation")
+	}
+}
+
+func TestServerStartInvalidTransport(t *testing.T) {
+	server := NewServer()
+	server.SetTransport("carrier-pigeon")
+
+	err := server.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unsupported transport") {
+		t.Errorf("Expected unsupported transport error, got %v", err)
+	}
+}
+
+func TestServerStartHTTPInvalidListenAddr(t *testing.T) {
+	server := NewServer()
+	server.SetTransport(TransportHTTP)
+	server.SetListenAddr("not-an-address")
+
+	err := server.Start(context.Background())
+	if err == nil {
+		t.Error("Expected error for invalid listen address")
+	}
+}
+
+func TestServerToolErrors(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(&MockTool{
+		name:        "failing",
+		description: "Tool that always fails",
+		inputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"count": map[string]interface{}{"type": "number"},
+			},
+		},
+		executeFunc: func(params map[string]interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("something went wrong")
+		},
+	})
+
+	session := connectTestClient(t, server)
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		arguments    map[string]interface{}
+		expectedText string
+	}{
+		{
+			name:         "execution error",
+			arguments:    map[string]interface{}{},
+			expectedText: "something went wrong",
+		},
+		{
+			name:         "invalid arguments",
+			arguments:    map[string]interface{}{"count": "many"},
+			expectedText: "invalid arguments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "failing", Arguments: tt.arguments})
+			if err != nil {
+				t.Fatalf("CallTool() should report failures as tool errors, got protocol error: %v", err)
+			}
+			if !result.IsError {
+				t.Error("Expected IsError to be set")
+			}
+			if result.StructuredContent != nil {
+				t.Errorf("Expected no structured content for an error, got %v", result.StructuredContent)
+			}
+			if len(result.Content) != 1 {
+				t.Fatalf("Expected 1 content item, got %d", len(result.Content))
+			}
+			text, ok := result.Content[0].(*mcp.TextContent)
+			if !ok || !strings.Contains(text.Text, tt.expectedText) {
+				t.Errorf("Expected error text containing %q, got %v", tt.expectedText, result.Content[0])
+			}
+		})
+	}
+}
+
+func TestServerStructuredResults(t *testing.T) {
+	server := NewServer()
+	for _, tool := range []tools.Tool{
+		tools.NewEchoTool(),
+		tools.NewVersionTool(),
+		tools.NewTimeTool(),
+		tools.NewRandomTool(),
+		tools.NewUUIDTool(),
+		tools.NewIMOTool(),
+		tools.NewMMSITool(),
+		tools.NewCreditCardTool(),
+		tools.NewISBNTool(),
+		tools.NewEAN13Tool(),
+		tools.NewIBANTool(),
+	} {
+		server.RegisterTool(tool)
+	}
+
+	session := connectTestClient(t, server)
+	ctx := context.Background()
+
+	// The output schema of every tool must be published in tools/list
+	list, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	for _, tool := range list.Tools {
+		if tool.OutputSchema == nil {
+			t.Errorf("Tool %s has no output schema", tool.Name)
+		}
+	}
+
+	tests := []struct {
+		tool      string
+		arguments map[string]interface{}
+	}{
+		{"echo", map[string]interface{}{"message": "hello"}},
+		{"version", map[string]interface{}{}},
+		{"time", map[string]interface{}{"format": "unix"}},
+		{"random", map[string]interface{}{"type": "integer"}},
+		{"random", map[string]interface{}{"type": "float", "count": 3}},
+		{"random", map[string]interface{}{"type": "boolean", "count": 2}},
+		{"uuid", map[string]interface{}{"version": "v4"}},
+		{"uuid", map[string]interface{}{"version": "v7", "count": 2}},
+		{"uuid", map[string]interface{}{"version": "validate", "input": "550e8400-e29b-41d4-a716-446655440000"}},
+		{"imo", map[string]interface{}{"operation": "validate", "input": "9074729"}},
+		{"imo", map[string]interface{}{"operation": "generate"}},
+		{"mmsi", map[string]interface{}{"operation": "validate", "input": "366123456"}},
+		{"mmsi", map[string]interface{}{"operation": "generate"}},
+		{"creditcard", map[string]interface{}{"operation": "validate", "input": "4532015112830366"}},
+		{"creditcard", map[string]interface{}{"operation": "generate", "count": 2}},
+		{"isbn", map[string]interface{}{"operation": "validate", "input": "9780306406157"}},
+		{"isbn", map[string]interface{}{"operation": "generate"}},
+		{"ean13", map[string]interface{}{"operation": "validate", "input": "1234567890128"}},
+		{"ean13", map[string]interface{}{"operation": "generate", "count": 2}},
+		{"iban", map[string]interface{}{"operation": "validate", "input": "GB82WEST12345698765432"}},
+		{"iban", map[string]interface{}{"operation": "validate", "input": "GB82WEST12345698765433"}},
+		{"iban", map[string]interface{}{"operation": "generate", "count": 2}},
+	}
+
+	outputSchemas := make(map[string]map[string]interface{})
+	for name, tool := range server.tools {
+		outputSchemas[name] = tool.GetOutputSchema()
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v", tt.tool, tt.arguments), func(t *testing.T) {
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tt.tool, Arguments: tt.arguments})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("Unexpected tool error: %v", result.Content)
+			}
+			if result.StructuredContent == nil {
+				t.Fatal("Expected structured content")
+			}
+
+			schema, err := resolveSchema(outputSchemas[tt.tool])
+			if err != nil {
+				t.Fatalf("Failed to resolve output schema: %v", err)
+			}
+			if err := schema.Validate(result.StructuredContent); err != nil {
+				t.Errorf("Structured content %v does not match output schema: %v", result.StructuredContent, err)
+			}
+		})
+	}
+}
+
+// resolveSchema converts a tool's JSON schema map into a resolved schema that can validate values
+func resolveSchema(schema map[string]interface{}) (*jsonschema.Resolved, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed jsonschema.Schema
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed.Resolve(nil)
+}
+
+func TestServerArgumentValidation(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewIMOTool())
+	server.RegisterTool(tools.NewCreditCardTool())
+	server.RegisterTool(tools.NewIBANTool())
+
+	session := connectTestClient(t, server)
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		tool         string
+		arguments    map[string]interface{}
+		expectedText string
+	}{
+		{
+			name:         "fractional count",
+			tool:         "imo",
+			arguments:    map[string]interface{}{"operation": "generate", "count": 2.5},
+			expectedText: "count: must be an integer",
+		},
+		{
+			name:         "count above maximum",
+			tool:         "creditcard",
+			arguments:    map[string]interface{}{"operation": "generate", "count": 500},
+			expectedText: "count: must be at most 100",
+		},
+		{
+			name:         "value not in enum",
+			tool:         "iban",
+			arguments:    map[string]interface{}{"operation": "checksum"},
+			expectedText: `operation: must be one of ["validate", "generate"]`,
+		},
+		{
+			name:         "unknown parameter",
+			tool:         "iban",
+			arguments:    map[string]interface{}{"operation": "generate", "country": "GB"},
+			expectedText: "country: unknown parameter",
+		},
+		{
+			name:         "tool specific validation",
+			tool:         "imo",
+			arguments:    map[string]interface{}{"operation": "generate", "count": 101},
+			expectedText: "count cannot exceed 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tt.tool, Arguments: tt.arguments})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if !result.IsError {
+				t.Fatalf("Expected a tool error, got %v", result.Content)
+			}
+			text, ok := result.Content[0].(*mcp.TextContent)
+			if !ok || !strings.Contains(text.Text, tt.expectedText) {
+				t.Errorf("Expected error text containing %q, got %v", tt.expectedText, result.Content[0])
+			}
+		})
+	}
+
+	// Counts arrive as JSON numbers and must still be honoured
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "imo",
+		Arguments: map[string]interface{}{"operation": "generate", "count": 3},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Unexpected tool error: %v", result.Content)
+	}
+	var structured struct {
+		Result []string `json:"result"`
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if err := json.Unmarshal([]byte(text), &structured); err != nil {
+		t.Fatalf("Failed to parse result %q: %v", text, err)
+	}
+	if len(structured.Result) != 3 {
+		t.Errorf("Expected 3 IMO numbers, got %v", structured.Result)
+	}
+}
+
+func TestServerToolProgress(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewUUIDTool())
+
+	var mu sync.Mutex
+	var notifications []*mcp.ProgressNotificationParams
+	session := connectTestClientWithOptions(t, server, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, request *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			notifications = append(notifications, request.Params)
+		},
+	})
+
+	params := &mcp.CallToolParams{
+		Meta:      mcp.Meta{"progressToken": "uuid-batch"},
+		Name:      "uuid",
+		Arguments: map[string]interface{}{"version": "v4", "count": 1000},
+	}
+
+	result, err := session.CallTool(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Unexpected tool error: %v", result.Content)
+	}
+
+	// Notifications are delivered asynchronously, so wait for the final one
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		done := len(notifications) > 0 && notifications[len(notifications)-1].Progress == 1000
+		mu.Unlock()
+		if done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(notifications) == 0 {
+		t.Fatal("Expected progress notifications")
+	}
+	for _, notification := range notifications {
+		if notification.ProgressToken != "uuid-batch" {
+			t.Errorf("Expected progress token 'uuid-batch', got %v", notification.ProgressToken)
+		}
+		if notification.Total != 1000 {
+			t.Errorf("Expected total 1000, got %v", notification.Total)
+		}
+	}
+	if last := notifications[len(notifications)-1]; last.Progress != 1000 {
+		t.Errorf("Expected final progress 1000, got %v", last.Progress)
+	}
+}
+
+func TestServerToolProgressWithoutToken(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewUUIDTool())
+
+	var mu sync.Mutex
+	notified := false
+	session := connectTestClientWithOptions(t, server, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, request *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			notified = true
+		},
+	})
+
+	_, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "uuid",
+		Arguments: map[string]interface{}{"version": "v4", "count": 100},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if notified {
+		t.Error("Progress should only be reported when the client sends a progress token")
+	}
+}
+
+func TestServerToolCancellation(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan error, 1)
+
+	server := NewServer()
+	server.RegisterTool(&ContextMockTool{
+		MockTool: MockTool{
+			name:        "slow",
+			description: "Tool that runs until it is cancelled",
+			inputSchema: map[string]interface{}{"type": "object"},
+		},
+		executeContextFunc: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			close(started)
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return nil, ctx.Err()
+		},
+	})
+
+	session := connectTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go session.CallTool(ctx, &mcp.CallToolParams{Name: "slow", Arguments: map[string]interface{}{}})
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Tool was not started")
+	}
+	cancel()
+
+	select {
+	case err := <-cancelled:
+		if err == nil {
+			t.Error("Expected the tool context to report an error after cancellation")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Tool was not cancelled when the client cancelled the request")
+	}
+}
+
+func TestServerSeed(t *testing.T) {
+	server := NewServer()
+	server.SetSeed(42)
+	server.RegisterTool(tools.NewUUIDTool())
+	server.RegisterTool(tools.NewIBANTool())
+
+	session := connectTestClient(t, server)
+	ctx := context.Background()
+
+	call := func(t *testing.T, tool string, arguments map[string]interface{}) string {
+		t.Helper()
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tool, Arguments: arguments})
+		if err != nil {
+			t.Fatalf("CallTool() error = %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("Unexpected tool error: %v", result.Content)
+		}
+		return result.Content[0].(*mcp.TextContent).Text
+	}
+
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]interface{}
+	}{
+		{"uuid v4", "uuid", map[string]interface{}{"version": "v4", "count": 3}},
+		{"uuid v7", "uuid", map[string]interface{}{"version": "v7"}},
+		{"iban", "iban", map[string]interface{}{"operation": "generate", "count": 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := call(t, tt.tool, tt.arguments)
+			if second := call(t, tt.tool, tt.arguments); second != first {
+				t.Errorf("Expected the server seed to repeat %s, got %s", first, second)
+			}
+
+			// A seed parameter takes precedence over the server seed
+			arguments := maps.Clone(tt.arguments)
+			arguments["seed"] = 7
+			if seeded := call(t, tt.tool, arguments); seeded == first {
+				t.Errorf("Expected the seed parameter to override the server seed, got %s twice", seeded)
+			}
+		})
+	}
+}
+
+func TestServerSecure(t *testing.T) {
+	server := NewServer()
+	server.SetSecure(true)
+	server.RegisterTool(tools.NewRandomTool())
+	server.RegisterTool(tools.NewEchoTool())
+
+	session := connectTestClient(t, server)
+	ctx := context.Background()
+
+	list, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	for _, tool := range list.Tools {
+		properties := tool.OutputSchema.(map[string]interface{})["properties"].(map[string]interface{})
+		if _, ok := properties["source"]; ok != (tool.Name == "random") {
+			t.Errorf("Tool %s: expected a source property in the output schema only for generating tools", tool.Name)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		tool      string
+		arguments map[string]interface{}
+		expected  interface{}
+	}{
+		{"server default", "random", map[string]interface{}{}, tools.SourceCrypto},
+		{"seed parameter", "random", map[string]interface{}{"seed": 7}, "math/rand (seed 7)"},
+		{"opt out", "random", map[string]interface{}{"secure": false}, tools.SourceMath},
+		{"no randomness", "echo", map[string]interface{}{"message": "hello"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tt.tool, Arguments: tt.arguments})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("Unexpected tool error: %v", result.Content)
+			}
+			content := result.StructuredContent.(map[string]interface{})
+			if content["source"] != tt.expected {
+				t.Errorf("Expected source %v, got %v", tt.expected, content["source"])
+			}
+		})
+	}
+}
+
+func TestServerToolAnnotations(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewIBANTool())
+	server.RegisterTool(tools.NewEchoTool())
+	server.RegisterTool(&MockTool{
+		name:        "plain",
+		description: "Tool without annotations",
+		inputSchema: map[string]interface{}{"type": "object"},
+	})
+
+	session := connectTestClient(t, server)
+	list, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+
+	listed := make(map[string]*mcp.Tool)
+	for _, tool := range list.Tools {
+		listed[tool.Name] = tool
+	}
+
+	tests := []struct {
+		name        string
+		title       string
+		readOnly    bool
+		idempotent  bool
+		openWorld   bool
+		destructive bool
+		tags        []interface{}
+	}{
+		{"iban", "IBAN", true, false, false, false, []interface{}{"finance", "identifiers", "generation", "validation"}},
+		{"echo", "Echo", true, true, false, false, []interface{}{"utility"}},
+		{"plain", "", false, false, true, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool, ok := listed[tt.name]
+			if !ok {
+				t.Fatalf("Tool %s not listed", tt.name)
+			}
+			if tool.Title != tt.title {
+				t.Errorf("Expected title %q, got %q", tt.title, tool.Title)
+			}
+			annotations := tool.Annotations
+			if annotations == nil {
+				t.Fatal("Expected annotations")
+			}
+			if annotations.ReadOnlyHint != tt.readOnly {
+				t.Errorf("Expected readOnlyHint %v, got %v", tt.readOnly, annotations.ReadOnlyHint)
+			}
+			if annotations.IdempotentHint != tt.idempotent {
+				t.Errorf("Expected idempotentHint %v, got %v", tt.idempotent, annotations.IdempotentHint)
+			}
+			if annotations.OpenWorldHint == nil || *annotations.OpenWorldHint != tt.openWorld {
+				t.Errorf("Expected openWorldHint %v, got %v", tt.openWorld, annotations.OpenWorldHint)
+			}
+			// An unset destructiveHint defaults to true
+			destructive := annotations.DestructiveHint == nil || *annotations.DestructiveHint
+			if destructive != tt.destructive {
+				t.Errorf("Expected destructiveHint %v, got %v", tt.destructive, destructive)
+			}
+			tags, _ := tool.Meta["tags"].([]interface{})
+			if fmt.Sprint(tags) != fmt.Sprint(tt.tags) {
+				t.Errorf("Expected tags %v, got %v", tt.tags, tool.Meta["tags"])
+			}
+		})
+	}
+}
+
+// listPromptNames returns the sorted names of the prompts offered to the session
+func listPromptNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+
+	list, err := session.ListPrompts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	names := []string{}
+	for _, prompt := range list.Prompts {
+		names = append(names, prompt.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestServerPrompts(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewTimeTool())
+	server.RegisterTool(tools.NewUUIDTool())
+	server.RegisterTool(tools.NewIBANTool())
+	server.RegisterTool(tools.NewCreditCardTool())
+	server.RegisterTool(tools.NewIMOTool()) // Without mmsi, the vessel audit is not offered
+	server.RegisterPromptProvider(tools.NewWorkflowPrompts())
+	session := connectTestClient(t, server)
+
+	if names := listPromptNames(t, session); !slices.Equal(names, []string{"convert-meeting-time", "test-customer-record"}) {
+		t.Errorf("Expected prompts [convert-meeting-time test-customer-record], got %v", names)
+	}
+
+	tests := []struct {
+		name      string
+		prompt    string
+		arguments map[string]string
+		contains  []string
+		wantError string
+	}{
+		{
+			name:      "meeting time",
+			prompt:    "convert-meeting-time",
+			arguments: map[string]string{"time": "2025-03-14 15:00", "from_timezone": "Europe/Oslo", "to_timezones": "Asia/Tokyo"},
+			contains:  []string{"2025-03-14 15:00", "Europe/Oslo", "Asia/Tokyo", `"time" tool`},
+		},
+		{
+			name:      "customer record with optional arguments",
+			prompt:    "test-customer-record",
+			arguments: map[string]string{"count": "3", "country": "DE"},
+			contains:  []string{"Generate 3 realistic", `country-code "DE"`, `"uuid" tool`, `"creditcard" tool`},
+		},
+		{
+			name:      "missing required argument",
+			prompt:    "convert-meeting-time",
+			arguments: map[string]string{"time": "now", "from_timezone": "UTC"},
+			wantError: "missing required argument: to_timezones",
+		},
+		{
+			name:      "unknown argument",
+			prompt:    "test-customer-record",
+			arguments: map[string]string{"currency": "EUR"},
+			wantError: "unknown argument: currency",
+		},
+		{
+			name:      "prompt not offered",
+			prompt:    "audit-vessel-identifiers",
+			arguments: map[string]string{"identifiers": "IMO 9074729"},
+			wantError: "audit-vessel-identifiers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{
+				Name:      tt.prompt,
+				Arguments: tt.arguments,
+			})
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPrompt() error = %v", err)
+			}
+
+			if len(result.Messages) != 1 || result.Messages[0].Role != "user" {
+				t.Fatalf("Expected a single user message, got %+v", result.Messages)
+			}
+			text, ok := result.Messages[0].Content.(*mcp.TextContent)
+			if !ok {
+				t.Fatalf("Expected text content, got %T", result.Messages[0].Content)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(text.Text, want) {
+					t.Errorf("Expected prompt to contain %q, got:\n%s", want, text.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestServerPromptsFollowToolState(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewIMOTool())
+	server.RegisterTool(tools.NewMMSITool())
+	server.RegisterTool(tools.NewTimeTool())
+	server.RegisterPromptProvider(tools.NewWorkflowPrompts())
+	server.SetToolManager(true)
+	if err := server.SetToolEnabled("time", false); err != nil {
+		t.Fatalf("SetToolEnabled() error = %v", err)
+	}
+	session := connectTestClient(t, server)
+
+	steps := []struct {
+		tool    string
+		enabled bool
+		want    []string
+	}{
+		{tool: "time", enabled: true, want: []string{"audit-vessel-identifiers", "convert-meeting-time"}},
+		{tool: "mmsi", enabled: false, want: []string{"convert-meeting-time"}},
+		{tool: "time", enabled: false, want: []string{}},
+		{tool: "mmsi", enabled: true, want: []string{"audit-vessel-identifiers"}},
+	}
+
+	if names := listPromptNames(t, session); !slices.Equal(names, []string{"audit-vessel-identifiers"}) {
+		t.Errorf("Expected prompts [audit-vessel-identifiers] at startup, got %v", names)
+	}
+	for _, step := range steps {
+		if err := server.SetToolEnabled(step.tool, step.enabled); err != nil {
+			t.Fatalf("SetToolEnabled(%s, %v) error = %v", step.tool, step.enabled, err)
+		}
+		if names := listPromptNames(t, session); !slices.Equal(names, step.want) {
+			t.Errorf("After SetToolEnabled(%s, %v): expected prompts %v, got %v", step.tool, step.enabled, step.want, names)
+		}
+	}
+}
+
+func TestServerCompletion(t *testing.T) {
+	server := NewServer()
+	server.RegisterTool(tools.NewTimeTool())
+	server.RegisterTool(tools.NewUUIDTool())
+	server.RegisterTool(tools.NewIBANTool())
+	server.RegisterTool(tools.NewCreditCardTool())
+	server.RegisterPromptProvider(tools.NewWorkflowPrompts())
+	session := connectTestClient(t, server)
+
+	tests := []struct {
+		name     string
+		ref      *mcp.CompleteReference
+		argument string
+		value    string
+		want     []string
+		total    int
+		hasMore  bool
+	}{
+		{
+			name:     "country code from iban tool",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "test-customer-record"},
+			argument: "country",
+			value:    "gr",
+			want:     []string{"GR"},
+			total:    1,
+		},
+		{
+			name:     "card type from schema enum",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "test-customer-record"},
+			argument: "card_type",
+			value:    "d",
+			want:     []string{"discover", "diners"},
+			total:    2,
+		},
+		{
+			name:     "timezone list element",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "convert-meeting-time"},
+			argument: "to_timezones",
+			value:    "UTC, asia/tok",
+			want:     []string{"UTC, Asia/Tokyo"},
+			total:    1,
+		},
+		{
+			name:     "free-form argument",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "test-customer-record"},
+			argument: "count",
+			value:    "1",
+			want:     []string{},
+		},
+		{
+			name:     "prompt not offered",
+			ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "audit-vessel-identifiers"},
+			argument: "identifiers",
+			want:     []string{},
+		},
+		{
+			name:     "resource reference",
+			ref:      &mcp.CompleteReference{Type: "ref/resource", URI: "iban://countries"},
+			argument: "country",
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.argument == "to_timezones" && len(tools.TimezoneNames()) == 0 {
+				t.Skip("No zoneinfo database installed")
+			}
+			result, err := session.Complete(context.Background(), &mcp.CompleteParams{
+				Ref:      tt.ref,
+				Argument: mcp.CompleteParamsArgument{Name: tt.argument, Value: tt.value},
+			})
+			if err != nil {
+				t.Fatalf("Complete() error = %v", err)
+			}
+			if !slices.Equal(result.Completion.Values, tt.want) {
+				t.Errorf("Expected values %v, got %v", tt.want, result.Completion.Values)
+			}
+			if result.Completion.Total != tt.total || result.Completion.HasMore != tt.hasMore {
+				t.Errorf("Expected total %d and hasMore %v, got %d and %v", tt.total, tt.hasMore, result.Completion.Total, result.Completion.HasMore)
+			}
+		})
+	}
+
+	// Timezones exceed the result limit, so the client is told there are more
+	if len(tools.TimezoneNames()) > maxCompletions {
+		result, err := session.Complete(context.Background(), &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "convert-meeting-time"},
+			Argument: mcp.CompleteParamsArgument{Name: "from_timezone"},
+		})
+		if err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+		if len(result.Completion.Values) != maxCompletions || !result.Completion.HasMore || result.Completion.Total <= maxCompletions {
+			t.Errorf("Expected %d values with hasMore, got %d values, total %d, hasMore %v",
+				maxCompletions, len(result.Completion.Values), result.Completion.Total, result.Completion.HasMore)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// MaxBulkInputs is the maximum number of values accepted in the inputs
+// parameter of a single validate request
+const MaxBulkInputs = 10000
+
+// BulkSummary counts the outcomes of validating many values
+type BulkSummary struct {
+	Total   int            `json:"total"`
+	Valid   int            `json:"valid"`
+	Invalid int            `json:"invalid"`
+	Errors  map[string]int `json:"errors"`
+}
+
+// NewBulkSummary creates an empty summary
+func NewBulkSummary() *BulkSummary {
+	return &BulkSummary{Errors: make(map[string]int)}
+}
+
+// Add counts a validation result. Errors are grouped by their first sentence,
+// so "invalid check digit. Expected 3, got 4" counts as "invalid check digit".
+func (s *BulkSummary) Add(result map[string]interface{}) {
+	s.Total++
+	if valid, _ := result["valid"].(bool); valid {
+		s.Valid++
+		return
+	}
+	s.Invalid++
+
+	message, _ := result["error"].(string)
+	message, _, _ = strings.Cut(message, ". ")
+	if message == "" {
+		message = "invalid"
+	}
+	s.Errors[message]++
+}
+
+// ValidateLine validates a single value of a bulk validation with the tool's
+// validate operation and numbers the result with the value's line. The other
+// parameters, such as the ISBN format, are passed on to the tool. Errors are
+// reported as invalid results, so one bad line does not stop the run.
+func ValidateLine(tool Tool, params map[string]interface{}, line int, input string) map[string]interface{} {
+	return validateLine(tool.Execute, params, line, input)
+}
+
+// validateLine validates a single value with validate, which reads it from the
+// "input" parameter, and numbers the result with the value's line
+func validateLine(validate func(map[string]interface{}) (interface{}, error), params map[string]interface{}, line int, input string) map[string]interface{} {
+	single := make(map[string]interface{}, len(params)+1)
+	maps.Copy(single, params)
+	delete(single, "inputs")
+	single["input"] = input
+
+	value, err := validate(single)
+	result, ok := value.(map[string]interface{})
+	if err != nil || !ok {
+		message := "unexpected validation result"
+		if err != nil {
+			message = err.Error()
+		}
+		result = map[string]interface{}{
+			"valid": false,
+			"error": message,
+			"input": input,
+		}
+	}
+	result["line"] = line
+	return result
+}
+
+// validateEach validates each value of the inputs parameter with validate and
+// returns the results, numbered from 1 in their "line" property, together with
+// a summary. It stops with the context's error when the context is cancelled.
+func validateEach(ctx context.Context, params map[string]interface{}, validate func(map[string]interface{}) (interface{}, error)) (interface{}, error) {
+	inputs, err := bulkInputs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]interface{}, len(inputs))
+	summary := NewBulkSummary()
+	err = forEach(ctx, len(inputs), "Validated", func(i int) error {
+		results[i] = validateLine(validate, params, i+1, inputs[i])
+		summary.Add(results[i])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"results": results,
+		"summary": summary,
+	}, nil
+}
+
+// hasInputs reports whether the parameters request a bulk validation
+func hasInputs(params map[string]interface{}) bool {
+	_, ok := params["inputs"]
+	return ok
+}
+
+// validateBulkParams checks the inputs parameter of a bulk validation
+func validateBulkParams(params map[string]interface{}) error {
+	if !hasInputs(params) {
+		return nil
+	}
+	if operation, _ := params["operation"].(string); operation != "" && operation != "validate" {
+		return fmt.Errorf("inputs is only supported by the validate operation")
+	}
+	if _, ok := params["input"]; ok {
+		return fmt.Errorf("input and inputs cannot be used together")
+	}
+	_, err := bulkInputs(params)
+	return err
+}
+
+// bulkInputs returns the values of the inputs parameter, given as a JSON array
+// or a Go string slice
+func bulkInputs(params map[string]interface{}) ([]string, error) {
+	var inputs []string
+	switch v := params["inputs"].(type) {
+	case []string:
+		inputs = v
+	case []interface{}:
+		inputs = make([]string, len(v))
+		for i, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("inputs must be an array of strings")
+			}
+			inputs[i] = str
+		}
+	default:
+		return nil, fmt.Errorf("inputs must be an array of strings")
+	}
+
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("inputs must contain at least one value")
+	}
+	if len(inputs) > MaxBulkInputs {
+		return nil, fmt.Errorf("inputs cannot contain more than %d values", MaxBulkInputs)
+	}
+	return inputs, nil
+}
+
+// withBulkResults adds the results and summary of a bulk validation to the
+// object properties of a tool's result schema. Each of the results is an object
+// with the properties of a single validation result, numbered by line.
+func withBulkResults(result map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	if single, ok := result["properties"].(map[string]interface{}); ok {
+		maps.Copy(properties, single)
+	}
+
+	itemProperties := map[string]interface{}{
+		"valid": map[string]interface{}{
+			"type":        "boolean",
+			"description": "Whether the value is valid",
+		},
+		"input": map[string]interface{}{
+			"type":        "string",
+			"description": "The value as given",
+		},
+		"error": map[string]interface{}{
+			"type":        "string",
+			"description": "Error message if validation fails",
+		},
+	}
+	maps.Copy(itemProperties, properties)
+	itemProperties["line"] = map[string]interface{}{
+		"type":        "integer",
+		"description": "Position of the value in inputs, counting from 1",
+	}
+
+	properties["results"] = map[string]interface{}{
+		"type":        "array",
+		"description": "Validation result of each value of inputs, in order",
+		"items": map[string]interface{}{
+			"type":       "object",
+			"properties": itemProperties,
+		},
+	}
+	properties["summary"] = bulkSummarySchema
+	result["properties"] = properties
+	return result
+}
+
+// bulkSummarySchema describes a BulkSummary
+var bulkSummarySchema = map[string]interface{}{
+	"type":        "object",
+	"description": "Counts of the validation results",
+	"properties": map[string]interface{}{
+		"total": map[string]interface{}{
+			"type":        "integer",
+			"description": "Number of values validated",
+		},
+		"valid": map[string]interface{}{
+			"type":        "integer",
+			"description": "Number of valid values",
+		},
+		"invalid": map[string]interface{}{
+			"type":        "integer",
+			"description": "Number of invalid values",
+		},
+		"errors": map[string]interface{}{
+			"type":                 "object",
+			"description":          "Number of invalid values per error, grouped by the first sentence of the error message",
+			"additionalProperties": map[string]interface{}{"type": "integer"},
+		},
+	},
+}
@@ -0,0 +1,218 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestBulkValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    Tool
+		params  map[string]interface{}
+		valid   []bool
+		errors  map[string]int
+		wantErr string
+	}{
+		{
+			name:   "iban",
+			tool:   NewIBANTool(),
+			params: map[string]interface{}{"operation": "validate", "inputs": []interface{}{"GB82WEST12345698765432", "GB82WEST12345698765433", "short"}},
+			valid:  []bool{true, false, false},
+			errors: map[string]int{"invalid check digits": 1, "IBAN must be between 15 and 34 characters": 1},
+		},
+		{
+			name:   "imo groups errors by first sentence",
+			tool:   NewIMOTool(),
+			params: map[string]interface{}{"operation": "validate", "inputs": []interface{}{"9074729", "9074728", "9074727"}},
+			valid:  []bool{true, false, false},
+			errors: map[string]int{"invalid check digit": 2},
+		},
+		{
+			name:   "default operation",
+			tool:   NewMMSITool(),
+			params: map[string]interface{}{"inputs": []interface{}{"257123450", "12345"}},
+			valid:  []bool{true, false},
+			errors: map[string]int{"MMSI number must be exactly 9 digits": 1},
+		},
+		{
+			name:   "empty values are invalid",
+			tool:   NewEAN13Tool(),
+			params: map[string]interface{}{"operation": "validate", "inputs": []interface{}{"4006381333931", ""}},
+			valid:  []bool{true, false},
+			errors: map[string]int{"input parameter is required for validation": 1},
+		},
+		{
+			name:   "other parameters apply to every value",
+			tool:   NewISBNTool(),
+			params: map[string]interface{}{"operation": "validate", "format": "isbn13", "inputs": []interface{}{"9780306406157", "0306406152"}},
+			valid:  []bool{true, false},
+		},
+		{
+			name:   "credit cards",
+			tool:   NewCreditCardTool(),
+			params: map[string]interface{}{"operation": "validate", "inputs": []interface{}{"4111111111111111", "4111111111111112"}},
+			valid:  []bool{true, false},
+		},
+		{
+			name:   "ids",
+			tool:   NewIDTool(),
+			params: map[string]interface{}{"operation": "validate", "inputs": []interface{}{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "not-a-ulid"}},
+			valid:  []bool{true, false},
+		},
+		{
+			name:    "input and inputs",
+			tool:    NewIBANTool(),
+			params:  map[string]interface{}{"operation": "validate", "input": "GB82WEST12345698765432", "inputs": []interface{}{"GB82WEST12345698765432"}},
+			wantErr: "input and inputs cannot be used together",
+		},
+		{
+			name:    "generate",
+			tool:    NewIMOTool(),
+			params:  map[string]interface{}{"operation": "generate", "inputs": []interface{}{"9074729"}},
+			wantErr: "inputs is only supported by the validate operation",
+		},
+		{
+			name:    "empty inputs",
+			tool:    NewCreditCardTool(),
+			params:  map[string]interface{}{"operation": "validate", "inputs": []interface{}{}},
+			wantErr: "inputs must contain at least one value",
+		},
+		{
+			name:    "non-string inputs",
+			tool:    NewISBNTool(),
+			params:  map[string]interface{}{"operation": "validate", "inputs": []interface{}{9780306406157.0}},
+			wantErr: "inputs[0]: must be a string",
+		},
+		{
+			name:    "too many inputs",
+			tool:    NewEAN13Tool(),
+			params:  map[string]interface{}{"operation": "validate", "inputs": repeatInputs("4006381333931", MaxBulkInputs+1)},
+			wantErr: "inputs cannot contain more than 10000 values",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewToolRegistry()
+			registry.RegisterTool(tt.tool)
+			result, err := registry.ExecuteTool(tt.tool.Name(), tt.params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			bulk := result.(map[string]interface{})
+			results := bulk["results"].([]map[string]interface{})
+			summary := bulk["summary"].(*BulkSummary)
+			if len(results) != len(tt.valid) {
+				t.Fatalf("Expected %d results, got %d", len(tt.valid), len(results))
+			}
+
+			invalid := 0
+			for i, result := range results {
+				if result["line"] != i+1 {
+					t.Errorf("Result %d: expected line %d, got %v", i, i+1, result["line"])
+				}
+				if result["valid"] != tt.valid[i] {
+					t.Errorf("Result %d: expected valid = %v, got %v", i, tt.valid[i], result)
+				}
+				if !tt.valid[i] {
+					invalid++
+				}
+			}
+
+			if summary.Total != len(tt.valid) || summary.Invalid != invalid || summary.Valid != len(tt.valid)-invalid {
+				t.Errorf("Unexpected summary %+v", summary)
+			}
+			for message, count := range tt.errors {
+				if summary.Errors[message] != count {
+					t.Errorf("Expected %d errors %q, got %v", count, message, summary.Errors)
+				}
+			}
+
+			// The output schema describes the results and summary as clients receive them
+			properties := tt.tool.GetOutputSchema()["properties"].(map[string]interface{})["result"].(map[string]interface{})["properties"].(map[string]interface{})
+			items := maps.Clone(properties["results"].(map[string]interface{})["items"].(map[string]interface{}))
+			items["additionalProperties"] = true
+			for i, result := range results {
+				if err := ValidateSchema(items, decodeJSONObject(t, result)); err != nil {
+					t.Errorf("Result %d does not match the output schema: %v", i, err)
+				}
+			}
+			if err := ValidateSchema(properties["summary"].(map[string]interface{}), decodeJSONObject(t, summary)); err != nil {
+				t.Errorf("Summary does not match the output schema: %v", err)
+			}
+		})
+	}
+}
+
+// decodeJSONObject converts a value to the JSON object an MCP client decodes
+func decodeJSONObject(t *testing.T, value interface{}) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return object
+}
+
+func TestBulkValidationCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewIBANTool().ExecuteContext(ctx, map[string]interface{}{"operation": "validate", "inputs": []interface{}{"GB82WEST12345698765432"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestValidateLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+		error string
+	}{
+		{name: "valid", input: "9074729", valid: true},
+		{name: "invalid", input: "9074728", error: "invalid check digit. Expected 9, got 8"},
+		{name: "tool error", input: "", error: "input parameter is required for validation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateLine(NewIMOTool(), map[string]interface{}{"operation": "validate"}, 7, tt.input)
+			if result["line"] != 7 {
+				t.Errorf("Expected line 7, got %v", result["line"])
+			}
+			if result["valid"] != tt.valid {
+				t.Errorf("Expected valid = %v, got %v", tt.valid, result)
+			}
+			if tt.error != "" && result["error"] != tt.error {
+				t.Errorf("Expected error %q, got %v", tt.error, result["error"])
+			}
+		})
+	}
+}
+
+// repeatInputs returns an inputs parameter holding the same value n times
+func repeatInputs(value string, n int) []interface{} {
+	inputs := make([]interface{}, n)
+	for i := range inputs {
+		inputs[i] = value
+	}
+	return inputs
+}
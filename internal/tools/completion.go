@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Completion is a candidate value for a parameter, with an optional label
+// (such as a country name) that is matched against the typed text as well
+type Completion struct {
+	Value string
+	Label string
+}
+
+// Completer is implemented by tools whose parameters take values from sets
+// the tool knows, such as country codes or timezones
+type Completer interface {
+	// CompleteParam returns the candidate values of the named parameter, or
+	// nil when the tool has no candidates for it
+	CompleteParam(name string) []Completion
+}
+
+// CompleteParam returns the values of a tool parameter that match the typed
+// prefix. Candidates come from the tool's Completer, falling back to the enum
+// declared in the input schema, or by the items of an array parameter. Values
+// are matched case-insensitively against the prefix, and so are their labels
+// and the last segment of values such as "Europe/Oslo".
+func CompleteParam(tool Tool, name, prefix string) []string {
+	var candidates []Completion
+	if completer, ok := tool.(Completer); ok {
+		candidates = completer.CompleteParam(name)
+	}
+	if candidates == nil {
+		if properties, ok := tool.GetInputSchema()["properties"].(map[string]interface{}); ok {
+			if property, ok := properties[name].(map[string]interface{}); ok {
+				// Array parameters take their candidates from the enum of their items
+				if items, ok := property["items"].(map[string]interface{}); ok {
+					property = items
+				}
+				for _, value := range schemaStrings(property["enum"]) {
+					candidates = append(candidates, Completion{Value: value})
+				}
+			}
+		}
+	}
+
+	prefix = strings.ToLower(prefix)
+	values := []string{}
+	for _, candidate := range candidates {
+		value := strings.ToLower(candidate.Value)
+		segment := value[strings.LastIndex(value, "/")+1:]
+		if strings.HasPrefix(value, prefix) || strings.HasPrefix(segment, prefix) ||
+			strings.HasPrefix(strings.ToLower(candidate.Label), prefix) {
+			values = append(values, candidate.Value)
+		}
+	}
+	return values
+}
+
+// CompleteList completes the last element of a comma-separated list, keeping
+// the elements typed before it, e.g. "UTC, Europe/Os" completes to
+// "UTC, Europe/Oslo"
+func CompleteList(tool Tool, name, value string) []string {
+	index := strings.LastIndex(value, ",")
+	if index < 0 {
+		return CompleteParam(tool, name, strings.TrimSpace(value))
+	}
+
+	head := value[:index+1]
+	if !strings.HasSuffix(head, " ") {
+		head += " "
+	}
+	values := CompleteParam(tool, name, strings.TrimSpace(value[index+1:]))
+	for i, completion := range values {
+		values[i] = head + completion
+	}
+	return values
+}
+
+// zoneNames caches the timezone names found in the zoneinfo database
+var zoneNames = sync.OnceValue(loadZoneNames)
+
+// TimezoneNames returns the sorted IANA timezone names from the zoneinfo
+// database. It returns nil when no database is installed.
+func TimezoneNames() []string {
+	return zoneNames()
+}
+
+// loadZoneNames reads the timezone names from $ZONEINFO (a directory or a
+// zoneinfo.zip archive) or the system zoneinfo directories
+func loadZoneNames() []string {
+	sources := []string{os.Getenv("ZONEINFO"), "/usr/share/zoneinfo", "/usr/share/lib/zoneinfo", "/usr/lib/locale/TZ"}
+
+	for _, source := range sources {
+		if source == "" {
+			continue
+		}
+		var names []string
+		if strings.HasSuffix(source, ".zip") {
+			names = zipZoneNames(source)
+		} else {
+			names = dirZoneNames(source)
+		}
+		if len(names) > 0 {
+			slices.Sort(names)
+			return names
+		}
+	}
+	return nil
+}
+
+// dirZoneNames lists the timezone files in a zoneinfo directory
+func dirZoneNames(dir string) []string {
+	var names []string
+	filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		name, _ := filepath.Rel(dir, path)
+		name = filepath.ToSlash(name)
+		if isZoneName(name) && hasZoneHeader(path) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	return names
+}
+
+// zipZoneNames lists the timezones in a zoneinfo.zip archive
+func zipZoneNames(path string) []string {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil
+	}
+	defer archive.Close()
+
+	var names []string
+	for _, file := range archive.File {
+		if !file.FileInfo().IsDir() && isZoneName(file.Name) {
+			names = append(names, file.Name)
+		}
+	}
+	return names
+}
+
+// isZoneName reports whether a path in a zoneinfo database names a timezone,
+// skipping the duplicate posix/ and right/ trees and the database's data files
+func isZoneName(name string) bool {
+	if name == "" || name[0] < 'A' || name[0] > 'Z' {
+		return false
+	}
+	if strings.HasPrefix(name, "posix/") || strings.HasPrefix(name, "right/") || strings.Contains(name, ".") {
+		return false
+	}
+	return name != "Factory" && name != "SECURITY"
+}
+
+// hasZoneHeader reports whether a file starts with the TZif magic of compiled timezone data
+func hasZoneHeader(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	header := make([]byte, 4)
+	n, _ := file.Read(header)
+	return n == 4 && string(header) == "TZif"
+}
@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompleteParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     Tool
+		param    string
+		prefix   string
+		want     []string
+		contains []string
+		zoneinfo bool
+	}{
+		{name: "iban country code", tool: NewIBANTool(), param: "country-code", prefix: "d", want: []string{"DE", "DK"}},
+		{name: "iban country name", tool: NewIBANTool(), param: "country-code", prefix: "norw", want: []string{"NO"}},
+		{name: "mmsi country code", tool: NewMMSITool(), param: "country-code", prefix: "NO", want: []string{"NO"}},
+		{name: "mmsi type", tool: NewMMSITool(), param: "type", prefix: "sar", want: []string{"sar-aircraft"}},
+		{name: "timezone city", tool: NewTimeTool(), param: "timezone", prefix: "osl", want: []string{"Europe/Oslo"}, zoneinfo: true},
+		{name: "timezone region", tool: NewTimeTool(), param: "timezone", prefix: "europe/", contains: []string{"Europe/Oslo", "Europe/Paris"}, zoneinfo: true},
+		{name: "timezone keywords", tool: NewTimeTool(), param: "timezone", prefix: "u", contains: []string{"utc"}},
+		{name: "time format", tool: NewTimeTool(), param: "format", prefix: "date", want: []string{"date", "datetime"}},
+		{name: "schema enum", tool: NewCreditCardTool(), param: "card-type", prefix: "", want: []string{"visa", "mastercard", "amex", "discover", "diners", "jcb"}},
+		{name: "schema enum prefix", tool: NewISBNTool(), param: "format", prefix: "ISBN1", want: []string{"isbn10", "isbn13"}},
+		{name: "array item enum", tool: NewScanTool(), param: "types", prefix: "i", want: []string{"iban", "isbn", "imo"}},
+		{name: "defaults wrapper", tool: WithDefaults(NewIBANTool(), map[string]interface{}{"count": 2}), param: "country-code", prefix: "gb", want: []string{"GB"}},
+		{name: "no match", tool: NewIBANTool(), param: "country-code", prefix: "xx", want: []string{}},
+		{name: "free-form parameter", tool: NewIBANTool(), param: "input", prefix: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.zoneinfo && len(TimezoneNames()) == 0 {
+				t.Skip("No zoneinfo database installed")
+			}
+			got := CompleteParam(tt.tool, tt.param, tt.prefix)
+			if tt.want != nil && !slices.Equal(got, tt.want) {
+				t.Errorf("CompleteParam(%s, %q) = %v, want %v", tt.param, tt.prefix, got, tt.want)
+			}
+			for _, value := range tt.contains {
+				if !slices.Contains(got, value) {
+					t.Errorf("CompleteParam(%s, %q) = %v, expected it to contain %s", tt.param, tt.prefix, got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestCompleteList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{"single value", "Asia/Toky", []string{"Asia/Tokyo"}},
+		{"last element", "UTC, Europe/Osl", []string{"UTC, Europe/Oslo"}},
+		{"without space", "UTC,Europe/Osl", []string{"UTC, Europe/Oslo"}},
+	}
+
+	if len(TimezoneNames()) == 0 {
+		t.Skip("No zoneinfo database installed")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompleteList(NewTimeTool(), "timezone", tt.value); !slices.Equal(got, tt.want) {
+				t.Errorf("CompleteList(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimezoneNames(t *testing.T) {
+	names := TimezoneNames()
+	if len(names) == 0 {
+		t.Skip("No zoneinfo database installed")
+	}
+	if !slices.IsSorted(names) {
+		t.Error("Expected timezone names to be sorted")
+	}
+	for _, name := range []string{"Europe/Oslo", "America/New_York", "UTC"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("Expected timezone names to contain %s", name)
+		}
+	}
+	for _, name := range names {
+		if !isZoneName(name) {
+			t.Errorf("Unexpected timezone name %s", name)
+		}
+	}
+}
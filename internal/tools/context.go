@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+)
+
+// ContextTool is implemented by tools that support cancellation and progress
+// reporting. Long-running tools should check the context regularly and report
+// progress with ReportProgress.
+type ContextTool interface {
+	Tool
+
+	// ExecuteContext runs the tool with the given parameters, stopping early
+	// with the context's error when it is cancelled
+	ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error)
+}
+
+// ProgressFunc receives progress updates from a running tool. Total is zero
+// when the total amount of work is unknown.
+type ProgressFunc func(progress, total float64, message string)
+
+// progressKey is the context key under which the progress reporter is stored
+type progressKey struct{}
+
+// WithProgress returns a context that delivers progress updates from tools to fn
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress reports progress to the reporter attached to the context, if any
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(progress, total, message)
+	}
+}
+
+// AsContextTool returns the tool as a ContextTool. Tools that only implement
+// Execute are wrapped in an adapter that honours cancellation before running.
+func AsContextTool(tool Tool) ContextTool {
+	if contextTool, ok := tool.(ContextTool); ok {
+		return contextTool
+	}
+	return contextAdapter{Tool: tool}
+}
+
+// contextAdapter adapts a plain Tool to the ContextTool interface
+type contextAdapter struct {
+	Tool
+}
+
+// ExecuteContext runs the wrapped tool unless the context is already done
+func (a contextAdapter) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.Execute(params)
+}
+
+// progressSteps is the number of progress updates reported for a bulk operation
+const progressSteps = 100
+
+// generateEach calls generate for each of count items. It stops with the
+// context's error as soon as the context is cancelled, and reports progress
+// at regular intervals when more than one item is generated.
+func generateEach(ctx context.Context, count int, generate func(i int) error) error {
+	return forEach(ctx, count, "Generated", generate)
+}
+
+// forEach calls fn for each of count items, stopping with the context's error
+// as soon as the context is cancelled. When there is more than one item it
+// reports progress at regular intervals, e.g. "Validated 10 of 100" for the
+// verb "Validated".
+func forEach(ctx context.Context, count int, verb string, fn func(i int) error) error {
+	step := max(count/progressSteps, 1)
+	for i := range count {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+		if count > 1 && ((i+1)%step == 0 || i+1 == count) {
+			ReportProgress(ctx, float64(i+1), float64(count), fmt.Sprintf("%s %d of %d", verb, i+1, count))
+		}
+	}
+	return nil
+}
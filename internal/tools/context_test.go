@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAsContextTool(t *testing.T) {
+	t.Run("context tool is returned as is", func(t *testing.T) {
+		tool := NewUUIDTool()
+		if AsContextTool(tool) != ContextTool(tool) {
+			t.Error("AsContextTool() should return tools implementing ContextTool unchanged")
+		}
+	})
+
+	t.Run("plain tool is adapted", func(t *testing.T) {
+		executed := false
+		tool := &MockTool{
+			name: "plain",
+			executeFunc: func(params map[string]interface{}) (interface{}, error) {
+				executed = true
+				return "done", nil
+			},
+		}
+
+		result, err := AsContextTool(tool).ExecuteContext(context.Background(), map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("ExecuteContext() error = %v", err)
+		}
+		if !executed || result != "done" {
+			t.Errorf("Expected adapted tool to run Execute, got %v", result)
+		}
+	})
+
+	t.Run("adapter honours cancellation", func(t *testing.T) {
+		executed := false
+		tool := &MockTool{
+			name: "plain",
+			executeFunc: func(params map[string]interface{}) (interface{}, error) {
+				executed = true
+				return "done", nil
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := AsContextTool(tool).ExecuteContext(ctx, map[string]interface{}{})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if executed {
+			t.Error("Cancelled tool should not have been executed")
+		}
+	})
+}
+
+func TestGenerateEachProgress(t *testing.T) {
+	tests := []struct {
+		name            string
+		count           int
+		expectedReports int
+	}{
+		{"single item reports nothing", 1, 0},
+		{"small batch reports every item", 5, 5},
+		{"large batch is throttled", 1000, 100},
+		{"uneven batch reports completion", 251, 126},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reports []float64
+			var lastTotal float64
+			ctx := WithProgress(context.Background(), func(progress, total float64, message string) {
+				reports = append(reports, progress)
+				lastTotal = total
+			})
+
+			generated := 0
+			err := generateEach(ctx, tt.count, func(i int) error {
+				generated++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("generateEach() error = %v", err)
+			}
+			if generated != tt.count {
+				t.Errorf("Expected %d items, generated %d", tt.count, generated)
+			}
+			if len(reports) != tt.expectedReports {
+				t.Errorf("Expected %d progress reports, got %d", tt.expectedReports, len(reports))
+			}
+			if len(reports) > 0 {
+				if reports[len(reports)-1] != float64(tt.count) || lastTotal != float64(tt.count) {
+					t.Errorf("Expected final report %d/%d, got %v/%v", tt.count, tt.count, reports[len(reports)-1], lastTotal)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateEachCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	generated := 0
+	err := generateEach(ctx, 1000, func(i int) error {
+		generated++
+		if generated == 10 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if generated != 10 {
+		t.Errorf("Expected generation to stop after 10 items, generated %d", generated)
+	}
+}
+
+func TestToolsExecuteContextCancellation(t *testing.T) {
+	tests := []struct {
+		name   string
+		tool   ContextTool
+		params map[string]interface{}
+	}{
+		{"random", NewRandomTool(), map[string]interface{}{"count": float64(1000)}},
+		{"uuid", NewUUIDTool(), map[string]interface{}{"version": "v7", "count": float64(1000)}},
+		{"imo", NewIMOTool(), map[string]interface{}{"operation": "generate", "count": 100}},
+		{"mmsi", NewMMSITool(), map[string]interface{}{"operation": "generate", "count": 100}},
+		{"creditcard", NewCreditCardTool(), map[string]interface{}{"operation": "generate", "count": float64(100)}},
+		{"isbn", NewISBNTool(), map[string]interface{}{"operation": "generate", "count": float64(100)}},
+		{"ean13", NewEAN13Tool(), map[string]interface{}{"operation": "generate", "count": float64(100)}},
+		{"iban", NewIBANTool(), map[string]interface{}{"operation": "generate", "count": float64(100)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Cancel half way through the generation
+			reports := 0
+			ctx = WithProgress(ctx, func(progress, total float64, message string) {
+				reports++
+				if progress >= total/2 {
+					cancel()
+				}
+			})
+
+			_, err := tt.tool.ExecuteContext(ctx, tt.params)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+			if reports == 0 {
+				t.Error("Expected progress to be reported")
+			}
+		})
+	}
+}
+
+func TestToolRegistryExecuteToolContext(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.RegisterTool(NewUUIDTool())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := registry.ExecuteToolContext(ctx, "uuid", map[string]interface{}{"count": float64(10)})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	result, err := registry.ExecuteToolContext(context.Background(), "uuid", map[string]interface{}{"count": float64(10)})
+	if err != nil {
+		t.Fatalf("ExecuteToolContext() error = %v", err)
+	}
+	if uuids, ok := result.([]string); !ok || len(uuids) != 10 {
+		t.Errorf("Expected 10 UUIDs, got %v", result)
+	}
+}
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -26,8 +27,24 @@ func (c *CreditCardTool) Description() string {
 	return "Generate and validate credit card numbers using Luhn algorithm with card type support"
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (c *CreditCardTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "Credit Card Number",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"finance", "identifiers", "generation", "validation"},
+	}
+}
+
 // Execute processes the credit card tool request
 func (c *CreditCardTool) Execute(params map[string]interface{}) (interface{}, error) {
+	return c.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext processes the credit card tool request, stopping early when the context is cancelled
+func (c *CreditCardTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	// Validate parameters first
 	if err := c.ValidateParams(params); err != nil {
 		return nil, err
@@ -40,9 +57,12 @@ func (c *CreditCardTool) Execute(params map[string]interface{}) (interface{}, er
 
 	switch operation {
 	case "validate":
+		if hasInputs(params) {
+			return validateEach(ctx, params, c.validateCreditCard)
+		}
 		return c.validateCreditCard(params)
 	case "generate":
-		return c.generateCreditCard(params)
+		return c.generateCreditCard(ctx, params)
 	default:
 		return nil, fmt.Errorf("invalid operation: %s. Supported operations: validate, generate", operation)
 	}
@@ -63,12 +83,15 @@ func (c *CreditCardTool) ValidateParams(params map[string]interface{}) error {
 
 	// Validate input for validation operation
 	if operation, ok := params["operation"]; ok {
-		if opStr, ok := operation.(string); ok && opStr == "validate" {
+		if opStr, ok := operation.(string); ok && opStr == "validate" && !hasInputs(params) {
 			if input, ok := params["input"]; !ok || input == "" {
 				return fmt.Errorf("input parameter is required for validation")
 			}
 		}
 	}
+	if err := validateBulkParams(params); err != nil {
+		return err
+	}
 
 	// Validate count
 	if count, ok := params["count"]; ok {
@@ -95,7 +118,7 @@ func (c *CreditCardTool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	return nil
+	return validateSeed(params)
 }
 
 // GetInputSchema returns the JSON schema for input parameters
@@ -110,7 +133,14 @@ func (c *CreditCardTool) GetInputSchema() map[string]interface{} {
 			},
 			"input": map[string]interface{}{
 				"type":        "string",
-				"description": "Credit card number to validate (required for validate operation)",
+				"description": "Credit card number to validate (required for validate operation unless inputs is given)",
+			},
+			"inputs": map[string]interface{}{
+				"type":        "array",
+				"description": "Credit card numbers to validate in bulk (max 10000); the result lists a validation result per value, numbered by line, and a summary",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
 			},
 			"card-type": map[string]interface{}{
 				"type":        "string",
@@ -123,8 +153,17 @@ func (c *CreditCardTool) GetInputSchema() map[string]interface{} {
 				"minimum":     1,
 				"maximum":     100,
 			},
+			"seed": map[string]interface{}{
+				"type":        "integer",
+				"description": seedParameter.Description,
+			},
+			"secure": map[string]interface{}{
+				"type":        "boolean",
+				"description": secureParameter.Description,
+			},
 		},
-		"required": []string{},
+		"required":             []string{},
+		"additionalProperties": false,
 	}
 }
 
@@ -133,29 +172,35 @@ func (c *CreditCardTool) GetOutputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"valid": map[string]interface{}{
-				"type":        "boolean",
-				"description": "Whether the credit card number is valid",
-			},
-			"card": map[string]interface{}{
-				"type":        "string",
-				"description": "Generated credit card number",
-			},
-			"cards": map[string]interface{}{
-				"type":        "array",
-				"description": "Generated credit card numbers",
+			"result": withBulkResults(map[string]interface{}{
+				"type":        []string{"string", "array", "object"},
+				"description": "Generated credit card number (or array of numbers if count>1), or validation result for validate (results and summary when validating inputs)",
 				"items": map[string]interface{}{
 					"type": "string",
 				},
-			},
-			"type": map[string]interface{}{
-				"type":        "string",
-				"description": "Detected or generated card type",
-			},
-			"error": map[string]interface{}{
-				"type":        "string",
-				"description": "Error message if validation fails",
-			},
+				"properties": map[string]interface{}{
+					"valid": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether the credit card number is valid",
+					},
+					"card": map[string]interface{}{
+						"type":        "string",
+						"description": "Normalized credit card number",
+					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Detected card type",
+					},
+					"input": map[string]interface{}{
+						"type":        "string",
+						"description": "The original input",
+					},
+					"error": map[string]interface{}{
+						"type":        "string",
+						"description": "Error message if validation fails",
+					},
+				},
+			}),
 		},
 	}
 }
@@ -340,7 +385,7 @@ func (c *CreditCardTool) validateCreditCard(params map[string]interface{}) (inte
 }
 
 // generateCreditCard generates credit card numbers
-func (c *CreditCardTool) generateCreditCard(params map[string]interface{}) (interface{}, error) {
+func (c *CreditCardTool) generateCreditCard(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	count := 1
 	if c, ok := params["count"].(float64); ok {
 		count = int(c)
@@ -351,8 +396,9 @@ func (c *CreditCardTool) generateCreditCard(params map[string]interface{}) (inte
 		cardType = ct
 	}
 
+	rng := randFor(ctx, params)
 	if count == 1 {
-		card, err := c.generateSingleCard(cardType)
+		card, err := c.generateSingleCard(rng, cardType)
 		if err != nil {
 			return nil, err
 		}
@@ -360,23 +406,27 @@ func (c *CreditCardTool) generateCreditCard(params map[string]interface{}) (inte
 	}
 
 	cards := make([]string, count)
-	for i := range count {
-		card, err := c.generateSingleCard(cardType)
+	err := generateEach(ctx, count, func(i int) error {
+		card, err := c.generateSingleCard(rng, cardType)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		cards[i] = card
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return cards, nil
 }
 
 // generateSingleCard generates a single credit card number
-func (c *CreditCardTool) generateSingleCard(cardType string) (string, error) {
+func (c *CreditCardTool) generateSingleCard(rng *rand.Rand, cardType string) (string, error) {
 	if cardType == "" {
 		// Random card type
 		types := []string{"visa", "mastercard", "amex", "discover", "diners", "jcb"}
-		cardType = types[rand.Intn(len(types))]
+		cardType = types[rng.Intn(len(types))]
 	}
 
 	var prefix string
@@ -388,18 +438,18 @@ func (c *CreditCardTool) generateSingleCard(cardType string) (string, error) {
 		length = 16
 	case "mastercard":
 		prefixes := []string{"51", "52", "53", "54", "55"}
-		prefix = prefixes[rand.Intn(len(prefixes))]
+		prefix = prefixes[rng.Intn(len(prefixes))]
 		length = 16
 	case "amex":
 		prefixes := []string{"34", "37"}
-		prefix = prefixes[rand.Intn(len(prefixes))]
+		prefix = prefixes[rng.Intn(len(prefixes))]
 		length = 15
 	case "discover":
 		prefix = "6011"
 		length = 16
 	case "diners":
 		prefixes := []string{"300", "301", "302", "303", "304", "305", "36", "38"}
-		prefix = prefixes[rand.Intn(len(prefixes))]
+		prefix = prefixes[rng.Intn(len(prefixes))]
 		length = 14
 	case "jcb":
 		prefix = "35"
@@ -412,7 +462,7 @@ func (c *CreditCardTool) generateSingleCard(cardType string) (string, error) {
 	remainingLength := length - len(prefix) - 1 // -1 for check digit
 	randomDigits := ""
 	for i := 0; i < remainingLength; i++ {
-		randomDigits += strconv.Itoa(rand.Intn(10))
+		randomDigits += strconv.Itoa(rng.Intn(10))
 	}
 
 	// Combine prefix and random digits
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// defaultsTool wraps a tool with default parameters that apply whenever the
+// caller does not set them explicitly
+type defaultsTool struct {
+	tool     Tool
+	defaults map[string]interface{}
+}
+
+// WithDefaults returns a tool that fills in the given default parameters before
+// validating and executing the wrapped tool. The defaults are also published
+// as "default" values in the input schema.
+func WithDefaults(tool Tool, defaults map[string]interface{}) Tool {
+	if len(defaults) == 0 {
+		return tool
+	}
+	return &defaultsTool{
+		tool:     tool,
+		defaults: defaults,
+	}
+}
+
+// apply returns the parameters with the defaults filled in
+func (d *defaultsTool) apply(params map[string]interface{}) map[string]interface{} {
+	merged := maps.Clone(d.defaults)
+	maps.Copy(merged, params)
+	return merged
+}
+
+// Name returns the wrapped tool's name
+func (d *defaultsTool) Name() string {
+	return d.tool.Name()
+}
+
+// Description returns the wrapped tool's description
+func (d *defaultsTool) Description() string {
+	return d.tool.Description()
+}
+
+// Annotations returns the wrapped tool's annotations
+func (d *defaultsTool) Annotations() ToolAnnotations {
+	return GetAnnotations(d.tool)
+}
+
+// Execute runs the wrapped tool with the defaults applied
+func (d *defaultsTool) Execute(params map[string]interface{}) (interface{}, error) {
+	return d.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext runs the wrapped tool with the defaults applied, stopping early when the context is cancelled
+func (d *defaultsTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	return AsContextTool(d.tool).ExecuteContext(ctx, d.apply(params))
+}
+
+// ValidateParams validates the parameters with the defaults applied
+func (d *defaultsTool) ValidateParams(params map[string]interface{}) error {
+	return d.tool.ValidateParams(d.apply(params))
+}
+
+// GetInputSchema returns the wrapped tool's input schema, with the defaults
+// documented and parameters covered by a default no longer required
+func (d *defaultsTool) GetInputSchema() map[string]interface{} {
+	schema := maps.Clone(d.tool.GetInputSchema())
+	if schema == nil {
+		return nil
+	}
+
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		properties = maps.Clone(properties)
+		for name, value := range d.defaults {
+			if property, ok := properties[name].(map[string]interface{}); ok {
+				property = maps.Clone(property)
+				property["default"] = value
+				properties[name] = property
+			}
+		}
+		schema["properties"] = properties
+	}
+
+	if required := schemaStrings(schema["required"]); len(required) > 0 {
+		schema["required"] = slices.DeleteFunc(required, func(name string) bool {
+			_, hasDefault := d.defaults[name]
+			return hasDefault
+		})
+	}
+
+	return schema
+}
+
+// GetOutputSchema returns the wrapped tool's output schema
+func (d *defaultsTool) GetOutputSchema() map[string]interface{} {
+	return d.tool.GetOutputSchema()
+}
+
+// GetResources returns the wrapped tool's resources
+func (d *defaultsTool) GetResources() []Resource {
+	return d.tool.GetResources()
+}
+
+// ReadResource reads a resource of the wrapped tool
+func (d *defaultsTool) ReadResource(uri string) (string, error) {
+	return d.tool.ReadResource(uri)
+}
+
+// CompleteParam returns the wrapped tool's candidate values for a parameter
+func (d *defaultsTool) CompleteParam(name string) []Completion {
+	if completer, ok := d.tool.(Completer); ok {
+		return completer.CompleteParam(name)
+	}
+	return nil
+}
+
+// GetPrompts returns the wrapped tool's prompts
+func (d *defaultsTool) GetPrompts() []Prompt {
+	return GetPrompts(d.tool)
+}
+
+// GetPrompt renders a prompt of the wrapped tool
+func (d *defaultsTool) GetPrompt(name string, arguments map[string]string) (string, error) {
+	if provider, ok := d.tool.(PromptProvider); ok {
+		return provider.GetPrompt(name, arguments)
+	}
+	return "", fmt.Errorf("unknown prompt: %s", name)
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestWithDefaults(t *testing.T) {
+	tool := WithDefaults(NewIBANTool(), map[string]interface{}{
+		"operation":    "generate",
+		"country-code": "DE",
+	})
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		country string
+	}{
+		{"defaults apply", map[string]interface{}{}, "DE"},
+		{"explicit parameters win", map[string]interface{}{"country-code": "FR"}, "FR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateInput(tool, tt.params); err != nil {
+				t.Fatalf("ValidateInput() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			iban, ok := result.(string)
+			if !ok || iban[:2] != tt.country {
+				t.Errorf("Expected a %s IBAN, got %v", tt.country, result)
+			}
+		})
+	}
+}
+
+func TestWithDefaultsSchema(t *testing.T) {
+	echo := NewEchoTool()
+	tool := WithDefaults(echo, map[string]interface{}{"message": "hello"})
+
+	schema := tool.GetInputSchema()
+	message := schema["properties"].(map[string]interface{})["message"].(map[string]interface{})
+	if message["default"] != "hello" {
+		t.Errorf("Expected default to be published in the schema, got %v", message["default"])
+	}
+	if required := schemaStrings(schema["required"]); slices.Contains(required, "message") {
+		t.Errorf("Parameters with a default should not be required, got %v", required)
+	}
+
+	// The wrapped tool's schema is left untouched
+	original := echo.GetInputSchema()["properties"].(map[string]interface{})["message"].(map[string]interface{})
+	if _, ok := original["default"]; ok {
+		t.Error("WithDefaults() should not modify the wrapped tool's schema")
+	}
+
+	result, err := tool.Execute(map[string]interface{}{})
+	if err != nil || result != "hello" {
+		t.Errorf("Execute() = %v, %v, want hello", result, err)
+	}
+}
+
+func TestWithDefaultsPreservesExtensions(t *testing.T) {
+	tool := WithDefaults(NewUUIDTool(), map[string]interface{}{"version": "v7"})
+
+	if tool.Name() != "uuid" {
+		t.Errorf("Expected name uuid, got %s", tool.Name())
+	}
+	if annotations := GetAnnotations(tool); annotations.Title != "UUID" {
+		t.Errorf("Expected annotations of the wrapped tool, got %+v", annotations)
+	}
+
+	contextTool, ok := tool.(ContextTool)
+	if !ok {
+		t.Fatal("Expected wrapped tool to support cancellation")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := contextTool.ExecuteContext(ctx, map[string]interface{}{"count": float64(10)}); err == nil {
+		t.Error("Expected cancelled execution to fail")
+	}
+
+	if WithDefaults(NewUUIDTool(), nil).Name() != "uuid" {
+		t.Error("WithDefaults() without defaults should return the tool")
+	}
+}
@@ -0,0 +1,362 @@
+package tools
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Limits of dice notation
+const (
+	maxDicePerRoll  = 1000
+	maxDiceSides    = 1000000
+	maxDiceGroups   = 20
+	maxDieRepeats   = 100
+	maxDiceModifier = 1000000
+)
+
+// diceSyntax summarises the supported dice notation for the documentation
+const diceSyntax = "NdM groups joined by + or -, with optional kN/khN/klN (keep highest/lowest), dhN/dlN (drop highest/lowest), ! or !<compare> (explode), rN/r<compare> (reroll until it misses), roN/ro<compare> (reroll once) and integer modifiers, e.g. 4d6dl1+2, 4d6 drop lowest + 2 or 1d20+1d4-1"
+
+// diceWords matches the spelled-out keep and drop modifiers, e.g. "drop lowest"
+var diceWords = regexp.MustCompile(`(keep|drop)\s+(highest|lowest)`)
+
+// diceSplitNumber matches digits separated by whitespace, which would run
+// together into a different number once the whitespace is removed
+var diceSplitNumber = regexp.MustCompile(`\d\s+\d`)
+
+// comparePoint selects the faces of a die that explode or are rerolled
+type comparePoint struct {
+	Op    string
+	Value int
+}
+
+// matches reports whether a face meets the compare point
+func (c comparePoint) matches(face int) bool {
+	switch c.Op {
+	case ">":
+		return face > c.Value
+	case ">=":
+		return face >= c.Value
+	case "<":
+		return face < c.Value
+	case "<=":
+		return face <= c.Value
+	}
+	return face == c.Value
+}
+
+// matchesEvery reports whether every face of a die with the given sides meets
+// the compare point; as the faces are consecutive, checking the lowest and
+// highest is enough
+func (c comparePoint) matchesEvery(sides int) bool {
+	return c.matches(1) && c.matches(sides)
+}
+
+// diceGroup is one NdM term of a dice expression with its modifiers
+type diceGroup struct {
+	Notation   string
+	Sign       int
+	Count      int
+	Sides      int
+	Keep       string // kh, kl, dh or dl
+	KeepCount  int
+	Explode    *comparePoint
+	Reroll     *comparePoint
+	RerollOnce bool
+}
+
+// diceExpression is a parsed dice notation: dice groups plus a constant modifier
+type diceExpression struct {
+	Notation string
+	Groups   []diceGroup
+	Modifier int
+}
+
+// diceParser reads dice notation one character at a time
+type diceParser struct {
+	input   string
+	pos     int
+	lastLen int
+}
+
+// parseDiceNotation parses dice notation such as "4d6dl1+2"; it ignores case
+// and whitespace and accepts spelled-out modifiers such as "4d6 drop lowest + 2"
+func parseDiceNotation(notation string) (*diceExpression, error) {
+	input := diceWords.ReplaceAllStringFunc(strings.ToLower(notation), func(words string) string {
+		match := diceWords.FindStringSubmatch(words)
+		return match[1][:1] + match[2][:1]
+	})
+	if split := diceSplitNumber.FindString(input); split != "" {
+		return nil, fmt.Errorf("invalid dice notation %q: expected + or - between %q", notation, split)
+	}
+	input = strings.Join(strings.Fields(input), "")
+	if input == "" {
+		return nil, fmt.Errorf("notation parameter is required, e.g. 4d6dl1+2")
+	}
+	p := &diceParser{input: input}
+	expr := &diceExpression{Notation: input}
+	dice := 0
+	for p.pos < len(p.input) {
+		start := p.pos
+		sign := 1
+		if p.accept("-") {
+			sign = -1
+		} else if !p.accept("+") && start > 0 {
+			return nil, p.syntaxError("expected + or -")
+		}
+
+		number, hasNumber := p.number()
+		if !p.accept("d") {
+			if !hasNumber {
+				return nil, p.syntaxError("expected a number or dice")
+			}
+			expr.Modifier += sign * number
+			if expr.Modifier > maxDiceModifier || expr.Modifier < -maxDiceModifier {
+				return nil, fmt.Errorf("dice modifiers must add up to between -%d and %d", maxDiceModifier, maxDiceModifier)
+			}
+			continue
+		}
+
+		group, err := p.group(sign, number, hasNumber)
+		if err != nil {
+			return nil, err
+		}
+		group.Notation = strings.TrimPrefix(p.input[start:p.pos], "+")
+		expr.Groups = append(expr.Groups, *group)
+		dice += group.Count
+	}
+
+	if len(expr.Groups) == 0 {
+		return nil, fmt.Errorf("dice notation %q contains no dice", notation)
+	}
+	if len(expr.Groups) > maxDiceGroups {
+		return nil, fmt.Errorf("dice notation must not contain more than %d groups", maxDiceGroups)
+	}
+	if dice > maxDicePerRoll {
+		return nil, fmt.Errorf("dice notation must not roll more than %d dice, got %d", maxDicePerRoll, dice)
+	}
+	return expr, nil
+}
+
+// group parses the sides and modifiers of a dice group after its "d"
+func (p *diceParser) group(sign, count int, hasCount bool) (*diceGroup, error) {
+	group := &diceGroup{Sign: sign, Count: 1}
+	if hasCount {
+		if count < 1 {
+			return nil, p.syntaxError("the number of dice must be at least 1")
+		}
+		group.Count = count
+	}
+	if p.accept("%") {
+		group.Sides = 100
+	} else {
+		sides, ok := p.number()
+		if !ok {
+			return nil, p.syntaxError("expected the number of sides")
+		}
+		if sides < 1 || sides > maxDiceSides {
+			return nil, p.syntaxError(fmt.Sprintf("the number of sides must be between 1 and %d", maxDiceSides))
+		}
+		group.Sides = sides
+	}
+
+	for p.pos < len(p.input) && p.input[p.pos] != '+' && p.input[p.pos] != '-' {
+		switch {
+		case p.accept("kh"), p.accept("kl"), p.accept("k"), p.accept("dh"), p.accept("dl"):
+			if group.Keep != "" {
+				return nil, p.syntaxError("only one keep or drop modifier is allowed per group")
+			}
+			group.Keep = p.input[p.last():p.pos]
+			if group.Keep == "k" {
+				group.Keep = "kh"
+			}
+			group.KeepCount = 1
+			if n, ok := p.number(); ok {
+				group.KeepCount = n
+			}
+			if group.KeepCount > group.Count {
+				return nil, p.syntaxError(fmt.Sprintf("cannot keep or drop %d of %d dice", group.KeepCount, group.Count))
+			}
+		case p.accept("!"):
+			if group.Explode != nil {
+				return nil, p.syntaxError("only one explode modifier is allowed per group")
+			}
+			point, ok := p.comparePoint()
+			if !ok {
+				point = comparePoint{Op: "=", Value: group.Sides}
+			}
+			if point.matchesEvery(group.Sides) {
+				return nil, p.syntaxError("dice that explode on every face would never stop")
+			}
+			group.Explode = &point
+		case p.accept("ro"), p.accept("r"):
+			if group.Reroll != nil {
+				return nil, p.syntaxError("only one reroll modifier is allowed per group")
+			}
+			group.RerollOnce = p.input[p.last():p.pos] == "ro"
+			point, ok := p.comparePoint()
+			if !ok {
+				return nil, p.syntaxError("expected the faces to reroll, e.g. r1 or r<3")
+			}
+			if point.matchesEvery(group.Sides) {
+				return nil, p.syntaxError("cannot reroll every face of the die")
+			}
+			group.Reroll = &point
+		default:
+			return nil, p.syntaxError("unknown dice modifier")
+		}
+	}
+	return group, nil
+}
+
+// comparePoint parses an optional compare point such as 6, =6, >5, >=5, <2 or <=2
+func (p *diceParser) comparePoint() (comparePoint, bool) {
+	start := p.pos
+	point := comparePoint{Op: "="}
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if p.accept(op) {
+			point.Op = op
+			break
+		}
+	}
+	value, ok := p.number()
+	if !ok {
+		p.pos = start
+		return comparePoint{}, false
+	}
+	point.Value = value
+	return point, true
+}
+
+// accept consumes token when the input continues with it
+func (p *diceParser) accept(token string) bool {
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		p.lastLen = len(token)
+		return true
+	}
+	return false
+}
+
+// last returns the position of the last accepted token
+func (p *diceParser) last() int {
+	return p.pos - p.lastLen
+}
+
+// number consumes a non-negative integer
+func (p *diceParser) number() (int, bool) {
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, false
+	}
+	value, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil || value > maxDiceModifier {
+		// Larger numbers are rejected by every limit, so cap them
+		value = maxDiceModifier + 1
+	}
+	return value, true
+}
+
+// syntaxError reports a syntax error at the current position
+func (p *diceParser) syntaxError(message string) error {
+	return fmt.Errorf("invalid dice notation %q at position %d: %s", p.input, p.pos+1, message)
+}
+
+// roll rolls the dice of an expression and returns the individual rolls,
+// which dice were kept, and the total
+func (e *diceExpression) roll(rng *rand.Rand) map[string]interface{} {
+	total := int64(e.Modifier)
+	groups := make([]map[string]interface{}, len(e.Groups))
+	for i, group := range e.Groups {
+		result, subtotal := group.roll(rng)
+		groups[i] = result
+		total += subtotal
+	}
+	return map[string]interface{}{
+		"notation": e.Notation,
+		"groups":   groups,
+		"modifier": e.Modifier,
+		"total":    total,
+	}
+}
+
+// roll rolls the dice of a group and returns them with their signed subtotal
+func (g diceGroup) roll(rng *rand.Rand) (map[string]interface{}, int64) {
+	var rolls, rerolled []int
+	rollDie := func() int {
+		face := rng.Intn(g.Sides) + 1
+		for n := 0; g.Reroll != nil && g.Reroll.matches(face) && n < maxDieRepeats; n++ {
+			rerolled = append(rerolled, face)
+			face = rng.Intn(g.Sides) + 1
+			if g.RerollOnce {
+				break
+			}
+		}
+		return face
+	}
+	for range g.Count {
+		face := rollDie()
+		rolls = append(rolls, face)
+		// Exploded dice are extra dice that can be kept or dropped like the others
+		for n := 0; g.Explode != nil && g.Explode.matches(face) && n < maxDieRepeats; n++ {
+			face = rollDie()
+			rolls = append(rolls, face)
+		}
+	}
+
+	kept := make([]bool, len(rolls))
+	for i := range kept {
+		kept[i] = g.Keep == ""
+	}
+	if g.Keep != "" {
+		// Order the dice from lowest to highest, keeping ties in roll order
+		order := make([]int, len(rolls))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return rolls[order[a]] < rolls[order[b]]
+		})
+		n := min(g.KeepCount, len(rolls))
+		var selected []int
+		switch g.Keep {
+		case "kh":
+			selected = order[len(order)-n:]
+		case "kl":
+			selected = order[:n]
+		case "dh":
+			selected = order[:len(order)-n]
+		case "dl":
+			selected = order[n:]
+		}
+		for _, i := range selected {
+			kept[i] = true
+		}
+	}
+
+	subtotal := int64(0)
+	for i, face := range rolls {
+		if kept[i] {
+			subtotal += int64(face)
+		}
+	}
+	subtotal *= int64(g.Sign)
+
+	result := map[string]interface{}{
+		"dice":     g.Notation,
+		"rolls":    rolls,
+		"kept":     kept,
+		"subtotal": subtotal,
+	}
+	if len(rerolled) > 0 {
+		result["rerolled"] = rerolled
+	}
+	return result, subtotal
+}
@@ -0,0 +1,268 @@
+package tools
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestParseDiceNotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		want     string
+		groups   []diceGroup
+		modifier int
+	}{
+		{"single die", "d20", "d20", []diceGroup{{Notation: "d20", Sign: 1, Count: 1, Sides: 20}}, 0},
+		{"percentile", "2d%", "2d%", []diceGroup{{Notation: "2d%", Sign: 1, Count: 2, Sides: 100}}, 0},
+		{"drop lowest with modifier", "4d6dl1+2", "4d6dl1+2", []diceGroup{{Notation: "4d6dl1", Sign: 1, Count: 4, Sides: 6, Keep: "dl", KeepCount: 1}}, 2},
+		{"spelled out", "4d6 Drop Lowest + 2", "4d6dl+2", []diceGroup{{Notation: "4d6dl", Sign: 1, Count: 4, Sides: 6, Keep: "dl", KeepCount: 1}}, 2},
+		{"keep shorthand", "5d6k3", "5d6k3", []diceGroup{{Notation: "5d6k3", Sign: 1, Count: 5, Sides: 6, Keep: "kh", KeepCount: 3}}, 0},
+		{"keep lowest", "2d20kl", "2d20kl", []diceGroup{{Notation: "2d20kl", Sign: 1, Count: 2, Sides: 20, Keep: "kl", KeepCount: 1}}, 0},
+		{"drop highest", "3d8dh2", "3d8dh2", []diceGroup{{Notation: "3d8dh2", Sign: 1, Count: 3, Sides: 8, Keep: "dh", KeepCount: 2}}, 0},
+		{"explode on max", "3d6!", "3d6!", []diceGroup{{Notation: "3d6!", Sign: 1, Count: 3, Sides: 6, Explode: &comparePoint{Op: "=", Value: 6}}}, 0},
+		{"explode on compare", "3d10!>=9", "3d10!>=9", []diceGroup{{Notation: "3d10!>=9", Sign: 1, Count: 3, Sides: 10, Explode: &comparePoint{Op: ">=", Value: 9}}}, 0},
+		{"reroll", "2d6r1", "2d6r1", []diceGroup{{Notation: "2d6r1", Sign: 1, Count: 2, Sides: 6, Reroll: &comparePoint{Op: "=", Value: 1}}}, 0},
+		{"reroll once", "2d6ro<3", "2d6ro<3", []diceGroup{{Notation: "2d6ro<3", Sign: 1, Count: 2, Sides: 6, Reroll: &comparePoint{Op: "<", Value: 3}, RerollOnce: true}}, 0},
+		{
+			name:     "multiple groups",
+			notation: "1d20 + 1d4 - 1d6 - 1 + 3",
+			want:     "1d20+1d4-1d6-1+3",
+			groups: []diceGroup{
+				{Notation: "1d20", Sign: 1, Count: 1, Sides: 20},
+				{Notation: "1d4", Sign: 1, Count: 1, Sides: 4},
+				{Notation: "-1d6", Sign: -1, Count: 1, Sides: 6},
+			},
+			modifier: 2,
+		},
+		{"leading modifier", "-2+1D8", "-2+1d8", []diceGroup{{Notation: "1d8", Sign: 1, Count: 1, Sides: 8}}, -2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseDiceNotation(tt.notation)
+			if err != nil {
+				t.Fatalf("parseDiceNotation() error = %v", err)
+			}
+			if expr.Notation != tt.want {
+				t.Errorf("Expected notation %q, got %q", tt.want, expr.Notation)
+			}
+			if expr.Modifier != tt.modifier {
+				t.Errorf("Expected modifier %d, got %d", tt.modifier, expr.Modifier)
+			}
+			if len(expr.Groups) != len(tt.groups) {
+				t.Fatalf("Expected %d groups, got %+v", len(tt.groups), expr.Groups)
+			}
+			for i, group := range expr.Groups {
+				want := tt.groups[i]
+				if group.Notation != want.Notation || group.Sign != want.Sign || group.Count != want.Count || group.Sides != want.Sides ||
+					group.Keep != want.Keep || group.KeepCount != want.KeepCount || group.RerollOnce != want.RerollOnce ||
+					!equalComparePoints(group.Explode, want.Explode) || !equalComparePoints(group.Reroll, want.Reroll) {
+					t.Errorf("Expected group %+v, got %+v", want, group)
+				}
+			}
+		})
+	}
+}
+
+// equalComparePoints reports whether two optional compare points are equal
+func equalComparePoints(a, b *comparePoint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestParseDiceNotationErrors(t *testing.T) {
+	tests := []struct {
+		notation string
+		wantErr  string
+	}{
+		{"", "notation parameter is required"},
+		{"5", "contains no dice"},
+		{"0d6", "the number of dice must be at least 1"},
+		{"2d", "expected the number of sides"},
+		{"2d0", "the number of sides must be between 1 and 1000000"},
+		{"1d1000001", "the number of sides must be between 1 and 1000000"},
+		{"1001d6", "must not roll more than 1000 dice"},
+		{"600d6+600d6", "must not roll more than 1000 dice"},
+		{"4d6x", "at position 4: unknown dice modifier"},
+		{"3d6dl4", "cannot keep or drop 4 of 3 dice"},
+		{"4d6dl1kh2", "only one keep or drop modifier"},
+		{"1d1!", "would never stop"},
+		{"3d6!>0", "would never stop"},
+		{"3d6!!", "only one explode modifier"},
+		{"3d6r", "expected the faces to reroll"},
+		{"3d6r<=6", "cannot reroll every face"},
+		{"3d6r1r2", "only one reroll modifier"},
+		{"2d6 3", "expected + or -"},
+		{"2d6+", "expected a number or dice"},
+		{"2d6+*", "expected a number or dice"},
+		{"1d6+9999999", "modifiers must add up to between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			_, err := parseDiceNotation(tt.notation)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseDiceNotation(%q) error = %v, want %q", tt.notation, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRollDice(t *testing.T) {
+	tests := []struct {
+		notation string
+		kept     int // number of kept dice, or -1 for all of them
+		check    func(t *testing.T, rolls []int, rerolled []int)
+	}{
+		{"4d6dl1+2", 3, nil},
+		{"4d6dh1", 3, nil},
+		{"2d20kh1", 1, nil},
+		{"2d20kl1", 1, nil},
+		{"10d6", -1, func(t *testing.T, rolls []int, _ []int) {
+			if len(rolls) != 10 {
+				t.Errorf("Expected 10 rolls, got %v", rolls)
+			}
+		}},
+		{"10d6!", -1, func(t *testing.T, rolls []int, _ []int) {
+			// Every six adds a die, so there is one die more than there are sixes
+			sixes := 0
+			for _, face := range rolls {
+				if face == 6 {
+					sixes++
+				}
+			}
+			if len(rolls) != 10+sixes {
+				t.Errorf("Expected %d rolls for %d sixes, got %v", 10+sixes, sixes, rolls)
+			}
+		}},
+		{"20d6r<3", -1, func(t *testing.T, rolls []int, rerolled []int) {
+			for _, face := range rolls {
+				if face < 3 {
+					t.Errorf("Expected faces below 3 to be rerolled, got %v", rolls)
+				}
+			}
+			for _, face := range rerolled {
+				if face >= 3 {
+					t.Errorf("Expected only faces below 3 to be rerolled, got %v", rerolled)
+				}
+			}
+		}},
+		{"20d6ro1", -1, func(t *testing.T, rolls []int, rerolled []int) {
+			if len(rolls) != 20 {
+				t.Errorf("Expected 20 rolls, got %v", rolls)
+			}
+		}},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			expr, err := parseDiceNotation(tt.notation)
+			if err != nil {
+				t.Fatalf("parseDiceNotation() error = %v", err)
+			}
+			for range 50 {
+				result := expr.roll(rng)
+				total := int64(expr.Modifier)
+				for i, group := range result["groups"].([]map[string]interface{}) {
+					rolls := group["rolls"].([]int)
+					kept := group["kept"].([]bool)
+					rerolled, _ := group["rerolled"].([]int)
+					if len(kept) != len(rolls) {
+						t.Fatalf("Expected a kept flag per roll, got %v for %v", kept, rolls)
+					}
+
+					subtotal, keptCount := int64(0), 0
+					lowestKept, highestDropped := 1<<31, 0
+					highestKept, lowestDropped := 0, 1<<31
+					for j, face := range rolls {
+						if face < 1 || face > expr.Groups[i].Sides {
+							t.Errorf("Roll %d out of range in %v", face, rolls)
+						}
+						if kept[j] {
+							subtotal += int64(face)
+							keptCount++
+							lowestKept, highestKept = min(lowestKept, face), max(highestKept, face)
+						} else {
+							highestDropped, lowestDropped = max(highestDropped, face), min(lowestDropped, face)
+						}
+					}
+					if tt.kept >= 0 && keptCount != tt.kept {
+						t.Errorf("Expected %d kept dice, got %v of %v", tt.kept, kept, rolls)
+					}
+					if tt.kept < 0 && keptCount != len(rolls) {
+						t.Errorf("Expected every die to be kept, got %v", kept)
+					}
+					switch expr.Groups[i].Keep {
+					case "kh", "dl":
+						if keptCount < len(rolls) && lowestKept < highestDropped {
+							t.Errorf("Expected the highest dice to be kept, got %v of %v", kept, rolls)
+						}
+					case "kl", "dh":
+						if keptCount < len(rolls) && highestKept > lowestDropped {
+							t.Errorf("Expected the lowest dice to be kept, got %v of %v", kept, rolls)
+						}
+					}
+
+					subtotal *= int64(expr.Groups[i].Sign)
+					if group["subtotal"] != subtotal {
+						t.Errorf("Expected subtotal %d, got %v", subtotal, group["subtotal"])
+					}
+					total += subtotal
+					if tt.check != nil {
+						tt.check(t, rolls, rerolled)
+					}
+				}
+				if result["total"] != total {
+					t.Errorf("Expected total %d, got %v", total, result["total"])
+				}
+			}
+		})
+	}
+}
+
+func TestRandomToolDice(t *testing.T) {
+	tool := NewRandomTool()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"roll", map[string]interface{}{"type": "dice", "notation": "4d6dl1+2"}, ""},
+		{"several rolls", map[string]interface{}{"type": "dice", "notation": "1d20", "count": 6.0}, ""},
+		{"notation missing", map[string]interface{}{"type": "dice"}, "notation parameter is required"},
+		{"notation not a string", map[string]interface{}{"type": "dice", "notation": 20.0}, "notation parameter must be a string"},
+		{"invalid notation", map[string]interface{}{"type": "dice", "notation": "4d6x"}, "unknown dice modifier"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tool.ValidateParams(tt.params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ValidateParams() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if count, ok := tt.params["count"].(float64); ok {
+				if rolls, ok := result.([]map[string]interface{}); !ok || len(rolls) != int(count) {
+					t.Errorf("Expected %v rolls, got %v", count, result)
+				}
+			} else if _, ok := result.(map[string]interface{})["total"].(int64); !ok {
+				t.Errorf("Expected a total, got %v", result)
+			}
+		})
+	}
+}
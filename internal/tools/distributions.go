@@ -0,0 +1,379 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Limits of the distribution and sampling parameters
+const (
+	maxBinomialTrials = 100000
+	maxSampleItems    = 10000
+	maxSampleSize     = 1000
+)
+
+// drawFunc draws one value of a distribution or sampling operation
+type drawFunc func(rng *rand.Rand) interface{}
+
+// parseDistribution returns the draw function of a distribution or sampling
+// type configured by the parameters, or an error when they are invalid
+func parseDistribution(typeParam string, params map[string]interface{}) (drawFunc, error) {
+	switch typeParam {
+	case "normal":
+		mean, err := numberParam(params, "mean", 0)
+		if err != nil {
+			return nil, err
+		}
+		stddev, err := numberParam(params, "stddev", 1)
+		if err != nil {
+			return nil, err
+		}
+		if stddev < 0 {
+			return nil, fmt.Errorf("stddev must not be negative")
+		}
+		precision, err := precisionParam(params)
+		if err != nil {
+			return nil, err
+		}
+		return func(rng *rand.Rand) interface{} {
+			return roundPrecision(mean+stddev*rng.NormFloat64(), precision)
+		}, nil
+
+	case "lognormal":
+		mu, err := numberParam(params, "mu", 0)
+		if err != nil {
+			return nil, err
+		}
+		sigma, err := numberParam(params, "sigma", 1)
+		if err != nil {
+			return nil, err
+		}
+		if sigma < 0 {
+			return nil, fmt.Errorf("sigma must not be negative")
+		}
+		precision, err := precisionParam(params)
+		if err != nil {
+			return nil, err
+		}
+		return func(rng *rand.Rand) interface{} {
+			return roundPrecision(math.Exp(mu+sigma*rng.NormFloat64()), precision)
+		}, nil
+
+	case "exponential":
+		rate, err := numberParam(params, "rate", 1)
+		if err != nil {
+			return nil, err
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate must be greater than 0")
+		}
+		precision, err := precisionParam(params)
+		if err != nil {
+			return nil, err
+		}
+		return func(rng *rand.Rand) interface{} {
+			return roundPrecision(rng.ExpFloat64()/rate, precision)
+		}, nil
+
+	case "poisson":
+		lambda, err := numberParam(params, "lambda", 1)
+		if err != nil {
+			return nil, err
+		}
+		if lambda < 0 {
+			return nil, fmt.Errorf("lambda must not be negative")
+		}
+		return func(rng *rand.Rand) interface{} {
+			return poisson(rng, lambda)
+		}, nil
+
+	case "binomial":
+		trials := 10
+		if value, ok := params["trials"]; ok {
+			var valid bool
+			trials, valid = IntParam(value)
+			if !valid || trials < 0 || trials > maxBinomialTrials {
+				return nil, fmt.Errorf("trials must be an integer between 0 and %d", maxBinomialTrials)
+			}
+		}
+		probability, err := numberParam(params, "probability", 0.5)
+		if err != nil {
+			return nil, err
+		}
+		if probability < 0 || probability > 1 {
+			return nil, fmt.Errorf("probability must be between 0 and 1")
+		}
+		return func(rng *rand.Rand) interface{} {
+			return binomial(rng, trials, probability)
+		}, nil
+
+	case "categorical":
+		categories, err := itemsParam(params, "categories")
+		if err != nil {
+			return nil, err
+		}
+		weights, err := weightsParam(params, len(categories), true)
+		if err != nil {
+			return nil, err
+		}
+		return func(rng *rand.Rand) interface{} {
+			return categories[weightedIndex(rng, weights)]
+		}, nil
+
+	case "shuffle":
+		items, err := itemsParam(params, "items")
+		if err != nil {
+			return nil, err
+		}
+		return func(rng *rand.Rand) interface{} {
+			shuffled := append([]interface{}(nil), items...)
+			rng.Shuffle(len(shuffled), func(i, j int) {
+				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+			})
+			return shuffled
+		}, nil
+
+	case "sample", "choice":
+		items, err := itemsParam(params, "items")
+		if err != nil {
+			return nil, err
+		}
+		// Weighted choice needs weights; a plain sample treats all items alike
+		weights, err := weightsParam(params, len(items), typeParam == "choice")
+		if err != nil {
+			return nil, err
+		}
+		replacement, _ := params["replacement"].(bool)
+		if _, ok := params["replacement"]; !ok {
+			replacement = typeParam == "choice"
+		}
+		k := 1
+		if value, ok := params["k"]; ok {
+			var valid bool
+			k, valid = IntParam(value)
+			if !valid || k < 1 || k > maxSampleSize {
+				return nil, fmt.Errorf("k must be an integer between 1 and %d", maxSampleSize)
+			}
+		}
+		if !replacement {
+			available := len(items)
+			if weights != nil {
+				available = 0
+				for _, weight := range weights {
+					if weight > 0 {
+						available++
+					}
+				}
+			}
+			if k > available {
+				return nil, fmt.Errorf("k must not exceed the %d items that can be drawn without replacement", available)
+			}
+		}
+		return func(rng *rand.Rand) interface{} {
+			return sample(rng, items, weights, k, replacement)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported distribution: %s", typeParam)
+}
+
+// poisson draws from the Poisson distribution with mean lambda. Small means
+// use Knuth's multiplication method; larger ones Hörmann's transformed
+// rejection (PTRS), whose running time does not grow with the mean.
+func poisson(rng *rand.Rand, lambda float64) int64 {
+	if lambda < 10 {
+		limit := math.Exp(-lambda)
+		k := int64(0)
+		for product := rng.Float64(); product > limit; product *= rng.Float64() {
+			k++
+		}
+		return k
+	}
+
+	sqrtLambda := math.Sqrt(lambda)
+	logLambda := math.Log(lambda)
+	b := 0.931 + 2.53*sqrtLambda
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := rng.Float64() - 0.5
+		v := rng.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int64(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		logFactorial, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLambda-logFactorial {
+			return int64(k)
+		}
+	}
+}
+
+// binomial draws the number of successes in n trials with success probability
+// p by skipping over the failures between successes with geometric draws,
+// which takes about n*min(p, 1-p) steps
+func binomial(rng *rand.Rand, n int, p float64) int64 {
+	if p > 0.5 {
+		return int64(n) - binomial(rng, n, 1-p)
+	}
+	if p == 0 {
+		return 0
+	}
+	logFailure := math.Log1p(-p)
+	successes := int64(0)
+	position := 0.0
+	for {
+		position += math.Floor(math.Log(1-rng.Float64())/logFailure) + 1
+		if position > float64(n) {
+			return successes
+		}
+		successes++
+	}
+}
+
+// weightedIndex draws an index with probability proportional to its weight
+func weightedIndex(rng *rand.Rand, weights []float64) int {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	target := rng.Float64() * total
+	last := 0
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		if target < weight {
+			return i
+		}
+		target -= weight
+		last = i
+	}
+	// Rounding can leave a sliver of the total; it belongs to the last item
+	return last
+}
+
+// sample draws k items, with probabilities proportional to the weights when
+// given. Without replacement, a drawn item is taken out of later draws.
+func sample(rng *rand.Rand, items []interface{}, weights []float64, k int, replacement bool) []interface{} {
+	if weights == nil {
+		weights = make([]float64, len(items))
+		for i := range weights {
+			weights[i] = 1
+		}
+	} else if !replacement {
+		weights = append([]float64(nil), weights...)
+	}
+	drawn := make([]interface{}, k)
+	for i := range drawn {
+		index := weightedIndex(rng, weights)
+		drawn[i] = items[index]
+		if !replacement {
+			weights[index] = 0
+		}
+	}
+	return drawn
+}
+
+// numberParam returns the named number parameter, or fallback when it is absent
+func numberParam(params map[string]interface{}, name string, fallback float64) (float64, error) {
+	value, ok := params[name]
+	if !ok {
+		return fallback, nil
+	}
+	number, ok := toFloat(value)
+	if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("%s must be a number", name)
+	}
+	return number, nil
+}
+
+// precisionParam returns the number of decimal places of continuous values
+func precisionParam(params map[string]interface{}) (int, error) {
+	precision := 2
+	if value, ok := params["precision"]; ok {
+		var valid bool
+		precision, valid = IntParam(value)
+		if !valid || precision < 0 || precision > 10 {
+			return 0, fmt.Errorf("precision must be between 0 and 10")
+		}
+	}
+	return precision, nil
+}
+
+// roundPrecision rounds a value to the given number of decimal places
+func roundPrecision(value float64, precision int) float64 {
+	multiplier := math.Pow(10, float64(precision))
+	return math.Round(value*multiplier) / multiplier
+}
+
+// itemsParam returns the named list of items to draw from. JSON arrays arrive
+// as []interface{}, while CLI callers pass []string.
+func itemsParam(params map[string]interface{}, name string) ([]interface{}, error) {
+	var items []interface{}
+	switch v := params[name].(type) {
+	case nil:
+		return nil, fmt.Errorf("%s parameter is required", name)
+	case []interface{}:
+		items = v
+	case []string:
+		items = make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+	default:
+		return nil, fmt.Errorf("%s must be an array", name)
+	}
+	if len(items) == 0 || len(items) > maxSampleItems {
+		return nil, fmt.Errorf("%s must contain between 1 and %d items", name, maxSampleItems)
+	}
+	return items, nil
+}
+
+// weightsParam returns the weights of n items, or nil when they are optional
+// and absent. Weights must be non-negative with a positive sum.
+func weightsParam(params map[string]interface{}, n int, required bool) ([]float64, error) {
+	value, ok := params["weights"]
+	if !ok {
+		if required {
+			return nil, fmt.Errorf("weights parameter is required")
+		}
+		return nil, nil
+	}
+	var weights []float64
+	switch v := value.(type) {
+	case []float64:
+		weights = v
+	case []interface{}:
+		weights = make([]float64, len(v))
+		for i, item := range v {
+			weight, ok := toFloat(item)
+			if !ok {
+				return nil, fmt.Errorf("weights must be an array of numbers")
+			}
+			weights[i] = weight
+		}
+	default:
+		return nil, fmt.Errorf("weights must be an array of numbers")
+	}
+	if len(weights) != n {
+		return nil, fmt.Errorf("weights must have one weight per item: got %d weights for %d items", len(weights), n)
+	}
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("weights must be non-negative numbers")
+		}
+		total += weight
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("weights must not all be 0")
+	}
+	return weights, nil
+}
@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDistributionMoments(t *testing.T) {
+	const samples = 20000
+
+	tests := []struct {
+		name     string
+		typ      string
+		params   map[string]interface{}
+		mean     float64
+		variance float64
+	}{
+		{"standard normal", "normal", map[string]interface{}{"precision": 6.0}, 0, 1},
+		{"normal", "normal", map[string]interface{}{"mean": 170.0, "stddev": 10.0, "precision": 4.0}, 170, 100},
+		{"lognormal", "lognormal", map[string]interface{}{"mu": 1.0, "sigma": 0.5, "precision": 6.0}, math.Exp(1.125), (math.Exp(0.25) - 1) * math.Exp(2.25)},
+		{"exponential", "exponential", map[string]interface{}{"rate": 2.0, "precision": 6.0}, 0.5, 0.25},
+		{"poisson small", "poisson", map[string]interface{}{"lambda": 3.0}, 3, 3},
+		{"poisson large", "poisson", map[string]interface{}{"lambda": 250.0}, 250, 250},
+		{"binomial", "binomial", map[string]interface{}{"trials": 20.0, "probability": 0.3}, 6, 4.2},
+		{"binomial above one half", "binomial", map[string]interface{}{"trials": 40.0, "probability": 0.8}, 32, 6.4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draw, err := parseDistribution(tt.typ, tt.params)
+			if err != nil {
+				t.Fatalf("parseDistribution() error = %v", err)
+			}
+			rng := rand.New(rand.NewSource(1))
+			values := make([]float64, samples)
+			for i := range values {
+				value, ok := toFloat(draw(rng))
+				if !ok {
+					t.Fatalf("Expected a number, got %T", draw(rng))
+				}
+				values[i] = value
+			}
+
+			mean, variance := 0.0, 0.0
+			for _, value := range values {
+				mean += value
+			}
+			mean /= samples
+			for _, value := range values {
+				variance += (value - mean) * (value - mean)
+			}
+			variance /= samples - 1
+
+			// Allow five standard errors of the mean, and 10% on the variance
+			if tolerance := 5 * math.Sqrt(tt.variance/samples); math.Abs(mean-tt.mean) > tolerance {
+				t.Errorf("Expected mean %v ± %v, got %v", tt.mean, tolerance, mean)
+			}
+			if math.Abs(variance-tt.variance) > 0.1*tt.variance {
+				t.Errorf("Expected variance %v, got %v", tt.variance, variance)
+			}
+		})
+	}
+}
+
+func TestWeightedDraws(t *testing.T) {
+	const samples = 20000
+
+	tests := []struct {
+		name   string
+		typ    string
+		params map[string]interface{}
+		want   map[interface{}]float64
+	}{
+		{
+			name:   "categorical",
+			typ:    "categorical",
+			params: map[string]interface{}{"categories": []interface{}{"200", "404", "500"}, "weights": []interface{}{90.0, 8.0, 2.0}},
+			want:   map[interface{}]float64{"200": 0.9, "404": 0.08, "500": 0.02},
+		},
+		{
+			name:   "categorical with zero weight",
+			typ:    "categorical",
+			params: map[string]interface{}{"categories": []string{"a", "b", "c"}, "weights": []float64{1, 0, 3}},
+			want:   map[interface{}]float64{"a": 0.25, "c": 0.75},
+		},
+		{
+			name:   "choice",
+			typ:    "choice",
+			params: map[string]interface{}{"items": []interface{}{1.0, 2.0, 3.0}, "weights": []interface{}{1.0, 2.0, 1.0}},
+			want:   map[interface{}]float64{1.0: 0.25, 2.0: 0.5, 3.0: 0.25},
+		},
+		{
+			name:   "uniform sample",
+			typ:    "sample",
+			params: map[string]interface{}{"items": []string{"x", "y"}},
+			want:   map[interface{}]float64{"x": 0.5, "y": 0.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draw, err := parseDistribution(tt.typ, tt.params)
+			if err != nil {
+				t.Fatalf("parseDistribution() error = %v", err)
+			}
+			rng := rand.New(rand.NewSource(1))
+			counts := make(map[interface{}]int)
+			for range samples {
+				value := draw(rng)
+				if list, ok := value.([]interface{}); ok {
+					value = list[0]
+				}
+				counts[value]++
+			}
+			for value, count := range counts {
+				if _, ok := tt.want[value]; !ok {
+					t.Errorf("Unexpected value %v drawn %d times", value, count)
+				}
+			}
+			for value, p := range tt.want {
+				got := float64(counts[value]) / samples
+				if tolerance := 5 * math.Sqrt(p*(1-p)/samples); math.Abs(got-p) > tolerance {
+					t.Errorf("Expected %v with frequency %v ± %v, got %v", value, p, tolerance, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSampling(t *testing.T) {
+	items := []interface{}{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		name     string
+		typ      string
+		params   map[string]interface{}
+		size     int
+		distinct bool
+		allowed  []interface{}
+	}{
+		{"shuffle", "shuffle", map[string]interface{}{"items": items}, 5, true, items},
+		{"sample default", "sample", map[string]interface{}{"items": items}, 1, true, items},
+		{"sample without replacement", "sample", map[string]interface{}{"items": items, "k": 5.0}, 5, true, items},
+		{"sample with replacement", "sample", map[string]interface{}{"items": items, "k": 20.0, "replacement": true}, 20, false, items},
+		{"weighted sample", "sample", map[string]interface{}{"items": items, "k": 2.0, "weights": []float64{0, 1, 0, 1, 0}}, 2, true, []interface{}{"b", "d"}},
+		{"choice with replacement", "choice", map[string]interface{}{"items": items, "k": 10.0, "weights": []float64{1, 1, 1, 1, 1}}, 10, false, items},
+		{"choice without replacement", "choice", map[string]interface{}{"items": items, "k": 3.0, "weights": []float64{5, 0, 1, 1, 0}, "replacement": false}, 3, true, []interface{}{"a", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draw, err := parseDistribution(tt.typ, tt.params)
+			if err != nil {
+				t.Fatalf("parseDistribution() error = %v", err)
+			}
+			rng := rand.New(rand.NewSource(1))
+			for range 100 {
+				drawn := draw(rng).([]interface{})
+				if len(drawn) != tt.size {
+					t.Fatalf("Expected %d items, got %v", tt.size, drawn)
+				}
+				seen := make(map[interface{}]bool)
+				for _, item := range drawn {
+					if !slices.Contains(tt.allowed, item) {
+						t.Errorf("Unexpected item %v in %v", item, drawn)
+					}
+					if tt.distinct && seen[item] {
+						t.Errorf("Expected distinct items, got %v", drawn)
+					}
+					seen[item] = true
+				}
+			}
+			if !slices.Equal(items, []interface{}{"a", "b", "c", "d", "e"}) {
+				t.Errorf("Expected the items to be left untouched, got %v", items)
+			}
+		})
+	}
+}
+
+func TestDistributionValidation(t *testing.T) {
+	tool := NewRandomTool()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"negative stddev", map[string]interface{}{"type": "normal", "stddev": -1.0}, "stddev must not be negative"},
+		{"mean not a number", map[string]interface{}{"type": "normal", "mean": "high"}, "mean must be a number"},
+		{"precision too high", map[string]interface{}{"type": "normal", "precision": 11.0}, "precision must be between 0 and 10"},
+		{"negative sigma", map[string]interface{}{"type": "lognormal", "sigma": -0.5}, "sigma must not be negative"},
+		{"zero rate", map[string]interface{}{"type": "exponential", "rate": 0.0}, "rate must be greater than 0"},
+		{"negative lambda", map[string]interface{}{"type": "poisson", "lambda": -1.0}, "lambda must not be negative"},
+		{"too many trials", map[string]interface{}{"type": "binomial", "trials": 100001.0}, "trials must be an integer between 0 and 100000"},
+		{"fractional trials", map[string]interface{}{"type": "binomial", "trials": 2.5}, "trials must be an integer"},
+		{"probability above 1", map[string]interface{}{"type": "binomial", "probability": 1.5}, "probability must be between 0 and 1"},
+		{"categories missing", map[string]interface{}{"type": "categorical", "weights": []interface{}{1.0}}, "categories parameter is required"},
+		{"categorical weights missing", map[string]interface{}{"type": "categorical", "categories": []interface{}{"a"}}, "weights parameter is required"},
+		{"weights length", map[string]interface{}{"type": "categorical", "categories": []interface{}{"a", "b"}, "weights": []interface{}{1.0}}, "got 1 weights for 2 items"},
+		{"negative weight", map[string]interface{}{"type": "categorical", "categories": []interface{}{"a", "b"}, "weights": []interface{}{1.0, -1.0}}, "weights must be non-negative"},
+		{"zero weights", map[string]interface{}{"type": "categorical", "categories": []interface{}{"a", "b"}, "weights": []interface{}{0.0, 0.0}}, "weights must not all be 0"},
+		{"weights not numbers", map[string]interface{}{"type": "choice", "items": []interface{}{"a"}, "weights": []interface{}{"heavy"}}, "weights must be an array of numbers"},
+		{"items missing", map[string]interface{}{"type": "shuffle"}, "items parameter is required"},
+		{"items empty", map[string]interface{}{"type": "sample", "items": []interface{}{}}, "items must contain between 1 and 10000 items"},
+		{"items not an array", map[string]interface{}{"type": "sample", "items": "abc"}, "items must be an array"},
+		{"k too large", map[string]interface{}{"type": "sample", "items": []interface{}{"a"}, "k": 1001.0, "replacement": true}, "k must be an integer between 1 and 1000"},
+		{"k exceeds items", map[string]interface{}{"type": "sample", "items": []interface{}{"a", "b"}, "k": 3.0}, "k must not exceed the 2 items"},
+		{"k exceeds weighted items", map[string]interface{}{"type": "choice", "items": []interface{}{"a", "b"}, "weights": []interface{}{1.0, 0.0}, "k": 2.0, "replacement": false}, "k must not exceed the 1 items"},
+		{"choice weights missing", map[string]interface{}{"type": "choice", "items": []interface{}{"a", "b"}}, "weights parameter is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tool.ValidateParams(tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateParams() error = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := tool.Execute(tt.params); err == nil {
+				t.Error("Execute() expected an error")
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -26,8 +27,24 @@ func (e *EAN13Tool) Description() string {
 	return "Generate and validate European Article Numbers (EAN-13) with checksum validation"
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (e *EAN13Tool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "EAN-13 Barcode",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"retail", "identifiers", "generation", "validation"},
+	}
+}
+
 // Execute processes the EAN-13 tool request
 func (e *EAN13Tool) Execute(params map[string]interface{}) (interface{}, error) {
+	return e.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext processes the EAN-13 tool request, stopping early when the context is cancelled
+func (e *EAN13Tool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	// Validate parameters first
 	if err := e.ValidateParams(params); err != nil {
 		return nil, err
@@ -40,9 +57,12 @@ func (e *EAN13Tool) Execute(params map[string]interface{}) (interface{}, error)
 
 	switch operation {
 	case "validate":
+		if hasInputs(params) {
+			return validateEach(ctx, params, e.validateEAN13)
+		}
 		return e.validateEAN13(params)
 	case "generate":
-		return e.generateEAN13(params)
+		return e.generateEAN13(ctx, params)
 	default:
 		return nil, fmt.Errorf("invalid operation: %s. Supported operations: validate, generate", operation)
 	}
@@ -63,12 +83,15 @@ func (e *EAN13Tool) ValidateParams(params map[string]interface{}) error {
 
 	// Validate input for validation operation
 	if operation, ok := params["operation"]; ok {
-		if opStr, ok := operation.(string); ok && opStr == "validate" {
+		if opStr, ok := operation.(string); ok && opStr == "validate" && !hasInputs(params) {
 			if input, ok := params["input"]; !ok || input == "" {
 				return fmt.Errorf("input parameter is required for validation")
 			}
 		}
 	}
+	if err := validateBulkParams(params); err != nil {
+		return err
+	}
 
 	// Validate count
 	if count, ok := params["count"]; ok {
@@ -81,7 +104,7 @@ func (e *EAN13Tool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	return nil
+	return validateSeed(params)
 }
 
 // GetInputSchema returns the JSON schema for input parameters
@@ -96,7 +119,14 @@ func (e *EAN13Tool) GetInputSchema() map[string]interface{} {
 			},
 			"input": map[string]interface{}{
 				"type":        "string",
-				"description": "EAN-13 number to validate (required for validate operation)",
+				"description": "EAN-13 number to validate (required for validate operation unless inputs is given)",
+			},
+			"inputs": map[string]interface{}{
+				"type":        "array",
+				"description": "EAN-13 numbers to validate in bulk (max 10000); the result lists a validation result per value, numbered by line, and a summary",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
 			},
 			"count": map[string]interface{}{
 				"type":        "number",
@@ -104,8 +134,17 @@ func (e *EAN13Tool) GetInputSchema() map[string]interface{} {
 				"minimum":     1,
 				"maximum":     100,
 			},
+			"seed": map[string]interface{}{
+				"type":        "integer",
+				"description": seedParameter.Description,
+			},
+			"secure": map[string]interface{}{
+				"type":        "boolean",
+				"description": secureParameter.Description,
+			},
 		},
-		"required": []string{},
+		"required":             []string{},
+		"additionalProperties": false,
 	}
 }
 
@@ -114,25 +153,31 @@ func (e *EAN13Tool) GetOutputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"valid": map[string]interface{}{
-				"type":        "boolean",
-				"description": "Whether the EAN-13 number is valid",
-			},
-			"ean13": map[string]interface{}{
-				"type":        "string",
-				"description": "Generated EAN-13 number",
-			},
-			"ean13s": map[string]interface{}{
-				"type":        "array",
-				"description": "Generated EAN-13 numbers",
+			"result": withBulkResults(map[string]interface{}{
+				"type":        []string{"string", "array", "object"},
+				"description": "Generated EAN-13 (or array of EAN-13s if count>1), or validation result for validate (results and summary when validating inputs)",
 				"items": map[string]interface{}{
 					"type": "string",
 				},
-			},
-			"error": map[string]interface{}{
-				"type":        "string",
-				"description": "Error message if validation fails",
-			},
+				"properties": map[string]interface{}{
+					"valid": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether the EAN-13 number is valid",
+					},
+					"ean13": map[string]interface{}{
+						"type":        "string",
+						"description": "Normalized EAN-13 number",
+					},
+					"input": map[string]interface{}{
+						"type":        "string",
+						"description": "The original input",
+					},
+					"error": map[string]interface{}{
+						"type":        "string",
+						"description": "Error message if validation fails",
+					},
+				},
+			}),
 		},
 	}
 }
@@ -256,14 +301,15 @@ func (e *EAN13Tool) validateEAN13(params map[string]interface{}) (interface{}, e
 }
 
 // generateEAN13 generates EAN-13 numbers
-func (e *EAN13Tool) generateEAN13(params map[string]interface{}) (interface{}, error) {
+func (e *EAN13Tool) generateEAN13(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	count := 1
 	if c, ok := params["count"].(float64); ok {
 		count = int(c)
 	}
 
+	rng := randFor(ctx, params)
 	if count == 1 {
-		ean13, err := e.generateSingleEAN13()
+		ean13, err := e.generateSingleEAN13(rng)
 		if err != nil {
 			return nil, err
 		}
@@ -271,12 +317,16 @@ func (e *EAN13Tool) generateEAN13(params map[string]interface{}) (interface{}, e
 	}
 
 	ean13s := make([]string, count)
-	for idx := range count {
-		ean13, err := e.generateSingleEAN13()
+	err := generateEach(ctx, count, func(idx int) error {
+		ean13, err := e.generateSingleEAN13(rng)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		ean13s[idx] = ean13
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return ean13s, nil
@@ -317,11 +367,11 @@ func (e *EAN13Tool) validateEAN13Number(ean13 string) (bool, string) {
 }
 
 // generateSingleEAN13 generates a single EAN-13 number
-func (e *EAN13Tool) generateSingleEAN13() (string, error) {
+func (e *EAN13Tool) generateSingleEAN13(rng *rand.Rand) (string, error) {
 	// Generate 12 random digits
 	digits := make([]int, 12)
 	for i := range 12 {
-		digits[i] = rand.Intn(10)
+		digits[i] = rng.Intn(10)
 	}
 
 	// Calculate check digit using EAN-13 algorithm
@@ -23,6 +23,17 @@ func (e *EchoTool) Description() string {
 	return "Echoes back the input message"
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (e *EchoTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "Echo",
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  false,
+		Tags:       []string{"utility"},
+	}
+}
+
 // Execute runs the echo tool
 func (e *EchoTool) Execute(params map[string]interface{}) (interface{}, error) {
 	message, ok := params["message"].(string)
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -37,8 +38,24 @@ func (i *IBANTool) Description() string {
 	return "Generate and validate International Bank Account Numbers (IBAN) with MOD-97 checksum algorithm"
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (i *IBANTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "IBAN",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"finance", "identifiers", "generation", "validation"},
+	}
+}
+
 // Execute processes the IBAN tool request
 func (i *IBANTool) Execute(params map[string]interface{}) (interface{}, error) {
+	return i.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext processes the IBAN tool request, stopping early when the context is cancelled
+func (i *IBANTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	// Validate parameters first
 	if err := i.ValidateParams(params); err != nil {
 		return nil, err
@@ -51,9 +68,12 @@ func (i *IBANTool) Execute(params map[string]interface{}) (interface{}, error) {
 
 	switch operation {
 	case "validate":
+		if hasInputs(params) {
+			return validateEach(ctx, params, i.validateIBAN)
+		}
 		return i.validateIBAN(params)
 	case "generate":
-		return i.generateIBAN(params)
+		return i.generateIBAN(ctx, params)
 	default:
 		return nil, fmt.Errorf("invalid operation: %s. Supported operations: validate, generate", operation)
 	}
@@ -74,12 +94,15 @@ func (i *IBANTool) ValidateParams(params map[string]interface{}) error {
 
 	// Validate input for validation operation
 	if operation, ok := params["operation"]; ok {
-		if opStr, ok := operation.(string); ok && opStr == "validate" {
+		if opStr, ok := operation.(string); ok && opStr == "validate" && !hasInputs(params) {
 			if input, ok := params["input"]; !ok || input == "" {
 				return fmt.Errorf("input parameter is required for validation")
 			}
 		}
 	}
+	if err := validateBulkParams(params); err != nil {
+		return err
+	}
 
 	// Validate count
 	if count, ok := params["count"]; ok {
@@ -105,7 +128,7 @@ func (i *IBANTool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	return nil
+	return validateSeed(params)
 }
 
 // GetInputSchema returns the JSON schema for input parameters
@@ -120,7 +143,14 @@ func (i *IBANTool) GetInputSchema() map[string]interface{} {
 			},
 			"input": map[string]interface{}{
 				"type":        "string",
-				"description": "IBAN number to validate (required for validate operation)",
+				"description": "IBAN number to validate (required for validate operation unless inputs is given)",
+			},
+			"inputs": map[string]interface{}{
+				"type":        "array",
+				"description": "IBAN numbers to validate in bulk (max 10000); the result lists a validation result per value, numbered by line, and a summary",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
 			},
 			"country-code": map[string]interface{}{
 				"type":        "string",
@@ -132,8 +162,17 @@ func (i *IBANTool) GetInputSchema() map[string]interface{} {
 				"minimum":     1,
 				"maximum":     100,
 			},
+			"seed": map[string]interface{}{
+				"type":        "integer",
+				"description": seedParameter.Description,
+			},
+			"secure": map[string]interface{}{
+				"type":        "boolean",
+				"description": secureParameter.Description,
+			},
 		},
-		"required": []string{},
+		"required":             []string{},
+		"additionalProperties": false,
 	}
 }
 
@@ -142,29 +181,35 @@ func (i *IBANTool) GetOutputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"valid": map[string]interface{}{
-				"type":        "boolean",
-				"description": "Whether the IBAN is valid",
-			},
-			"iban": map[string]interface{}{
-				"type":        "string",
-				"description": "Generated IBAN number",
-			},
-			"ibans": map[string]interface{}{
-				"type":        "array",
-				"description": "Generated IBAN numbers",
+			"result": withBulkResults(map[string]interface{}{
+				"type":        []string{"string", "array", "object"},
+				"description": "Generated IBAN (or array of IBANs if count>1), or validation result for validate (results and summary when validating inputs)",
 				"items": map[string]interface{}{
 					"type": "string",
 				},
-			},
-			"country": map[string]interface{}{
-				"type":        "string",
-				"description": "Country code of the IBAN",
-			},
-			"error": map[string]interface{}{
-				"type":        "string",
-				"description": "Error message if validation fails",
-			},
+				"properties": map[string]interface{}{
+					"valid": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether the IBAN is valid",
+					},
+					"iban": map[string]interface{}{
+						"type":        "string",
+						"description": "Normalized IBAN number",
+					},
+					"country": map[string]interface{}{
+						"type":        "string",
+						"description": "Country code of the IBAN",
+					},
+					"input": map[string]interface{}{
+						"type":        "string",
+						"description": "The original input",
+					},
+					"error": map[string]interface{}{
+						"type":        "string",
+						"description": "Error message if validation fails",
+					},
+				},
+			}),
 		},
 	}
 }
@@ -190,6 +235,18 @@ func (i *IBANTool) GetResources() []Resource {
 	}
 }
 
+// CompleteParam returns the candidate values of a parameter for argument completion
+func (i *IBANTool) CompleteParam(name string) []Completion {
+	if name != "country-code" {
+		return nil
+	}
+	completions := make([]Completion, len(i.countries))
+	for idx, country := range i.countries {
+		completions[idx] = Completion{Value: country.Code, Label: country.Name}
+	}
+	return completions
+}
+
 // ReadResource reads a specific resource by URI
 func (i *IBANTool) ReadResource(uri string) (string, error) {
 	switch uri {
@@ -323,7 +380,7 @@ func (i *IBANTool) validateIBAN(params map[string]interface{}) (interface{}, err
 }
 
 // generateIBAN generates IBAN numbers
-func (i *IBANTool) generateIBAN(params map[string]interface{}) (interface{}, error) {
+func (i *IBANTool) generateIBAN(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	count := 1
 	if c, ok := params["count"].(float64); ok {
 		count = int(c)
@@ -334,8 +391,9 @@ func (i *IBANTool) generateIBAN(params map[string]interface{}) (interface{}, err
 		countryCode = cc
 	}
 
+	rng := randFor(ctx, params)
 	if count == 1 {
-		iban, err := i.generateSingleIBAN(countryCode)
+		iban, err := i.generateSingleIBAN(rng, countryCode)
 		if err != nil {
 			return nil, err
 		}
@@ -343,22 +401,26 @@ func (i *IBANTool) generateIBAN(params map[string]interface{}) (interface{}, err
 	}
 
 	ibans := make([]string, count)
-	for j := range count {
-		iban, err := i.generateSingleIBAN(countryCode)
+	err := generateEach(ctx, count, func(j int) error {
+		iban, err := i.generateSingleIBAN(rng, countryCode)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		ibans[j] = iban
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return ibans, nil
 }
 
 // generateSingleIBAN generates a single IBAN number
-func (i *IBANTool) generateSingleIBAN(countryCode string) (string, error) {
+func (i *IBANTool) generateSingleIBAN(rng *rand.Rand, countryCode string) (string, error) {
 	if countryCode == "" {
 		// Random country code
-		countryCode = i.getRandomCountryCode()
+		countryCode = i.getRandomCountryCode(rng)
 	}
 
 	// Get the expected length for this country
@@ -370,7 +432,7 @@ func (i *IBANTool) generateSingleIBAN(countryCode string) (string, error) {
 	// Generate random BBAN (Basic Bank Account Number)
 	// Length is total - 4 (2 for country code + 2 for check digits)
 	bbanLength := expectedLength - 4
-	bban := i.generateRandomBBAN(bbanLength)
+	bban := i.generateRandomBBAN(rng, bbanLength)
 
 	// Create the IBAN without check digits
 	ibanWithoutChecks := countryCode + "00" + bban
@@ -441,16 +503,16 @@ func (i *IBANTool) calculateCheckDigits(ibanWithoutChecks string) string {
 }
 
 // generateRandomBBAN generates a random Basic Bank Account Number
-func (i *IBANTool) generateRandomBBAN(length int) string {
+func (i *IBANTool) generateRandomBBAN(rng *rand.Rand, length int) string {
 	var result strings.Builder
 	for j := 0; j < length; j++ {
 		// Generate random alphanumeric character
-		if rand.Intn(2) == 0 {
+		if rng.Intn(2) == 0 {
 			// Generate random digit
-			result.WriteString(strconv.Itoa(rand.Intn(10)))
+			result.WriteString(strconv.Itoa(rng.Intn(10)))
 		} else {
 			// Generate random letter
-			result.WriteRune(rune('A' + rand.Intn(26)))
+			result.WriteRune(rune('A' + rng.Intn(26)))
 		}
 	}
 	return result.String()
@@ -521,11 +583,11 @@ func (i *IBANTool) getCountriesData() []map[string]interface{} {
 }
 
 // getRandomCountryCode returns a random country code from the supported countries
-func (i *IBANTool) getRandomCountryCode() string {
+func (i *IBANTool) getRandomCountryCode(rng *rand.Rand) string {
 	if len(i.countries) == 0 {
 		return "GB" // Fallback to UK if no countries loaded
 	}
-	return i.countries[rand.Intn(len(i.countries))].Code
+	return i.countries[rng.Intn(len(i.countries))].Code
 }
 
 // isAlpha checks if a string contains only letters
@@ -0,0 +1,932 @@
+package tools
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// idOperations lists the values of the operation parameter
+var idOperations = []string{"generate", "validate", "convert"}
+
+// idFormats lists the values of the format parameter, the default first
+var idFormats = []string{"ulid", "ksuid", "nanoid", "snowflake", "typeid"}
+
+// Limits and defaults of generated IDs
+const (
+	maxIDCount        = 1000
+	defaultNanoIDSize = 21
+	maxNanoIDSize     = 256
+	maxTypeIDPrefix   = 63
+)
+
+// nanoIDAlphabet is NanoID's default URL-safe alphabet of 64 characters
+const nanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// base62Alphabet is the alphabet of KSUIDs, digits first and uppercase before lowercase
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ksuidEpoch is the start of KSUID timestamps, 2014-05-13T16:53:20Z, in Unix seconds
+const ksuidEpoch = 1400000000
+
+// ksuidLength is the length of a KSUID, 20 bytes in base62
+const ksuidLength = 27
+
+// Bit widths of a Snowflake ID after its sign bit: a millisecond timestamp,
+// then the worker ID and a sequence number within the millisecond
+const (
+	snowflakeTimestampBits = 41
+	snowflakeWorkerBits    = 10
+	snowflakeSequenceBits  = 12
+)
+
+// snowflakeEpochs maps the epoch presets to their start in Unix milliseconds
+var snowflakeEpochs = map[string]int64{
+	"twitter": 1288834974657, // 2010-11-04T01:42:54.657Z
+	"discord": 1420070400000, // 2015-01-01T00:00:00Z
+}
+
+// IDTool implements generation, validation and decoding of ULIDs, KSUIDs,
+// NanoIDs, Snowflake IDs and TypeIDs
+type IDTool struct{}
+
+// NewIDTool creates a new ID tool instance
+func NewIDTool() *IDTool {
+	return &IDTool{}
+}
+
+// Name returns the tool's name
+func (t *IDTool) Name() string {
+	return "id"
+}
+
+// Description returns the tool's description
+func (t *IDTool) Description() string {
+	return "Generate, validate and decode ULIDs, KSUIDs, NanoIDs, Snowflake IDs and TypeIDs, extracting their embedded timestamps, and convert between ULIDs and UUIDs"
+}
+
+// Annotations returns the tool's behavioural hints for clients
+func (t *IDTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "ID",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"identifiers", "generation", "validation"},
+	}
+}
+
+// Execute runs the ID tool
+func (t *IDTool) Execute(params map[string]interface{}) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext runs the ID tool, stopping early when the context is cancelled
+func (t *IDTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	operation, _ := params["operation"].(string)
+	if operation == "" {
+		operation = "generate"
+	}
+
+	opts, err := parseIDOptions(params)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation {
+	case "generate":
+		return t.generate(ctx, params, opts)
+	case "validate":
+		validate := func(params map[string]interface{}) (interface{}, error) {
+			return t.validateID(params, opts)
+		}
+		if hasInputs(params) {
+			return validateEach(ctx, params, validate)
+		}
+		return validate(params)
+	case "convert":
+		return t.convert(params)
+	default:
+		return nil, fmt.Errorf("invalid operation: %s, must be one of: %s", operation, strings.Join(idOperations, ", "))
+	}
+}
+
+// idOptions holds the format of the IDs and the options of each format
+type idOptions struct {
+	Format string
+	// Alphabet and Size describe NanoIDs; SizeSet records whether a size was
+	// given, as only then is the size of validated NanoIDs checked
+	Alphabet []rune
+	Size     int
+	SizeSet  bool
+	// Epoch is the start of Snowflake timestamps in Unix milliseconds, and
+	// Worker the worker ID of generated Snowflake IDs
+	Epoch  int64
+	Worker int
+	// Prefix is the type prefix of TypeIDs; PrefixSet records whether one
+	// was given, as only then must validated TypeIDs carry it
+	Prefix    string
+	PrefixSet bool
+}
+
+// parseIDOptions builds the options of the ID tool from the parameters
+func parseIDOptions(params map[string]interface{}) (*idOptions, error) {
+	opts := &idOptions{
+		Format:   idFormats[0],
+		Alphabet: []rune(nanoIDAlphabet),
+		Size:     defaultNanoIDSize,
+		Epoch:    snowflakeEpochs["twitter"],
+	}
+
+	if value, ok := params["format"]; ok {
+		format, _ := value.(string)
+		format = strings.ToLower(format)
+		if !contains(idFormats, format) {
+			return nil, fmt.Errorf("invalid format: %v, must be one of: %s", value, strings.Join(idFormats, ", "))
+		}
+		opts.Format = format
+	}
+
+	if value, ok := params["alphabet"]; ok {
+		alphabet, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("alphabet parameter must be a string")
+		}
+		runes := []rune(alphabet)
+		if len(runes) < 2 || len(runes) > 256 {
+			return nil, fmt.Errorf("alphabet must have between 2 and 256 characters")
+		}
+		seen := make(map[rune]bool)
+		for _, r := range runes {
+			if seen[r] {
+				return nil, fmt.Errorf("alphabet must not repeat characters, but %q appears more than once", r)
+			}
+			seen[r] = true
+		}
+		opts.Alphabet = runes
+	}
+
+	if value, ok := params["size"]; ok {
+		size, ok := IntParam(value)
+		if !ok || size < 1 || size > maxNanoIDSize {
+			return nil, fmt.Errorf("size must be an integer between 1 and %d", maxNanoIDSize)
+		}
+		opts.Size, opts.SizeSet = size, true
+	}
+
+	if value, ok := params["epoch"]; ok {
+		epoch, err := parseSnowflakeEpoch(value)
+		if err != nil {
+			return nil, err
+		}
+		opts.Epoch = epoch
+	}
+
+	if value, ok := params["worker"]; ok {
+		worker, ok := IntParam(value)
+		if !ok || worker < 0 || worker >= 1<<snowflakeWorkerBits {
+			return nil, fmt.Errorf("worker must be an integer between 0 and %d", 1<<snowflakeWorkerBits-1)
+		}
+		opts.Worker = worker
+	}
+
+	if value, ok := params["prefix"]; ok {
+		prefix, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("prefix parameter must be a string")
+		}
+		if err := validateTypeIDPrefix(prefix); err != nil {
+			return nil, err
+		}
+		opts.Prefix, opts.PrefixSet = prefix, true
+	}
+
+	return opts, nil
+}
+
+// parseSnowflakeEpoch returns the Snowflake epoch in Unix milliseconds named
+// by a preset, such as "discord", or given as a number of milliseconds
+func parseSnowflakeEpoch(value interface{}) (int64, error) {
+	if name, ok := value.(string); ok {
+		if epoch, ok := snowflakeEpochs[strings.ToLower(name)]; ok {
+			return epoch, nil
+		}
+		if epoch, err := strconv.ParseInt(name, 10, 64); err == nil && epoch >= 0 {
+			return epoch, nil
+		}
+	} else if epoch, ok := IntParam(value); ok && epoch >= 0 {
+		return int64(epoch), nil
+	}
+	return 0, fmt.Errorf("invalid epoch: %v, must be twitter, discord or a Unix time in milliseconds", value)
+}
+
+// validateTypeIDPrefix checks a TypeID prefix: up to 63 lowercase ASCII
+// letters and underscores, starting and ending with a letter, or empty
+func validateTypeIDPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if len(prefix) > maxTypeIDPrefix {
+		return fmt.Errorf("invalid prefix: %s, a TypeID prefix has at most %d characters", prefix, maxTypeIDPrefix)
+	}
+	for _, r := range prefix {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return fmt.Errorf("invalid prefix: %s, a TypeID prefix has only lowercase letters a-z and underscores", prefix)
+		}
+	}
+	if prefix[0] == '_' || prefix[len(prefix)-1] == '_' {
+		return fmt.Errorf("invalid prefix: %s, a TypeID prefix starts and ends with a letter", prefix)
+	}
+	return nil
+}
+
+// epoch returns the earliest time the format's timestamps can hold, or the
+// zero time for formats without a timestamp or with one starting at 1970
+func (o *idOptions) epoch() time.Time {
+	switch o.Format {
+	case "ksuid":
+		return time.Unix(ksuidEpoch, 0).UTC()
+	case "snowflake":
+		return time.UnixMilli(o.Epoch).UTC()
+	}
+	return time.Time{}
+}
+
+// clock returns the clock of generated IDs. Seeded calls read the fixed
+// seedTime so that their IDs are reproducible, or the format's epoch when it
+// is later, since KSUID and Snowflake timestamps cannot precede their epoch.
+func (o *idOptions) clock(ctx context.Context, params map[string]interface{}) func() time.Time {
+	if !seededCall(ctx, params) {
+		return time.Now
+	}
+	fixed := seedTime
+	if epoch := o.epoch(); epoch.After(fixed) {
+		fixed = epoch
+	}
+	return func() time.Time { return fixed }
+}
+
+// seededCall reports whether a call draws from a seeded source, so that its
+// IDs must be reproducible
+func seededCall(ctx context.Context, params map[string]interface{}) bool {
+	_, ok := seedFor(ctx, params)
+	return ok && !secureFor(ctx, params)
+}
+
+// generate generates IDs of the selected format. Their random bits come from
+// crypto/rand unless a seed is given, as IDs are often used where guessing
+// one must be hard.
+func (t *IDTool) generate(ctx context.Context, params map[string]interface{}, opts *idOptions) (interface{}, error) {
+	count, _ := IntParam(params["count"])
+	if count <= 0 {
+		count = 1
+	}
+	if count > maxIDCount {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxIDCount)
+	}
+
+	ctx = secureByDefault(ctx, params)
+	var rng *rand.Rand
+	if opts.Format != "snowflake" {
+		rng = randFor(ctx, params)
+	}
+	// TypeIDs of the current time sort in order across calls, like v7 UUIDs
+	order := processV7
+	if seededCall(ctx, params) {
+		order = newV7Generator()
+	}
+	next := opts.generator(rng, opts.clock(ctx, params), order)
+
+	results := make([]string, count)
+	err := generateEach(ctx, count, func(i int) error {
+		id, err := next()
+		if err != nil {
+			return err
+		}
+		results[i] = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return single value if count is 1, otherwise return array
+	if count == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// generator returns a function generating one ID after another. The IDs of a
+// batch that sort by time stay in order even when they share a timestamp;
+// the UUIDs of TypeIDs are ordered by the v7 generator.
+func (o *idOptions) generator(rng *rand.Rand, clock func() time.Time, order *v7Generator) func() (string, error) {
+	switch o.Format {
+	case "ksuid":
+		return func() (string, error) {
+			seconds := clock().Unix() - ksuidEpoch
+			if seconds < 0 || seconds > math.MaxUint32 {
+				return "", fmt.Errorf("the time %s is outside the range of KSUID timestamps", clock().UTC().Format(time.RFC3339))
+			}
+			var id [20]byte
+			binary.BigEndian.PutUint32(id[:4], uint32(seconds))
+			rng.Read(id[4:])
+			return encodeBase62(id), nil
+		}
+	case "nanoid":
+		return func() (string, error) {
+			id := make([]rune, o.Size)
+			for i := range id {
+				id[i] = o.Alphabet[rng.Intn(len(o.Alphabet))]
+			}
+			return string(id), nil
+		}
+	case "snowflake":
+		last, sequence := int64(-1), int64(0)
+		return func() (string, error) {
+			elapsed := clock().UnixMilli() - o.Epoch
+			if elapsed < 0 {
+				return "", fmt.Errorf("the time %s is before the Snowflake epoch %s", clock().UTC().Format(time.RFC3339), time.UnixMilli(o.Epoch).UTC().Format(time.RFC3339Nano))
+			}
+			// IDs within a millisecond count up the sequence, moving on to
+			// the next millisecond when it runs out
+			if elapsed <= last {
+				elapsed, sequence = last, sequence+1
+				if sequence == 1<<snowflakeSequenceBits {
+					elapsed, sequence = last+1, 0
+				}
+			} else {
+				sequence = 0
+			}
+			if elapsed >= 1<<snowflakeTimestampBits {
+				return "", fmt.Errorf("the time is more than %d bits of milliseconds after the Snowflake epoch", snowflakeTimestampBits)
+			}
+			last = elapsed
+			id := elapsed<<(snowflakeWorkerBits+snowflakeSequenceBits) | int64(o.Worker)<<snowflakeSequenceBits | sequence
+			return strconv.FormatInt(id, 10), nil
+		}
+	case "typeid":
+		return func() (string, error) {
+			v7, err := order.next(rng, clock())
+			if err != nil {
+				return "", fmt.Errorf("failed to generate TypeID: %v", err)
+			}
+			suffix := strings.ToLower(encodeBase32ID(v7))
+			if o.Prefix == "" {
+				return suffix, nil
+			}
+			return o.Prefix + "_" + suffix, nil
+		}
+	}
+
+	// ULIDs within a millisecond increment the random bits of the previous
+	// one, as the ULID specification's monotonic generation does
+	var last [16]byte
+	lastMillis := int64(-1)
+	return func() (string, error) {
+		millis := clock().UnixMilli()
+		if millis < 0 || millis >= 1<<48 {
+			return "", fmt.Errorf("the time %s is outside the range of ULID timestamps", clock().UTC().Format(time.RFC3339))
+		}
+		if millis <= lastMillis {
+			if !incrementBytes(last[6:]) {
+				return "", fmt.Errorf("failed to generate ULID: the random bits ran out within a millisecond")
+			}
+		} else {
+			lastMillis = millis
+			binary.BigEndian.PutUint16(last[0:], uint16(millis>>32))
+			binary.BigEndian.PutUint32(last[2:], uint32(millis))
+			rng.Read(last[6:])
+		}
+		return encodeBase32ID(last), nil
+	}
+}
+
+// incrementBytes adds one to a big-endian number, reporting false when it overflows
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validateID validates and decodes an ID of the selected format
+func (t *IDTool) validateID(params map[string]interface{}, opts *idOptions) (interface{}, error) {
+	input, _ := params["input"].(string)
+	if input == "" {
+		return nil, fmt.Errorf("input parameter is required for validation")
+	}
+
+	id := strings.TrimSpace(input)
+	var fields map[string]interface{}
+	var err error
+	switch opts.Format {
+	case "ksuid":
+		fields, err = decodeKSUID(id)
+	case "nanoid":
+		fields, err = opts.decodeNanoID(id)
+	case "snowflake":
+		fields, err = opts.decodeSnowflake(id)
+	case "typeid":
+		fields, err = opts.decodeTypeID(id)
+	default:
+		fields, err = decodeULID(id)
+	}
+	if err != nil {
+		return map[string]interface{}{
+			"valid":  false,
+			"error":  err.Error(),
+			"format": opts.Format,
+			"input":  input,
+		}, nil
+	}
+
+	result := map[string]interface{}{
+		"valid":  true,
+		"format": opts.Format,
+		"input":  input,
+	}
+	for name, value := range fields {
+		result[name] = value
+	}
+	return result, nil
+}
+
+// decodeULID decodes a ULID, case-insensitively, into its millisecond
+// timestamp, its 80 random bits and the UUIDv7 with the same timestamp
+func decodeULID(input string) (map[string]interface{}, error) {
+	id, err := decodeBase32ID(strings.ToUpper(input), "ULID")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"id":         encodeBase32ID(id),
+		"timestamp":  millisTimestamp(id),
+		"randomness": hex.EncodeToString(id[6:]),
+		"uuid":       ulidToUUID(id).String(),
+	}, nil
+}
+
+// ulidToUUID sets the version 7 and RFC 4122 variant bits on the 128 bits of a
+// ULID, giving a valid UUIDv7 with the ULID's timestamp. The 6 bits it
+// overwrites come from the ULID's random part and are lost, except for the
+// ULID of a UUIDv7, which already holds them.
+func ulidToUUID(id [16]byte) uuid.UUID {
+	id[6] = id[6]&0x0f | 0x70
+	id[8] = id[8]&0x3f | 0x80
+	return uuid.UUID(id)
+}
+
+// decodeKSUID decodes a KSUID into its timestamp and 128-bit payload
+func decodeKSUID(input string) (map[string]interface{}, error) {
+	if len(input) != ksuidLength {
+		return nil, fmt.Errorf("KSUID must be exactly %d characters, got %d", ksuidLength, len(input))
+	}
+	value := new(big.Int)
+	for _, r := range input {
+		digit := strings.IndexRune(base62Alphabet, r)
+		if digit < 0 {
+			return nil, fmt.Errorf("KSUID must contain only base62 characters 0-9, A-Z and a-z, got %q", r)
+		}
+		value.Mul(value, big.NewInt(62)).Add(value, big.NewInt(int64(digit)))
+	}
+	if value.BitLen() > 160 {
+		return nil, fmt.Errorf("KSUID exceeds 160 bits; the largest is %s", encodeBase62([20]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}))
+	}
+
+	var id [20]byte
+	value.FillBytes(id[:])
+	seconds := int64(binary.BigEndian.Uint32(id[:4])) + ksuidEpoch
+	return map[string]interface{}{
+		"id":        input,
+		"timestamp": time.Unix(seconds, 0).UTC().Format(time.RFC3339Nano),
+		"payload":   hex.EncodeToString(id[4:]),
+	}, nil
+}
+
+// decodeNanoID checks that a NanoID uses only the characters of the
+// alphabet and, when a size was given, has that size
+func (o *idOptions) decodeNanoID(input string) (map[string]interface{}, error) {
+	runes := []rune(input)
+	if o.SizeSet && len(runes) != o.Size {
+		return nil, fmt.Errorf("NanoID must be exactly %d characters, got %d", o.Size, len(runes))
+	}
+	for _, r := range runes {
+		if !strings.ContainsRune(string(o.Alphabet), r) {
+			return nil, fmt.Errorf("NanoID contains %q, which is not in the alphabet", r)
+		}
+	}
+	return map[string]interface{}{
+		"id":           input,
+		"size":         len(runes),
+		"entropy_bits": roundBits(float64(len(runes)) * math.Log2(float64(len(o.Alphabet)))),
+	}, nil
+}
+
+// decodeSnowflake decodes a Snowflake ID into its timestamp, counted from
+// the epoch, its worker ID and its sequence number
+func (o *idOptions) decodeSnowflake(input string) (map[string]interface{}, error) {
+	id, err := strconv.ParseInt(input, 10, 64)
+	if err != nil || id < 0 || strings.HasPrefix(input, "+") {
+		return nil, fmt.Errorf("Snowflake ID must be a decimal number between 0 and %d", int64(math.MaxInt64))
+	}
+	millis := id>>(snowflakeWorkerBits+snowflakeSequenceBits) + o.Epoch
+	return map[string]interface{}{
+		"id":        input,
+		"timestamp": time.UnixMilli(millis).UTC().Format(time.RFC3339Nano),
+		"worker":    int(id >> snowflakeSequenceBits & (1<<snowflakeWorkerBits - 1)),
+		"sequence":  int(id & (1<<snowflakeSequenceBits - 1)),
+		"epoch":     o.Epoch,
+	}, nil
+}
+
+// decodeTypeID decodes a TypeID into its prefix and the UUID of its suffix,
+// with the UUID's timestamp when it is a UUIDv7 as TypeIDs should be
+func (o *idOptions) decodeTypeID(input string) (map[string]interface{}, error) {
+	prefix, suffix := "", input
+	if i := strings.LastIndex(input, "_"); i >= 0 {
+		prefix, suffix = input[:i], input[i+1:]
+		if prefix == "" {
+			return nil, fmt.Errorf("TypeID must not start with an underscore; leave out the separator when there is no prefix")
+		}
+	}
+	if err := validateTypeIDPrefix(prefix); err != nil {
+		return nil, err
+	}
+	if o.PrefixSet && prefix != o.Prefix {
+		return nil, fmt.Errorf("TypeID prefix must be %q, got %q", o.Prefix, prefix)
+	}
+	if suffix != strings.ToLower(suffix) {
+		return nil, fmt.Errorf("TypeID suffix must be lowercase")
+	}
+	id, err := decodeBase32ID(strings.ToUpper(suffix), "TypeID suffix")
+	if err != nil {
+		return nil, err
+	}
+
+	u := uuid.UUID(id)
+	fields := map[string]interface{}{
+		"id":           input,
+		"prefix":       prefix,
+		"suffix":       suffix,
+		"uuid":         u.String(),
+		"uuid_version": int(u.Version()),
+	}
+	if u.Version() == 7 {
+		fields["timestamp"] = millisTimestamp(id)
+	}
+	return fields, nil
+}
+
+// convert converts a ULID to a UUIDv7, or a UUID to the ULID with the same 128
+// bits. Both start with a 48-bit millisecond timestamp, so a UUIDv7 and its
+// ULID carry the same time and convert back and forth without loss.
+func (t *IDTool) convert(params map[string]interface{}) (interface{}, error) {
+	input, _ := params["input"].(string)
+	if input == "" {
+		return nil, fmt.Errorf("input parameter is required for conversion")
+	}
+
+	trimmed := strings.TrimSpace(input)
+	var id [16]byte
+	var u uuid.UUID
+	fromULID := false
+	if parsed, err := uuid.Parse(trimmed); err == nil {
+		id, u = parsed, parsed
+	} else if id, err = decodeBase32ID(strings.ToUpper(trimmed), "ULID"); err == nil {
+		u, fromULID = ulidToUUID(id), true
+	} else {
+		return nil, fmt.Errorf("input must be a ULID or a UUID: %v", err)
+	}
+
+	result := map[string]interface{}{
+		"input":        input,
+		"ulid":         encodeBase32ID(id),
+		"uuid":         u.String(),
+		"uuid_version": int(u.Version()),
+	}
+	// Only ULIDs and UUIDv7 hold a millisecond timestamp in their first 48 bits
+	if fromULID || u.Version() == 7 {
+		result["timestamp"] = millisTimestamp(id)
+	}
+	return result, nil
+}
+
+// millisTimestamp formats the 48-bit millisecond timestamp at the start of a
+// ULID or UUIDv7 in RFC 3339
+func millisTimestamp(id [16]byte) string {
+	millis := int64(binary.BigEndian.Uint16(id[0:]))<<32 | int64(binary.BigEndian.Uint32(id[2:]))
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339Nano)
+}
+
+// encodeBase32ID encodes 128 bits as the 26 Crockford base32 characters of a
+// ULID. The 26 characters hold 130 bits, so the first holds only 3.
+func encodeBase32ID(id [16]byte) string {
+	var sb strings.Builder
+	for i := range 26 {
+		digit := 0
+		for bit := i*5 - 2; bit < i*5+3; bit++ {
+			digit <<= 1
+			if bit >= 0 && id[bit/8]>>(7-bit%8)&1 == 1 {
+				digit |= 1
+			}
+		}
+		sb.WriteByte(crockfordAlphabet[digit])
+	}
+	return sb.String()
+}
+
+// decodeBase32ID decodes the 26 uppercase Crockford base32 characters of a
+// ULID, or of the suffix of a TypeID, named by kind in errors
+func decodeBase32ID(input string, kind string) ([16]byte, error) {
+	var id [16]byte
+	if len(input) != 26 {
+		return id, fmt.Errorf("%s must be exactly 26 characters, got %d", kind, len(input))
+	}
+	for i, r := range input {
+		digit := strings.IndexRune(crockfordAlphabet, r)
+		if digit < 0 {
+			return id, fmt.Errorf("%s must contain only Crockford base32 characters, got %q", kind, r)
+		}
+		if i == 0 && digit > 7 {
+			return id, fmt.Errorf("%s exceeds 128 bits; its first character must be between 0 and 7", kind)
+		}
+		for bit := i*5 - 2; bit < i*5+3; bit++ {
+			if bit >= 0 && digit>>(i*5+2-bit)&1 == 1 {
+				id[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+	return id, nil
+}
+
+// encodeBase62 encodes the 20 bytes of a KSUID as 27 base62 characters,
+// padded with leading zeros
+func encodeBase62(id [20]byte) string {
+	value := new(big.Int).SetBytes(id[:])
+	digits := make([]byte, ksuidLength)
+	base, digit := big.NewInt(62), new(big.Int)
+	for i := ksuidLength - 1; i >= 0; i-- {
+		value.DivMod(value, base, digit)
+		digits[i] = base62Alphabet[digit.Int64()]
+	}
+	return string(digits)
+}
+
+// ValidateParams validates the input parameters
+func (t *IDTool) ValidateParams(params map[string]interface{}) error {
+	// Validate operation
+	operation := "generate"
+	if value, ok := params["operation"]; ok {
+		operationStr, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("operation parameter must be a string")
+		}
+		if !contains(idOperations, operationStr) {
+			return fmt.Errorf("invalid operation: %s, must be one of: %s", operationStr, strings.Join(idOperations, ", "))
+		}
+		operation = operationStr
+	}
+
+	// Validate count
+	if count, ok := params["count"]; ok {
+		countInt, ok := IntParam(count)
+		if !ok {
+			return fmt.Errorf("count must be an integer")
+		}
+		if countInt < 1 || countInt > maxIDCount {
+			return fmt.Errorf("count must be between 1 and %d", maxIDCount)
+		}
+	}
+
+	// Validate the options of the formats
+	if _, err := parseIDOptions(params); err != nil {
+		return err
+	}
+
+	// Validate input for validation and conversion
+	if operation != "generate" && !hasInputs(params) {
+		if input, ok := params["input"]; !ok {
+			return fmt.Errorf("input parameter is required for the %s operation", operation)
+		} else if _, ok := input.(string); !ok {
+			return fmt.Errorf("input parameter must be a string")
+		}
+	}
+	// The operation defaults to generate, which validateBulkParams does not expect
+	if hasInputs(params) && operation != "validate" {
+		return fmt.Errorf("inputs is only supported by the validate operation")
+	}
+	if err := validateBulkParams(params); err != nil {
+		return err
+	}
+
+	return validateSeed(params)
+}
+
+// GetInputSchema returns the JSON schema for tool input parameters
+func (t *IDTool) GetInputSchema() map[string]interface{} {
+	return CreateJSONSchema([]ParameterDefinition{
+		{
+			Name:        "operation",
+			Type:        "string",
+			Description: "Operation to perform: 'generate' (default), 'validate', which also decodes the ID, or 'convert', which converts a ULID to a UUIDv7 or a UUID to a ULID",
+			Required:    false,
+			Enum:        idOperations,
+		},
+		{
+			Name:        "format",
+			Type:        "string",
+			Description: "ID format: ulid (default), ksuid, nanoid, snowflake or typeid (e.g. user_01h455vb4pex5vsknk084sn02q)",
+			Required:    false,
+			Enum:        idFormats,
+		},
+		{
+			Name:        "input",
+			Type:        "string",
+			Description: "ID to validate, or ULID or UUID to convert (required for validate and convert unless inputs is given)",
+			Required:    false,
+		},
+		{
+			Name:        "inputs",
+			Type:        "array",
+			Description: "IDs to validate in bulk (max 10000); the result lists a validation result per value, numbered by line, and a summary",
+			Required:    false,
+			Items:       "string",
+		},
+		{
+			Name:        "count",
+			Type:        "integer",
+			Description: "Number of IDs to generate (default: 1, max: 1000)",
+			Required:    false,
+		},
+		{
+			Name:        "alphabet",
+			Type:        "string",
+			Description: "Alphabet of NanoIDs, 2 to 256 distinct characters (default: the URL-safe A-Za-z0-9_-)",
+			Required:    false,
+		},
+		{
+			Name:        "size",
+			Type:        "integer",
+			Description: "Number of characters of NanoIDs, 1 to 256 (default: 21); validated NanoIDs must have this size when it is given",
+			Required:    false,
+		},
+		{
+			Name:        "epoch",
+			Type:        "string",
+			Description: "Start of Snowflake timestamps: twitter (default, 2010-11-04T01:42:54.657Z), discord (2015-01-01T00:00:00Z) or a Unix time in milliseconds",
+			Required:    false,
+		},
+		{
+			Name:        "worker",
+			Type:        "integer",
+			Description: "Worker ID of generated Snowflake IDs, 0 to 1023 (default: 0); Twitter splits it into a 5-bit datacenter and a 5-bit worker, Discord into a 5-bit worker and a 5-bit process",
+			Required:    false,
+		},
+		{
+			Name:        "prefix",
+			Type:        "string",
+			Description: "Type prefix of TypeIDs, up to 63 lowercase letters and underscores, starting and ending with a letter (default: none); validated TypeIDs must carry it when it is given",
+			Required:    false,
+		},
+		{
+			Name:        "seed",
+			Type:        "integer",
+			Description: "Seed for the random bits of the IDs; seeded IDs carry the fixed time 2000-01-01T00:00:00Z, or the epoch of KSUIDs and Snowflake IDs when that is later, so the same seed always generates the same IDs (default: random)",
+			Required:    false,
+		},
+		{
+			Name:        "secure",
+			Type:        "boolean",
+			Description: "Draw the random bits from crypto/rand even when the server sets a default seed; unseeded IDs always do (cannot be combined with seed)",
+			Required:    false,
+		},
+	})
+}
+
+// GetOutputSchema returns the JSON schema for tool output
+func (t *IDTool) GetOutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"result": withBulkResults(map[string]interface{}{
+				"type":        []string{"string", "array", "object"},
+				"description": "Generated ID(s), the validation result with the decoded fields, such as the timestamp, or the ULID and UUID of a conversion (results and summary when validating inputs)",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			}),
+		},
+	}
+}
+
+// CompleteParam returns the candidate values of a parameter for argument completion
+func (t *IDTool) CompleteParam(name string) []Completion {
+	switch name {
+	case "format":
+		completions := make([]Completion, len(idFormatDescriptions))
+		for i, format := range idFormatDescriptions {
+			completions[i] = Completion{Value: format["format"].(string), Label: format["name"].(string)}
+		}
+		return completions
+	case "epoch":
+		return []Completion{{Value: "twitter", Label: "Twitter"}, {Value: "discord", Label: "Discord"}}
+	}
+	return nil
+}
+
+// idFormatDescriptions describes the ID formats for the formats resource
+var idFormatDescriptions = []map[string]interface{}{
+	{
+		"format":      "ulid",
+		"name":        "ULID",
+		"description": "Universally Unique Lexicographically Sortable Identifier: a 48-bit millisecond timestamp and 80 random bits in 26 Crockford base32 characters; IDs generated within a millisecond increment the random bits",
+		"example":     "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"decoded":     []string{"timestamp", "randomness", "uuid"},
+	},
+	{
+		"format":      "ksuid",
+		"name":        "KSUID",
+		"description": "K-Sortable Unique Identifier: a 32-bit timestamp in seconds since 2014-05-13T16:53:20Z and a 128-bit random payload in 27 base62 characters",
+		"example":     "0ujtsYcgvSTl8PAuAdqWYSMnLOv",
+		"decoded":     []string{"timestamp", "payload"},
+	},
+	{
+		"format":      "nanoid",
+		"name":        "NanoID",
+		"description": "Random ID of 21 URL-safe characters by default, with a custom alphabet and size",
+		"example":     "V1StGXR8_Z5jdHi6B-myT",
+		"decoded":     []string{"size", "entropy_bits"},
+	},
+	{
+		"format":      "snowflake",
+		"name":        "Snowflake",
+		"description": "64-bit decimal ID of Twitter and Discord: a 41-bit millisecond timestamp since the epoch, a 10-bit worker ID and a 12-bit sequence number",
+		"example":     "175928847299117063",
+		"decoded":     []string{"timestamp", "worker", "sequence", "epoch"},
+	},
+	{
+		"format":      "typeid",
+		"name":        "TypeID",
+		"description": "Type-prefixed UUIDv7: a lowercase prefix, an underscore and the UUID in 26 lowercase Crockford base32 characters",
+		"example":     "user_01h455vb4pex5vsknk084sn02q",
+		"decoded":     []string{"prefix", "suffix", "uuid", "uuid_version", "timestamp"},
+	},
+}
+
+// GetResources returns the list of resources this tool provides
+func (t *IDTool) GetResources() []Resource {
+	return []Resource{
+		{
+			Name:     "ID Formats",
+			URI:      "id://formats",
+			MIMEType: "application/json",
+		},
+		{
+			Name:     "Snowflake Epochs",
+			URI:      "id://epochs",
+			MIMEType: "application/json",
+		},
+	}
+}
+
+// ReadResource reads a specific resource by URI
+func (t *IDTool) ReadResource(uri string) (string, error) {
+	switch uri {
+	case "id://formats":
+		jsonData, err := json.Marshal(idFormatDescriptions)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal formats: %w", err)
+		}
+		return string(jsonData), nil
+	case "id://epochs":
+		epochs := make([]map[string]interface{}, 0, len(snowflakeEpochs))
+		for _, name := range []string{"twitter", "discord"} {
+			epochs = append(epochs, map[string]interface{}{
+				"name":   name,
+				"millis": snowflakeEpochs[name],
+				"time":   time.UnixMilli(snowflakeEpochs[name]).UTC().Format(time.RFC3339Nano),
+			})
+		}
+		jsonData, err := json.Marshal(epochs)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal epochs: %w", err)
+		}
+		return string(jsonData), nil
+	default:
+		return "", fmt.Errorf("unknown resource URI: %s", uri)
+	}
+}
@@ -0,0 +1,347 @@
+package tools
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestIDToolValidateDecodesFields(t *testing.T) {
+	tool := NewIDTool()
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		fields map[string]interface{}
+	}{
+		{"ulid", map[string]interface{}{"input": "01ARZ3NDEKTSV4RRFFQ69G5FAV"}, map[string]interface{}{
+			"timestamp":  "2016-07-30T23:54:10.259Z",
+			"randomness": "d6764c61efb99302bd5b",
+			"uuid":       "01563e3a-b5d3-7676-8c61-efb99302bd5b",
+		}},
+		{"lowercase ulid", map[string]interface{}{"input": "01arz3ndektsv4rrffq69g5fav"}, map[string]interface{}{
+			"id": "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		}},
+		{"ksuid", map[string]interface{}{"format": "ksuid", "input": "0ujtsYcgvSTl8PAuAdqWYSMnLOv"}, map[string]interface{}{
+			"timestamp": "2017-10-10T04:00:47Z",
+			"payload":   "b5a1cd34b5f99d1154fb6853345c9735",
+		}},
+		{"largest ksuid", map[string]interface{}{"format": "ksuid", "input": "aWgEPTl1tmebfsQzFP4bxwgy80V"}, map[string]interface{}{
+			"timestamp": "2150-06-19T23:21:35Z",
+			"payload":   "ffffffffffffffffffffffffffffffff",
+		}},
+		{"discord snowflake", map[string]interface{}{"format": "snowflake", "epoch": "discord", "input": "175928847299117063"}, map[string]interface{}{
+			"timestamp": "2016-04-30T11:18:25.796Z",
+			"worker":    32,
+			"sequence":  7,
+		}},
+		{"twitter snowflake", map[string]interface{}{"format": "snowflake", "input": "4195591873"}, map[string]interface{}{
+			"timestamp": "2010-11-04T01:42:55.657Z",
+			"worker":    314,
+			"sequence":  1729,
+		}},
+		{"snowflake with numeric epoch", map[string]interface{}{"format": "snowflake", "epoch": 0.0, "input": "4194304"}, map[string]interface{}{
+			"timestamp": "1970-01-01T00:00:00.001Z",
+			"epoch":     int64(0),
+		}},
+		{"nanoid", map[string]interface{}{"format": "nanoid", "input": "V1StGXR8_Z5jdHi6B-myT"}, map[string]interface{}{
+			"size":         21,
+			"entropy_bits": 126.0,
+		}},
+		{"typeid", map[string]interface{}{"format": "typeid", "input": "prefix_01h455vb4pex5vsknk084sn02q"}, map[string]interface{}{
+			"prefix":       "prefix",
+			"suffix":       "01h455vb4pex5vsknk084sn02q",
+			"uuid":         "01890a5d-ac96-774b-bcce-b302099a8057",
+			"uuid_version": 7,
+			"timestamp":    "2023-06-30T03:34:18.518Z",
+		}},
+		{"typeid without prefix", map[string]interface{}{"format": "typeid", "input": "00000000000000000000000000"}, map[string]interface{}{
+			"prefix": "",
+			"uuid":   "00000000-0000-0000-0000-000000000000",
+		}},
+		{"typeid with underscores", map[string]interface{}{"format": "typeid", "prefix": "pre_fix", "input": "pre_fix_00000000000000000000000000"}, map[string]interface{}{
+			"prefix": "pre_fix",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["operation"] = "validate"
+			if err := tool.ValidateParams(tt.params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			resultMap := result.(map[string]interface{})
+			if resultMap["valid"] != true {
+				t.Fatalf("Expected %s to be valid, got %v", tt.params["input"], resultMap)
+			}
+			for name, want := range tt.fields {
+				if resultMap[name] != want {
+					t.Errorf("Expected %s %v, got %v", name, want, resultMap[name])
+				}
+			}
+		})
+	}
+}
+
+func TestIDToolValidateRejects(t *testing.T) {
+	tool := NewIDTool()
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"short ulid", map[string]interface{}{"input": "01ARZ3NDEKTSV4RRFFQ69G5FA"}, "exactly 26 characters"},
+		{"ulid with excluded letter", map[string]interface{}{"input": "01ARZ3NDEKTSV4RRFFQ69G5FAU"}, "Crockford base32"},
+		{"ulid overflow", map[string]interface{}{"input": "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"}, "exceeds 128 bits"},
+		{"ksuid overflow", map[string]interface{}{"format": "ksuid", "input": "aWgEPTl1tmebfsQzFP4bxwgy80W"}, "exceeds 160 bits"},
+		{"ksuid with symbol", map[string]interface{}{"format": "ksuid", "input": "0ujtsYcgvSTl8PAuAdqWYSMnLO-"}, "base62"},
+		{"nanoid outside alphabet", map[string]interface{}{"format": "nanoid", "alphabet": "abc", "input": "abcd"}, "not in the alphabet"},
+		{"nanoid of wrong size", map[string]interface{}{"format": "nanoid", "size": 10.0, "input": "V1StGXR8_Z5jdHi6B-myT"}, "exactly 10 characters"},
+		{"negative snowflake", map[string]interface{}{"format": "snowflake", "input": "-1"}, "decimal number"},
+		{"snowflake beyond 63 bits", map[string]interface{}{"format": "snowflake", "input": "9223372036854775808"}, "decimal number"},
+		{"typeid with uppercase suffix", map[string]interface{}{"format": "typeid", "input": "user_01H455VB4PEX5VSKNK084SN02Q"}, "lowercase"},
+		{"typeid with invalid prefix", map[string]interface{}{"format": "typeid", "input": "User_01h455vb4pex5vsknk084sn02q"}, "invalid prefix"},
+		{"typeid with leading separator", map[string]interface{}{"format": "typeid", "input": "_01h455vb4pex5vsknk084sn02q"}, "must not start with an underscore"},
+		{"typeid of another type", map[string]interface{}{"format": "typeid", "prefix": "user", "input": "order_01h455vb4pex5vsknk084sn02q"}, `prefix must be "user"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["operation"] = "validate"
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			resultMap := result.(map[string]interface{})
+			if resultMap["valid"] != false {
+				t.Fatalf("Expected %s to be invalid, got %v", tt.params["input"], resultMap)
+			}
+			if message, _ := resultMap["error"].(string); !strings.Contains(message, tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErr, message)
+			}
+		})
+	}
+}
+
+func TestIDToolGenerate(t *testing.T) {
+	tool := NewIDTool()
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		length int
+		prefix string
+	}{
+		{"ulid", map[string]interface{}{}, 26, ""},
+		{"ksuid", map[string]interface{}{"format": "ksuid"}, 27, ""},
+		{"nanoid", map[string]interface{}{"format": "nanoid"}, 21, ""},
+		{"nanoid with alphabet", map[string]interface{}{"format": "nanoid", "alphabet": "0123456789abcdef", "size": 12.0}, 12, ""},
+		{"nanoid with unicode alphabet", map[string]interface{}{"format": "nanoid", "alphabet": "αβγδ", "size": 5.0}, 10, ""},
+		{"snowflake", map[string]interface{}{"format": "snowflake", "epoch": "discord", "worker": 7.0}, 0, ""},
+		{"typeid", map[string]interface{}{"format": "typeid", "prefix": "user"}, 31, "user_"},
+		{"typeid without prefix", map[string]interface{}{"format": "typeid"}, 26, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["count"] = 50.0
+			if err := tool.ValidateParams(tt.params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			ids := result.([]string)
+			if len(ids) != 50 {
+				t.Fatalf("Expected 50 IDs, got %d", len(ids))
+			}
+
+			// Every generated ID validates with the same options
+			validate := map[string]interface{}{"operation": "validate"}
+			for name, value := range tt.params {
+				if name != "count" {
+					validate[name] = value
+				}
+			}
+			for _, id := range ids {
+				if tt.length > 0 && len(id) != tt.length {
+					t.Errorf("Expected %q to have length %d", id, tt.length)
+				}
+				if !strings.HasPrefix(id, tt.prefix) {
+					t.Errorf("Expected %q to start with %q", id, tt.prefix)
+				}
+				validate["input"] = id
+				check, err := tool.Execute(validate)
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+				if checkMap := check.(map[string]interface{}); checkMap["valid"] != true {
+					t.Errorf("Expected generated %q to be valid, got %v", id, checkMap)
+				}
+			}
+		})
+	}
+}
+
+func TestIDToolGenerateInOrder(t *testing.T) {
+	// IDs of a batch that share a timestamp still sort in generation order
+	tool := NewIDTool()
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+	}{
+		{"ulid", map[string]interface{}{"seed": 1.0}},
+		{"ulid from the clock", map[string]interface{}{}},
+		{"snowflake", map[string]interface{}{"format": "snowflake", "seed": 1.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["count"] = 1000.0
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			ids := result.([]string)
+			sorted := sort.SliceIsSorted(ids, func(i, j int) bool {
+				if len(ids[i]) != len(ids[j]) {
+					return len(ids[i]) < len(ids[j])
+				}
+				return ids[i] < ids[j]
+			})
+			seen := make(map[string]bool)
+			for _, id := range ids {
+				seen[id] = true
+			}
+			if !sorted || len(seen) != len(ids) {
+				t.Errorf("Expected %d distinct IDs in order, got %v", len(ids), ids)
+			}
+		})
+	}
+
+	t.Run("snowflake sequence", func(t *testing.T) {
+		result, _ := tool.Execute(map[string]interface{}{"format": "snowflake", "worker": 3.0, "seed": 1.0, "count": 2.0})
+		decoded, _ := tool.Execute(map[string]interface{}{"operation": "validate", "format": "snowflake", "input": result.([]string)[1]})
+		fields := decoded.(map[string]interface{})
+		if fields["worker"] != 3 || fields["sequence"] != 1 || fields["timestamp"] != "2010-11-04T01:42:54.657Z" {
+			t.Errorf("Unexpected fields %v", fields)
+		}
+	})
+}
+
+func TestIDToolConvert(t *testing.T) {
+	tool := NewIDTool()
+	tests := []struct {
+		name      string
+		input     string
+		ulid      string
+		uuid      string
+		version   int
+		timestamp string
+	}{
+		{"ulid to uuid", "01ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAV", "01563e3a-b5d3-7676-8c61-efb99302bd5b", 7, "2016-07-30T23:54:10.259Z"},
+		{"lowercase ulid", "01arz3ndektsv4rrffq69g5fav", "01ARZ3NDEKTSV4RRFFQ69G5FAV", "01563e3a-b5d3-7676-8c61-efb99302bd5b", 7, "2016-07-30T23:54:10.259Z"},
+		{"uuidv7 to ulid", "01890a5d-ac96-774b-bcce-b302099a8057", "01H455VB4PEX5VSKNK084SN02Q", "01890a5d-ac96-774b-bcce-b302099a8057", 7, "2023-06-30T03:34:18.518Z"},
+		{"uuidv4 has no timestamp", "f47ac10b-58cc-4372-a567-0e02b2c3d479", "7MFB0GPP6C8DSAASRE0ASC7N3S", "f47ac10b-58cc-4372-a567-0e02b2c3d479", 4, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]interface{}{"operation": "convert", "input": tt.input}
+			if err := tool.ValidateParams(params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			resultMap := result.(map[string]interface{})
+			if resultMap["ulid"] != strings.ToUpper(tt.ulid) || resultMap["uuid"] != tt.uuid || resultMap["uuid_version"] != tt.version {
+				t.Errorf("Unexpected conversion %v", resultMap)
+			}
+			if timestamp, _ := resultMap["timestamp"].(string); timestamp != tt.timestamp {
+				t.Errorf("Expected timestamp %q, got %q", tt.timestamp, timestamp)
+			}
+		})
+	}
+
+	t.Run("ulids become uuidv7", func(t *testing.T) {
+		ids, err := tool.Execute(map[string]interface{}{"count": 20.0})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		for _, id := range ids.([]string) {
+			result, err := tool.Execute(map[string]interface{}{"operation": "convert", "input": id})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			u := uuid.MustParse(result.(map[string]interface{})["uuid"].(string))
+			if u.Version() != 7 || u.Variant() != uuid.RFC4122 {
+				t.Errorf("Expected a UUIDv7 of the RFC 4122 variant for %s, got %s", id, u)
+			}
+		}
+	})
+
+	if _, err := tool.Execute(map[string]interface{}{"operation": "convert", "input": "not an id"}); err == nil || !strings.Contains(err.Error(), "ULID or a UUID") {
+		t.Errorf("Expected an error for an input that is neither a ULID nor a UUID, got %v", err)
+	}
+}
+
+func TestIDToolValidateParams(t *testing.T) {
+	tool := NewIDTool()
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"valid generate", map[string]interface{}{"format": "KSUID", "count": 10.0}, ""},
+		{"valid bulk validate", map[string]interface{}{"operation": "validate", "inputs": []interface{}{"01ARZ3NDEKTSV4RRFFQ69G5FAV"}}, ""},
+		{"invalid operation", map[string]interface{}{"operation": "decode"}, "invalid operation"},
+		{"invalid format", map[string]interface{}{"format": "cuid"}, "invalid format"},
+		{"count too high", map[string]interface{}{"count": 1001.0}, "count must be between 1 and 1000"},
+		{"short alphabet", map[string]interface{}{"format": "nanoid", "alphabet": "a"}, "between 2 and 256 characters"},
+		{"repeated alphabet", map[string]interface{}{"format": "nanoid", "alphabet": "abca"}, "must not repeat"},
+		{"size too large", map[string]interface{}{"format": "nanoid", "size": 257.0}, "size must be an integer"},
+		{"unknown epoch", map[string]interface{}{"format": "snowflake", "epoch": "instagram"}, "invalid epoch"},
+		{"negative epoch", map[string]interface{}{"format": "snowflake", "epoch": -1.0}, "invalid epoch"},
+		{"worker too large", map[string]interface{}{"format": "snowflake", "worker": 1024.0}, "between 0 and 1023"},
+		{"prefix with digits", map[string]interface{}{"format": "typeid", "prefix": "user2"}, "invalid prefix"},
+		{"prefix ending in underscore", map[string]interface{}{"format": "typeid", "prefix": "user_"}, "starts and ends with a letter"},
+		{"prefix too long", map[string]interface{}{"format": "typeid", "prefix": strings.Repeat("a", 64)}, "at most 63"},
+		{"validate without input", map[string]interface{}{"operation": "validate"}, "input parameter is required"},
+		{"convert without input", map[string]interface{}{"operation": "convert"}, "input parameter is required"},
+		{"inputs with generate", map[string]interface{}{"inputs": []interface{}{"a"}}, "only supported by the validate operation"},
+		{"seed and secure", map[string]interface{}{"seed": 1.0, "secure": true}, "cannot be used together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tool.ValidateParams(tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIDToolResources(t *testing.T) {
+	tool := NewIDTool()
+	for _, resource := range tool.GetResources() {
+		content, err := tool.ReadResource(resource.URI)
+		if err != nil || content == "" {
+			t.Errorf("ReadResource(%s) = %q, %v", resource.URI, content, err)
+		}
+	}
+	if _, err := tool.ReadResource("id://unknown"); err == nil {
+		t.Error("Expected an error for an unknown resource")
+	}
+}
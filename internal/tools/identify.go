@@ -0,0 +1,282 @@
+// Package tools provides the identify tool implementation
+package tools
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+)
+
+// interpretation describes how to recognise one kind of identifier with a
+// validating tool
+type interpretation struct {
+	// Kind names the interpretation, e.g. "isbn-13"
+	Kind string
+	// Tool validates the input
+	Tool Tool
+	// Params are passed to the tool along with the input to select its validation
+	Params map[string]interface{}
+	// Score rates a valid result, returning a confidence between 0 and 1 and
+	// the evidence it rests on. A confidence of 0 rules the interpretation out.
+	Score func(input string, result map[string]interface{}) (float64, string)
+}
+
+// IdentifyTool recognises identifiers by running the input through every validator
+type IdentifyTool struct {
+	interpretations []interpretation
+}
+
+// NewIdentifyTool creates a new identify tool instance
+func NewIdentifyTool() *IdentifyTool {
+	tool := &IdentifyTool{}
+	tool.populateInterpretations()
+	return tool
+}
+
+// populateInterpretations lists the identifiers the tool recognises. The
+// confidence reflects how unlikely an arbitrary string is to pass the checks:
+// a MOD-97 checksum over a country-specific length is far more telling than
+// a single mod-10 check digit, and an MMSI has no check digit at all.
+func (t *IdentifyTool) populateInterpretations() {
+	t.interpretations = []interpretation{
+		{
+			Kind:   "uuid",
+			Tool:   NewUUIDTool(),
+			Params: map[string]interface{}{"version": "validate"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				if strings.Count(input, "-") == 4 {
+					return 0.99, fmt.Sprintf("canonical 8-4-4-4-12 hex layout, version %v", result["version"])
+				}
+				// 32 bare hex digits might as well be a hash
+				return 0.6, fmt.Sprintf("32 hex digits, version %v", result["version"])
+			},
+		},
+		{
+			Kind:   "iban",
+			Tool:   NewIBANTool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				return 0.99, fmt.Sprintf("length for country %v and MOD-97 checksum", result["country"])
+			},
+		},
+		{
+			Kind:   "isbn-13",
+			Tool:   NewISBNTool(),
+			Params: map[string]interface{}{"operation": "validate", "format": "isbn13"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				// Any EAN-13 passes the check digit; only the Bookland prefixes make it an ISBN
+				isbn, _ := result["isbn"].(string)
+				if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+					return 0, "no 978/979 prefix"
+				}
+				return 0.9, "978/979 prefix and check digit"
+			},
+		},
+		{
+			Kind:   "isbn-10",
+			Tool:   NewISBNTool(),
+			Params: map[string]interface{}{"operation": "validate", "format": "isbn10"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				return 0.8, "mod-11 check digit"
+			},
+		},
+		{
+			Kind:   "creditcard",
+			Tool:   NewCreditCardTool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				if cardType, _ := result["type"].(string); cardType != "unknown" {
+					return 0.8, fmt.Sprintf("Luhn check digit and %s issuer prefix", cardType)
+				}
+				return 0.4, "Luhn check digit, unknown issuer prefix"
+			},
+		},
+		{
+			Kind:   "ean-13",
+			Tool:   NewEAN13Tool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				return 0.7, "13 digits with mod-10 check digit"
+			},
+		},
+		{
+			Kind:   "imo",
+			Tool:   NewIMOTool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				return 0.6, "7 digits with weighted check digit"
+			},
+		},
+		{
+			Kind:   "mmsi",
+			Tool:   NewMMSITool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Score: func(input string, result map[string]interface{}) (float64, string) {
+				if country, _ := result["country_name"].(string); !strings.HasPrefix(country, "Unknown") {
+					return 0.4, fmt.Sprintf("9 digits with the MID of %s (MMSIs have no check digit)", country)
+				}
+				return 0.2, "9 digits with an unassigned MID (MMSIs have no check digit)"
+			},
+		},
+	}
+}
+
+// Name returns the tool's name
+func (t *IdentifyTool) Name() string {
+	return "identify"
+}
+
+// Description returns the tool's description
+func (t *IdentifyTool) Description() string {
+	return "Identify an unknown identifier by running it through every validator (IBAN, ISBN-10/13, EAN-13, IMO, MMSI, credit card, UUID), returning each plausible interpretation ranked by confidence with the fields the validator extracted"
+}
+
+// Annotations returns the tool's behavioural hints for clients
+func (t *IdentifyTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "Identify",
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  false,
+		Tags:       []string{"identifiers", "validation"},
+	}
+}
+
+// Execute runs the identify tool
+func (t *IdentifyTool) Execute(params map[string]interface{}) (interface{}, error) {
+	if err := t.ValidateParams(params); err != nil {
+		return nil, err
+	}
+	input := strings.TrimSpace(params["input"].(string))
+
+	matches := []map[string]interface{}{}
+	for _, interp := range t.interpretations {
+		toolParams := maps.Clone(interp.Params)
+		toolParams["input"] = input
+
+		// A validator that rejects its parameters does not recognise the input
+		value, err := interp.Tool.Execute(toolParams)
+		if err != nil {
+			continue
+		}
+		result, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if valid, _ := result["valid"].(bool); !valid {
+			continue
+		}
+
+		confidence, reason := interp.Score(input, result)
+		if confidence <= 0 {
+			continue
+		}
+		fields := maps.Clone(result)
+		delete(fields, "valid")
+		delete(fields, "input")
+		matches = append(matches, map[string]interface{}{
+			"kind":       interp.Kind,
+			"tool":       interp.Tool.Name(),
+			"confidence": confidence,
+			"reason":     reason,
+			"fields":     fields,
+		})
+	}
+
+	// Rank the most convincing interpretations first
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i]["confidence"].(float64) > matches[j]["confidence"].(float64)
+	})
+
+	return map[string]interface{}{
+		"input":   input,
+		"matches": matches,
+	}, nil
+}
+
+// ValidateParams validates the input parameters
+func (t *IdentifyTool) ValidateParams(params map[string]interface{}) error {
+	input, ok := params["input"]
+	if !ok {
+		return fmt.Errorf("input parameter is required")
+	}
+	inputStr, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("input parameter must be a string")
+	}
+	if strings.TrimSpace(inputStr) == "" {
+		return fmt.Errorf("input parameter cannot be empty")
+	}
+	return nil
+}
+
+// GetInputSchema returns the JSON schema for tool input parameters
+func (t *IdentifyTool) GetInputSchema() map[string]interface{} {
+	return CreateJSONSchema([]ParameterDefinition{
+		{
+			Name:        "input",
+			Type:        "string",
+			Description: "The identifier to recognise",
+			Required:    true,
+		},
+	})
+}
+
+// GetOutputSchema returns the JSON schema for tool output
+func (t *IdentifyTool) GetOutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"result": map[string]interface{}{
+				"type":        "object",
+				"description": "The plausible interpretations of the input, most confident first",
+				"properties": map[string]interface{}{
+					"input": map[string]interface{}{
+						"type":        "string",
+						"description": "The input, with surrounding whitespace removed",
+					},
+					"matches": map[string]interface{}{
+						"type":        "array",
+						"description": "Interpretations whose validator accepted the input; empty when none did",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"kind": map[string]interface{}{
+									"type":        "string",
+									"description": "Kind of identifier, e.g. iban, isbn-13 or mmsi",
+								},
+								"tool": map[string]interface{}{
+									"type":        "string",
+									"description": "Tool that validated the input",
+								},
+								"confidence": map[string]interface{}{
+									"type":        "number",
+									"description": "Confidence between 0 and 1 that the input is this kind of identifier",
+								},
+								"reason": map[string]interface{}{
+									"type":        "string",
+									"description": "The checks the confidence rests on",
+								},
+								"fields": map[string]interface{}{
+									"type":        "object",
+									"description": "Fields extracted by the validator, as returned by its validate operation",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// GetResources returns the list of resources this tool provides
+func (t *IdentifyTool) GetResources() []Resource {
+	return []Resource{}
+}
+
+// ReadResource reads a specific resource by URI
+func (t *IdentifyTool) ReadResource(uri string) (string, error) {
+	return "", fmt.Errorf("no resources available for identify tool")
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestIdentifyTool(t *testing.T) {
+	tool := NewIdentifyTool()
+
+	tests := []struct {
+		name    string
+		input   string
+		kinds   []string
+		fields  map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "iban with spaces",
+			input:  "GB82 WEST 1234 5698 7654 32",
+			kinds:  []string{"iban"},
+			fields: map[string]interface{}{"country": "GB"},
+		},
+		{
+			name:   "isbn-13 is also an ean-13",
+			input:  "978-0-306-40615-7",
+			kinds:  []string{"isbn-13", "ean-13"},
+			fields: map[string]interface{}{"format": "ISBN13"},
+		},
+		{
+			name:   "ean-13 without a book prefix is not an isbn-13",
+			input:  "4006381333931",
+			kinds:  []string{"ean-13"},
+			fields: map[string]interface{}{"ean13": "4006381333931"},
+		},
+		{
+			name:  "isbn-10",
+			input: "0306406152",
+			kinds: []string{"isbn-10"},
+		},
+		{
+			name:   "credit card with known issuer",
+			input:  "4111 1111 1111 1111",
+			kinds:  []string{"creditcard"},
+			fields: map[string]interface{}{"type": "visa"},
+		},
+		{
+			name:   "uuid with version",
+			input:  "550e8400-e29b-41d4-a716-446655440000",
+			kinds:  []string{"uuid"},
+			fields: map[string]interface{}{"version": 4},
+		},
+		{
+			name:   "mmsi",
+			input:  "257123450",
+			kinds:  []string{"mmsi"},
+			fields: map[string]interface{}{"country_name": "Norway"},
+		},
+		{
+			name:  "imo",
+			input: " 9074729 ",
+			kinds: []string{"imo"},
+		},
+		{
+			name:  "no match",
+			input: "hello",
+			kinds: []string{},
+		},
+		{
+			name:    "empty input",
+			input:   "  ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(map[string]interface{}{"input": tt.input})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			matches := result.(map[string]interface{})["matches"].([]map[string]interface{})
+			if len(matches) != len(tt.kinds) {
+				t.Fatalf("Expected matches %v, got %v", tt.kinds, matches)
+			}
+			for i, kind := range tt.kinds {
+				if matches[i]["kind"] != kind {
+					t.Errorf("Match %d: expected %s, got %v", i, kind, matches[i]["kind"])
+				}
+				if i > 0 && matches[i]["confidence"].(float64) > matches[i-1]["confidence"].(float64) {
+					t.Errorf("Matches are not ranked by confidence: %v", matches)
+				}
+			}
+
+			if len(matches) > 0 {
+				fields := matches[0]["fields"].(map[string]interface{})
+				for name, want := range tt.fields {
+					if fields[name] != want {
+						t.Errorf("Expected field %s = %v, got %v", name, want, fields[name])
+					}
+				}
+				if _, ok := fields["valid"]; ok {
+					t.Error("Fields should not repeat the valid flag")
+				}
+			}
+		})
+	}
+}
+
+func TestIdentifyConfidence(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		kind  string
+		more  string
+	}{
+		{name: "known issuer beats unknown", input: "4111111111111111", kind: "creditcard", more: "1000000000000008"},
+		{name: "known MID beats unassigned", input: "257123450", kind: "mmsi", more: "999123456"},
+		{name: "dashed uuid beats bare hex", input: "550e8400-e29b-41d4-a716-446655440000", kind: "uuid", more: "550e8400e29b41d4a716446655440000"},
+	}
+
+	confidence := func(t *testing.T, input, kind string) float64 {
+		result, err := NewIdentifyTool().Execute(map[string]interface{}{"input": input})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, match := range result.(map[string]interface{})["matches"].([]map[string]interface{}) {
+			if match["kind"] == kind {
+				return match["confidence"].(float64)
+			}
+		}
+		t.Fatalf("Expected %s to be identified as %s", input, kind)
+		return 0
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if strong, weak := confidence(t, tt.input, tt.kind), confidence(t, tt.more, tt.kind); strong <= weak {
+				t.Errorf("Expected %s (%v) to be more confident than %s (%v)", tt.input, strong, tt.more, weak)
+			}
+		})
+	}
+}
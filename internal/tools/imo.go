@@ -1,9 +1,9 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
 )
@@ -26,8 +26,24 @@ func (i *IMOTool) Description() string {
 	return "Generate and validate International Maritime Organization (IMO) numbers. IMO numbers are 7-digit numbers with a check digit calculated using a weighted sum algorithm."
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (i *IMOTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "IMO Number",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"maritime", "identifiers", "generation", "validation"},
+	}
+}
+
 // Execute runs the IMO tool
 func (i *IMOTool) Execute(params map[string]interface{}) (interface{}, error) {
+	return i.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext runs the IMO tool, stopping early when the context is cancelled
+func (i *IMOTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	operation, _ := params["operation"].(string)
 	if operation == "" {
 		operation = "validate" // Default to validate
@@ -35,9 +51,12 @@ func (i *IMOTool) Execute(params map[string]interface{}) (interface{}, error) {
 
 	switch operation {
 	case "validate":
+		if hasInputs(params) {
+			return validateEach(ctx, params, i.validateIMO)
+		}
 		return i.validateIMO(params)
 	case "generate":
-		return i.generateIMO(params)
+		return i.generateIMO(ctx, params)
 	default:
 		return nil, fmt.Errorf("invalid operation: %s. Must be 'validate' or 'generate'", operation)
 	}
@@ -100,8 +119,8 @@ func (i *IMOTool) validateIMO(params map[string]interface{}) (interface{}, error
 }
 
 // generateIMO generates IMO numbers
-func (i *IMOTool) generateIMO(params map[string]interface{}) (interface{}, error) {
-	count, _ := params["count"].(int)
+func (i *IMOTool) generateIMO(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	count, _ := IntParam(params["count"])
 	if count <= 0 {
 		count = 1
 	}
@@ -113,11 +132,12 @@ func (i *IMOTool) generateIMO(params map[string]interface{}) (interface{}, error
 
 	results := make([]string, count)
 
-	for idx := range count {
+	rng := randFor(ctx, params)
+	err := generateEach(ctx, count, func(idx int) error {
 		// Generate 6 random digits
 		digits := make([]int, 6)
 		for j := range 6 {
-			digits[j] = rand.Intn(10)
+			digits[j] = rng.Intn(10)
 		}
 
 		// Calculate check digit using weighted sum
@@ -132,6 +152,10 @@ func (i *IMOTool) generateIMO(params map[string]interface{}) (interface{}, error
 
 		// Format as 7-digit string with leading zeros if needed
 		results[idx] = fmt.Sprintf("%07d", imo)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if count == 1 {
@@ -156,7 +180,7 @@ func (i *IMOTool) ValidateParams(params map[string]interface{}) error {
 
 	// Validate count for generation
 	if count, ok := params["count"]; ok {
-		if countInt, ok := count.(int); ok {
+		if countInt, ok := IntParam(count); ok {
 			if countInt < 1 {
 				return fmt.Errorf("count must be at least 1")
 			}
@@ -170,7 +194,7 @@ func (i *IMOTool) ValidateParams(params map[string]interface{}) error {
 
 	// Validate input for validation
 	if operation, ok := params["operation"]; ok {
-		if operationStr, ok := operation.(string); ok && operationStr == "validate" {
+		if operationStr, ok := operation.(string); ok && operationStr == "validate" && !hasInputs(params) {
 			if input, ok := params["input"]; !ok {
 				return fmt.Errorf("input parameter is required for validation")
 			} else if _, ok := input.(string); !ok {
@@ -178,8 +202,11 @@ func (i *IMOTool) ValidateParams(params map[string]interface{}) error {
 			}
 		}
 	}
+	if err := validateBulkParams(params); err != nil {
+		return err
+	}
 
-	return nil
+	return validateSeed(params)
 }
 
 // GetInputSchema returns the JSON schema for tool input parameters
@@ -195,15 +222,24 @@ func (i *IMOTool) GetInputSchema() map[string]interface{} {
 		{
 			Name:        "input",
 			Type:        "string",
-			Description: "IMO number to validate (required for validation operation)",
+			Description: "IMO number to validate (required for validation operation unless inputs is given)",
 			Required:    false,
 		},
+		{
+			Name:        "inputs",
+			Type:        "array",
+			Description: "IMO numbers to validate in bulk (max 10000); the result lists a validation result per value, numbered by line, and a summary",
+			Required:    false,
+			Items:       "string",
+		},
 		{
 			Name:        "count",
 			Type:        "integer",
 			Description: "Number of IMO numbers to generate (default: 1, max: 100)",
 			Required:    false,
 		},
+		seedParameter,
+		secureParameter,
 	})
 }
 
@@ -212,9 +248,13 @@ func (i *IMOTool) GetOutputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"result": map[string]interface{}{
-				"description": "Generated IMO number(s) or validation result",
-			},
+			"result": withBulkResults(map[string]interface{}{
+				"type":        []string{"string", "array", "object"},
+				"description": "Generated IMO number(s) or validation result (results and summary when validating inputs)",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			}),
 		},
 	}
 }
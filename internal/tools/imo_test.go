@@ -250,6 +250,20 @@ func TestIMOToolGenerateIMO(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name:   "generate_multiple_json_count",
+			params: map[string]interface{}{"operation": "generate", "count": float64(3)},
+			validate: func(result interface{}) error {
+				imos, ok := result.([]string)
+				if !ok {
+					return fmt.Errorf("Expected []string result, got %T", result)
+				}
+				if len(imos) != 3 {
+					return fmt.Errorf("Expected 3 IMOs, got %d", len(imos))
+				}
+				return nil
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,6 +2,7 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -33,6 +34,47 @@ type Tool interface {
 	ReadResource(uri string) (string, error)
 }
 
+// ToolAnnotations describes a tool's behaviour to clients. All fields are hints
+// that clients may use, for example to auto-approve read-only tools.
+type ToolAnnotations struct {
+	// Title is a human-readable name for the tool
+	Title string `json:"title,omitempty"`
+
+	// ReadOnly indicates that the tool does not modify its environment
+	ReadOnly bool `json:"readOnlyHint"`
+
+	// Destructive indicates that the tool may perform destructive updates;
+	// it is only meaningful for tools that are not read-only
+	Destructive bool `json:"destructiveHint"`
+
+	// Idempotent indicates that repeated calls with the same parameters give the same result
+	Idempotent bool `json:"idempotentHint"`
+
+	// OpenWorld indicates that the tool interacts with external entities
+	OpenWorld bool `json:"openWorldHint"`
+
+	// Tags categorise the tool, e.g. "validation" or "maritime"
+	Tags []string `json:"tags,omitempty"`
+}
+
+// AnnotatedTool is implemented by tools that describe their behaviour with annotations
+type AnnotatedTool interface {
+	Tool
+
+	// Annotations returns the tool's annotations
+	Annotations() ToolAnnotations
+}
+
+// GetAnnotations returns the annotations of a tool. Tools that do not implement
+// AnnotatedTool get the conservative MCP defaults: not read-only, destructive,
+// not idempotent and open world.
+func GetAnnotations(tool Tool) ToolAnnotations {
+	if annotated, ok := tool.(AnnotatedTool); ok {
+		return annotated.Annotations()
+	}
+	return ToolAnnotations{Destructive: true, OpenWorld: true}
+}
+
 // Resource represents a resource that a tool can provide
 type Resource struct {
 	Name     string `json:"name"`
@@ -46,6 +88,7 @@ type ToolMetadata struct {
 	Description  string                 `json:"description"`
 	InputSchema  map[string]interface{} `json:"inputSchema"`
 	OutputSchema map[string]interface{} `json:"outputSchema"`
+	Annotations  ToolAnnotations        `json:"annotations"`
 }
 
 // ParameterDefinition defines a tool parameter
@@ -56,6 +99,7 @@ type ParameterDefinition struct {
 	Required    bool        `json:"required"`
 	Default     interface{} `json:"default,omitempty"`
 	Enum        []string    `json:"enum,omitempty"`
+	Items       string      `json:"items,omitempty"` // element type of array parameters
 }
 
 // ToolRegistry manages tool registration and discovery
@@ -116,6 +160,7 @@ func (r *ToolRegistry) GetToolMetadata(name string) (*ToolMetadata, error) {
 		Description:  tool.Description(),
 		InputSchema:  tool.GetInputSchema(),
 		OutputSchema: tool.GetOutputSchema(),
+		Annotations:  GetAnnotations(tool),
 	}, nil
 }
 
@@ -128,6 +173,7 @@ func (r *ToolRegistry) GetAllToolMetadata() ([]ToolMetadata, error) {
 			Description:  tool.Description(),
 			InputSchema:  tool.GetInputSchema(),
 			OutputSchema: tool.GetOutputSchema(),
+			Annotations:  GetAnnotations(tool),
 		})
 	}
 	return metadata, nil
@@ -140,23 +186,28 @@ func (r *ToolRegistry) ValidateToolParameters(toolName string, params map[string
 		return fmt.Errorf("tool %s not found", toolName)
 	}
 
-	return tool.ValidateParams(params)
+	return ValidateInput(tool, params)
 }
 
 // ExecuteTool executes a tool with the given parameters
 func (r *ToolRegistry) ExecuteTool(toolName string, params map[string]interface{}) (interface{}, error) {
+	return r.ExecuteToolContext(context.Background(), toolName, params)
+}
+
+// ExecuteToolContext executes a tool with the given parameters, cancelling it when the context is done
+func (r *ToolRegistry) ExecuteToolContext(ctx context.Context, toolName string, params map[string]interface{}) (interface{}, error) {
 	tool, exists := r.GetTool(toolName)
 	if !exists {
 		return nil, fmt.Errorf("tool %s not found", toolName)
 	}
 
-	// Validate parameters first
-	if err := tool.ValidateParams(params); err != nil {
+	// Validate parameters against the schema and the tool's own rules first
+	if err := ValidateInput(tool, params); err != nil {
 		return nil, fmt.Errorf("parameter validation failed: %w", err)
 	}
 
 	// Execute the tool
-	return tool.Execute(params)
+	return AsContextTool(tool).ExecuteContext(ctx, params)
 }
 
 // CreateJSONSchema creates a JSON schema for tool parameters
@@ -178,6 +229,10 @@ func CreateJSONSchema(parameters []ParameterDefinition) map[string]interface{} {
 			property["enum"] = param.Enum
 		}
 
+		if param.Items != "" {
+			property["items"] = map[string]interface{}{"type": param.Items}
+		}
+
 		properties[param.Name] = property
 
 		if param.Required {
@@ -186,8 +241,9 @@ func CreateJSONSchema(parameters []ParameterDefinition) map[string]interface{} {
 	}
 
 	schema := map[string]interface{}{
-		"type":       "object",
-		"properties": properties,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
 	}
 
 	if len(required) > 0 {
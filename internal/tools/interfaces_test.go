@@ -454,3 +454,68 @@ func TestToolResult(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expectedJSON, string(jsonData))
 	}
 }
+
+func TestToolAnnotations(t *testing.T) {
+	tests := []struct {
+		tool       Tool
+		idempotent bool
+		tag        string
+	}{
+		{NewEchoTool(), true, "utility"},
+		{NewVersionTool(), true, "utility"},
+		{NewTimeTool(), false, "time"},
+		{NewRandomTool(), false, "random"},
+		{NewUUIDTool(), false, "identifiers"},
+		{NewIMOTool(), false, "maritime"},
+		{NewMMSITool(), false, "maritime"},
+		{NewCreditCardTool(), false, "finance"},
+		{NewIBANTool(), false, "finance"},
+		{NewISBNTool(), false, "publishing"},
+		{NewEAN13Tool(), false, "retail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool.Name(), func(t *testing.T) {
+			if _, ok := tt.tool.(AnnotatedTool); !ok {
+				t.Fatal("Tool should implement AnnotatedTool")
+			}
+
+			annotations := GetAnnotations(tt.tool)
+			if annotations.Title == "" {
+				t.Error("Expected a title")
+			}
+			if !annotations.ReadOnly {
+				t.Error("Expected tool to be read-only")
+			}
+			if annotations.OpenWorld {
+				t.Error("Expected tool not to interact with external entities")
+			}
+			if annotations.Destructive {
+				t.Error("Expected tool not to be destructive")
+			}
+			if annotations.Idempotent != tt.idempotent {
+				t.Errorf("Expected idempotent to be %v, got %v", tt.idempotent, annotations.Idempotent)
+			}
+			if !contains(annotations.Tags, tt.tag) {
+				t.Errorf("Expected tags %v to contain %q", annotations.Tags, tt.tag)
+			}
+		})
+	}
+}
+
+func TestGetAnnotationsDefaults(t *testing.T) {
+	annotations := GetAnnotations(&MockTool{name: "mock"})
+	if annotations.ReadOnly || !annotations.Destructive || annotations.Idempotent || !annotations.OpenWorld {
+		t.Errorf("Expected conservative defaults for tools without annotations, got %+v", annotations)
+	}
+
+	registry := NewToolRegistry()
+	registry.RegisterTool(NewIBANTool())
+	metadata, err := registry.GetToolMetadata("iban")
+	if err != nil {
+		t.Fatalf("GetToolMetadata() error = %v", err)
+	}
+	if metadata.Annotations.Title != "IBAN" || !metadata.Annotations.ReadOnly {
+		t.Errorf("Expected tool metadata to include annotations, got %+v", metadata.Annotations)
+	}
+}
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -26,8 +27,24 @@ func (i *ISBNTool) Description() string {
 	return "Generate and validate International Standard Book Numbers (ISBN-10 and ISBN-13) with format support"
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (i *ISBNTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "ISBN",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"publishing", "identifiers", "generation", "validation"},
+	}
+}
+
 // Execute processes the ISBN tool request
 func (i *ISBNTool) Execute(params map[string]interface{}) (interface{}, error) {
+	return i.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext processes the ISBN tool request, stopping early when the context is cancelled
+func (i *ISBNTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	// Validate parameters first
 	if err := i.ValidateParams(params); err != nil {
 		return nil, err
@@ -40,9 +57,12 @@ func (i *ISBNTool) Execute(params map[string]interface{}) (interface{}, error) {
 
 	switch operation {
 	case "validate":
+		if hasInputs(params) {
+			return validateEach(ctx, params, i.validateISBN)
+		}
 		return i.validateISBN(params)
 	case "generate":
-		return i.generateISBN(params)
+		return i.generateISBN(ctx, params)
 	default:
 		return nil, fmt.Errorf("invalid operation: %s. Supported operations: validate, generate", operation)
 	}
@@ -63,12 +83,15 @@ func (i *ISBNTool) ValidateParams(params map[string]interface{}) error {
 
 	// Validate input for validation operation
 	if operation, ok := params["operation"]; ok {
-		if opStr, ok := operation.(string); ok && opStr == "validate" {
+		if opStr, ok := operation.(string); ok && opStr == "validate" && !hasInputs(params) {
 			if input, ok := params["input"]; !ok || input == "" {
 				return fmt.Errorf("input parameter is required for validation")
 			}
 		}
 	}
+	if err := validateBulkParams(params); err != nil {
+		return err
+	}
 
 	// Validate count
 	if count, ok := params["count"]; ok {
@@ -95,7 +118,7 @@ func (i *ISBNTool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	return nil
+	return validateSeed(params)
 }
 
 // GetInputSchema returns the JSON schema for input parameters
@@ -110,7 +133,14 @@ func (i *ISBNTool) GetInputSchema() map[string]interface{} {
 			},
 			"input": map[string]interface{}{
 				"type":        "string",
-				"description": "ISBN number to validate (required for validate operation)",
+				"description": "ISBN number to validate (required for validate operation unless inputs is given)",
+			},
+			"inputs": map[string]interface{}{
+				"type":        "array",
+				"description": "ISBN numbers to validate in bulk (max 10000); the result lists a validation result per value, numbered by line, and a summary",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
 			},
 			"format": map[string]interface{}{
 				"type":        "string",
@@ -123,8 +153,17 @@ func (i *ISBNTool) GetInputSchema() map[string]interface{} {
 				"minimum":     1,
 				"maximum":     100,
 			},
+			"seed": map[string]interface{}{
+				"type":        "integer",
+				"description": seedParameter.Description,
+			},
+			"secure": map[string]interface{}{
+				"type":        "boolean",
+				"description": secureParameter.Description,
+			},
 		},
-		"required": []string{},
+		"required":             []string{},
+		"additionalProperties": false,
 	}
 }
 
@@ -133,29 +172,35 @@ func (i *ISBNTool) GetOutputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"valid": map[string]interface{}{
-				"type":        "boolean",
-				"description": "Whether the ISBN number is valid",
-			},
-			"isbn": map[string]interface{}{
-				"type":        "string",
-				"description": "Generated ISBN number",
-			},
-			"isbns": map[string]interface{}{
-				"type":        "array",
-				"description": "Generated ISBN numbers",
+			"result": withBulkResults(map[string]interface{}{
+				"type":        []string{"string", "array", "object"},
+				"description": "Generated ISBN (or array of ISBNs if count>1), or validation result for validate (results and summary when validating inputs)",
 				"items": map[string]interface{}{
 					"type": "string",
 				},
-			},
-			"format": map[string]interface{}{
-				"type":        "string",
-				"description": "Detected or generated ISBN format (ISBN-10 or ISBN-13)",
-			},
-			"error": map[string]interface{}{
-				"type":        "string",
-				"description": "Error message if validation fails",
-			},
+				"properties": map[string]interface{}{
+					"valid": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether the ISBN number is valid",
+					},
+					"isbn": map[string]interface{}{
+						"type":        "string",
+						"description": "Normalized ISBN number",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Detected ISBN format (ISBN10 or ISBN13)",
+					},
+					"input": map[string]interface{}{
+						"type":        "string",
+						"description": "The original input",
+					},
+					"error": map[string]interface{}{
+						"type":        "string",
+						"description": "Error message if validation fails",
+					},
+				},
+			}),
 		},
 	}
 }
@@ -347,7 +392,7 @@ func (i *ISBNTool) validateISBN(params map[string]interface{}) (interface{}, err
 }
 
 // generateISBN generates ISBN numbers
-func (i *ISBNTool) generateISBN(params map[string]interface{}) (interface{}, error) {
+func (i *ISBNTool) generateISBN(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	count := 1
 	if c, ok := params["count"].(float64); ok {
 		count = int(c)
@@ -358,8 +403,9 @@ func (i *ISBNTool) generateISBN(params map[string]interface{}) (interface{}, err
 		format = f
 	}
 
+	rng := randFor(ctx, params)
 	if count == 1 {
-		isbn, err := i.generateSingleISBN(format)
+		isbn, err := i.generateSingleISBN(rng, format)
 		if err != nil {
 			return nil, err
 		}
@@ -367,24 +413,28 @@ func (i *ISBNTool) generateISBN(params map[string]interface{}) (interface{}, err
 	}
 
 	isbns := make([]string, count)
-	for idx := range count {
-		isbn, err := i.generateSingleISBN(format)
+	err := generateEach(ctx, count, func(idx int) error {
+		isbn, err := i.generateSingleISBN(rng, format)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		isbns[idx] = isbn
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return isbns, nil
 }
 
 // generateSingleISBN generates a single ISBN number
-func (i *ISBNTool) generateSingleISBN(format string) (string, error) {
+func (i *ISBNTool) generateSingleISBN(rng *rand.Rand, format string) (string, error) {
 	switch format {
 	case "isbn10":
-		return i.generateISBN10()
+		return i.generateISBN10(rng)
 	case "isbn13":
-		return i.generateISBN13()
+		return i.generateISBN13(rng)
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
@@ -465,11 +515,11 @@ func (i *ISBNTool) validateISBN13(isbn string) (bool, string) {
 }
 
 // generateISBN10 generates a random ISBN-10 number
-func (i *ISBNTool) generateISBN10() (string, error) {
+func (i *ISBNTool) generateISBN10(rng *rand.Rand) (string, error) {
 	// Generate 9 random digits
 	digits := make([]int, 9)
 	for i := range 9 {
-		digits[i] = rand.Intn(10)
+		digits[i] = rng.Intn(10)
 	}
 
 	// Calculate check digit
@@ -496,11 +546,11 @@ func (i *ISBNTool) generateISBN10() (string, error) {
 }
 
 // generateISBN13 generates a random ISBN-13 number
-func (i *ISBNTool) generateISBN13() (string, error) {
+func (i *ISBNTool) generateISBN13(rng *rand.Rand) (string, error) {
 	// Generate 12 random digits
 	digits := make([]int, 12)
 	for i := range 12 {
-		digits[i] = rand.Intn(10)
+		digits[i] = rng.Intn(10)
 	}
 
 	// Calculate check digit using EAN-13 algorithm
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -20,7 +21,7 @@ type MMSIType struct {
 	Name     string // kebab-case name (e.g., "ship", "sar-aircraft")
 	FullName string // full name (e.g., "Ship Station", "SAR Aircraft")
 	IsType   func(int) bool
-	Generate func(countryCode string) (int, error)
+	Generate func(rng *rand.Rand, countryCode string) (int, error)
 }
 
 // MMSITool implements MMSI number validation and generation
@@ -49,8 +50,24 @@ func (m *MMSITool) Description() string {
 	return "Generate and validate Maritime Mobile Service Identity (MMSI) numbers. MMSI numbers are 9-digit identifiers used for maritime communication."
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (m *MMSITool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "MMSI Number",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"maritime", "identifiers", "generation", "validation"},
+	}
+}
+
 // Execute runs the MMSI tool
 func (m *MMSITool) Execute(params map[string]interface{}) (interface{}, error) {
+	return m.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext runs the MMSI tool, stopping early when the context is cancelled
+func (m *MMSITool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	operation, _ := params["operation"].(string)
 	if operation == "" {
 		operation = "validate" // Default to validate
@@ -58,9 +75,12 @@ func (m *MMSITool) Execute(params map[string]interface{}) (interface{}, error) {
 
 	switch operation {
 	case "validate":
+		if hasInputs(params) {
+			return validateEach(ctx, params, m.validateMMSI)
+		}
 		return m.validateMMSI(params)
 	case "generate":
-		return m.generateMMSI(params)
+		return m.generateMMSI(ctx, params)
 	default:
 		return nil, fmt.Errorf("invalid operation: %s. Must be 'validate' or 'generate'", operation)
 	}
@@ -124,8 +144,8 @@ func (m *MMSITool) validateMMSI(params map[string]interface{}) (interface{}, err
 }
 
 // generateMMSI generates MMSI numbers
-func (m *MMSITool) generateMMSI(params map[string]interface{}) (interface{}, error) {
-	count, _ := params["count"].(int)
+func (m *MMSITool) generateMMSI(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	count, _ := IntParam(params["count"])
 	if count <= 0 {
 		count = 1
 	}
@@ -140,23 +160,28 @@ func (m *MMSITool) generateMMSI(params map[string]interface{}) (interface{}, err
 
 	results := make([]int, count)
 
-	for idx := range count {
+	rng := randFor(ctx, params)
+	err := generateEach(ctx, count, func(idx int) error {
 		var mmsi int
 		var err error
 
 		if mmsiType != "" {
 			// Generate specific MMSI type
-			mmsi, err = m.generateSpecificType(mmsiType, countryCode)
+			mmsi, err = m.generateSpecificType(rng, mmsiType, countryCode)
 		} else {
 			// Generate random MMSI
-			mmsi, err = m.generateRandomMMSI(countryCode)
+			mmsi, err = m.generateRandomMMSI(rng, countryCode)
 		}
 
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		results[idx] = mmsi
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if count == 1 {
@@ -296,7 +321,7 @@ func (m *MMSITool) getCountryName(mid int) string {
 }
 
 // generateRandomMMSI generates a random MMSI
-func (m *MMSITool) generateRandomMMSI(countryCode string) (int, error) {
+func (m *MMSITool) generateRandomMMSI(rng *rand.Rand, countryCode string) (int, error) {
 	var allMIDs []int
 	if countryCode != "" {
 		// Get MIDs for specific country
@@ -310,10 +335,10 @@ func (m *MMSITool) generateRandomMMSI(countryCode string) (int, error) {
 	}
 
 	// Select a random MID from all available ones
-	selectedMID := allMIDs[rand.Intn(len(allMIDs))]
+	selectedMID := allMIDs[rng.Intn(len(allMIDs))]
 
 	// Generate 6 random digits for the remaining part
-	remainingDigits := rand.Intn(1000000) // 0 to 999999
+	remainingDigits := rng.Intn(1000000) // 0 to 999999
 
 	// Build the MMSI number
 	mmsi := selectedMID*1000000 + remainingDigits
@@ -331,7 +356,7 @@ func (m *MMSITool) populateTypes() {
 			IsType: func(mmsi int) bool {
 				return mmsi == 36699999
 			},
-			Generate: func(countryCode string) (int, error) {
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
 				return 36699999, nil
 			},
 		},
@@ -341,7 +366,7 @@ func (m *MMSITool) populateTypes() {
 			IsType: func(mmsi int) bool {
 				return mmsi == 3669999
 			},
-			Generate: func(countryCode string) (int, error) {
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
 				return 3669999, nil
 			},
 		},
@@ -352,8 +377,8 @@ func (m *MMSITool) populateTypes() {
 				// US Federal: starts with 3669
 				return mmsi >= 366900000 && mmsi <= 366999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				remaining := rand.Intn(100000) // 0 to 99999
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				remaining := rng.Intn(100000) // 0 to 99999
 				return 366900000 + remaining, nil
 			},
 		},
@@ -364,8 +389,8 @@ func (m *MMSITool) populateTypes() {
 				// US Ship with Inmarsat: starts with 366 and ends with 000
 				return mmsi >= 366000000 && mmsi <= 366999999 && mmsi%1000 == 0
 			},
-			Generate: func(countryCode string) (int, error) {
-				remaining := rand.Intn(1000) * 1000 // 000, 1000, 2000, ..., 999000
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				remaining := rng.Intn(1000) * 1000 // 000, 1000, 2000, ..., 999000
 				return 366000000 + remaining, nil
 			},
 		},
@@ -376,9 +401,9 @@ func (m *MMSITool) populateTypes() {
 				// US Ship other: starts with 366, not ending with 000, not federal
 				return mmsi >= 366000000 && mmsi <= 366999999 && mmsi%1000 != 0 && mmsi < 366900000
 			},
-			Generate: func(countryCode string) (int, error) {
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
 				// Generate random US ship (not Inmarsat, not federal)
-				remaining := rand.Intn(900000) + 100000 // 100000 to 999999
+				remaining := rng.Intn(900000) + 100000 // 100000 to 999999
 				return 366000000 + remaining, nil
 			},
 		},
@@ -391,11 +416,11 @@ func (m *MMSITool) populateTypes() {
 					(mmsi >= 368000000 && mmsi <= 368999999) ||
 					(mmsi >= 369000000 && mmsi <= 369999999)
 			},
-			Generate: func(countryCode string) (int, error) {
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
 				// Generate random US ship with other MIDs
 				mids := []int{367, 368, 369}
-				selectedMID := mids[rand.Intn(len(mids))]
-				remaining := rand.Intn(1000000)
+				selectedMID := mids[rng.Intn(len(mids))]
+				remaining := rng.Intn(1000000)
 				return selectedMID*1000000 + remaining, nil
 			},
 		},
@@ -406,8 +431,8 @@ func (m *MMSITool) populateTypes() {
 				// SAR aircraft: 111xxxxxx
 				return mmsi >= 111000000 && mmsi <= 111999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				remaining := rand.Intn(1000000)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				remaining := rng.Intn(1000000)
 				return 111000000 + remaining, nil
 			},
 		},
@@ -418,8 +443,8 @@ func (m *MMSITool) populateTypes() {
 				// AIS-SART: 970xxxxxx
 				return mmsi >= 970000000 && mmsi <= 970999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				remaining := rand.Intn(1000000)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				remaining := rng.Intn(1000000)
 				return 970000000 + remaining, nil
 			},
 		},
@@ -430,8 +455,8 @@ func (m *MMSITool) populateTypes() {
 				// Handheld VHF: 8xxxxxxx
 				return mmsi >= 800000000 && mmsi <= 899999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				remaining := rand.Intn(100000000)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				remaining := rng.Intn(100000000)
 				return 800000000 + remaining, nil
 			},
 		},
@@ -442,8 +467,8 @@ func (m *MMSITool) populateTypes() {
 				// Man overboard: 972xxxxxx
 				return mmsi >= 972000000 && mmsi <= 972999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				remaining := rand.Intn(1000000)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				remaining := rng.Intn(1000000)
 				return 972000000 + remaining, nil
 			},
 		},
@@ -454,8 +479,8 @@ func (m *MMSITool) populateTypes() {
 				// EPIRB-AIS: 974xxxxxx
 				return mmsi >= 974000000 && mmsi <= 974999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				remaining := rand.Intn(1000000)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				remaining := rng.Intn(1000000)
 				return 974000000 + remaining, nil
 			},
 		},
@@ -471,8 +496,8 @@ func (m *MMSITool) populateTypes() {
 					!(mmsi >= 972000000 && mmsi <= 972999999) && // Not man overboard
 					!(mmsi >= 974000000 && mmsi <= 974999999) // Not EPIRB-AIS
 			},
-			Generate: func(countryCode string) (int, error) {
-				return m.generateShipStation(countryCode, false, false)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				return m.generateShipStation(rng, countryCode, false, false)
 			},
 		},
 		{
@@ -482,8 +507,8 @@ func (m *MMSITool) populateTypes() {
 				// Group ship: 0xxxxxxx (first digit is 0)
 				return mmsi >= 10000000 && mmsi <= 99999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				return m.generateGroupShipStation(countryCode)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				return m.generateGroupShipStation(rng, countryCode)
 			},
 		},
 		{
@@ -493,8 +518,8 @@ func (m *MMSITool) populateTypes() {
 				// Coast station: 00xxxxxxx (first two digits are 00)
 				return mmsi >= 1000000 && mmsi <= 9999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				return m.generateCoastStation(countryCode, false)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				return m.generateCoastStation(rng, countryCode, false)
 			},
 		},
 		{
@@ -504,8 +529,8 @@ func (m *MMSITool) populateTypes() {
 				// Group coast station: 000xxxxxx (first three digits are 000)
 				return mmsi >= 100000 && mmsi <= 999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				return m.generateCoastStation(countryCode, true)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				return m.generateCoastStation(rng, countryCode, true)
 			},
 		},
 		{
@@ -515,8 +540,8 @@ func (m *MMSITool) populateTypes() {
 				// Craft associated: 98xxxxxxx
 				return mmsi >= 980000000 && mmsi <= 989999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				return m.generateCraftAssociated()
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				return m.generateCraftAssociated(rng)
 			},
 		},
 		{
@@ -526,8 +551,8 @@ func (m *MMSITool) populateTypes() {
 				// Navigational aid: 99xxxxxxx
 				return mmsi >= 990000000 && mmsi <= 999999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				return m.generateNavigationalAid()
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				return m.generateNavigationalAid(rng)
 			},
 		},
 		{
@@ -537,18 +562,18 @@ func (m *MMSITool) populateTypes() {
 				// Free-form: any other valid MMSI
 				return mmsi >= 100000000 && mmsi <= 999999999
 			},
-			Generate: func(countryCode string) (int, error) {
-				return m.generateRandomMMSI(countryCode)
+			Generate: func(rng *rand.Rand, countryCode string) (int, error) {
+				return m.generateRandomMMSI(rng, countryCode)
 			},
 		},
 	}
 }
 
 // generateSpecificType generates a specific type of MMSI using the types slice
-func (m *MMSITool) generateSpecificType(mmsiType, countryCode string) (int, error) {
+func (m *MMSITool) generateSpecificType(rng *rand.Rand, mmsiType, countryCode string) (int, error) {
 	for _, t := range m.types {
 		if t.Name == mmsiType {
-			return t.Generate(countryCode)
+			return t.Generate(rng, countryCode)
 		}
 	}
 	return 0, fmt.Errorf("unsupported MMSI type: %s", mmsiType)
@@ -564,7 +589,7 @@ func (m *MMSITool) getSupportedTypes() []string {
 }
 
 // generateShipStation generates a ship station MMSI
-func (m *MMSITool) generateShipStation(countryCode string, inmarsatBCM, inmarsatC bool) (int, error) {
+func (m *MMSITool) generateShipStation(rng *rand.Rand, countryCode string, inmarsatBCM, inmarsatC bool) (int, error) {
 	var allMIDs []int
 	if countryCode != "" {
 		allMIDs = m.getMIDs(countryCode)
@@ -576,23 +601,23 @@ func (m *MMSITool) generateShipStation(countryCode string, inmarsatBCM, inmarsat
 	}
 
 	// Select a random MID from all available ones
-	selectedMID := allMIDs[rand.Intn(len(allMIDs))]
+	selectedMID := allMIDs[rng.Intn(len(allMIDs))]
 
 	// Generate remaining digits
 	var remaining int
 	if inmarsatBCM || inmarsatC {
 		// Inmarsat B/C/M: ends with 000
-		remaining = rand.Intn(1000) * 1000 // 000, 1000, 2000, ..., 999000
+		remaining = rng.Intn(1000) * 1000 // 000, 1000, 2000, ..., 999000
 	} else {
 		// Regular ship
-		remaining = rand.Intn(1000000) // 0 to 999999
+		remaining = rng.Intn(1000000) // 0 to 999999
 	}
 
 	return selectedMID*1000000 + remaining, nil
 }
 
 // generateGroupShipStation generates a group ship station MMSI
-func (m *MMSITool) generateGroupShipStation(countryCode string) (int, error) {
+func (m *MMSITool) generateGroupShipStation(rng *rand.Rand, countryCode string) (int, error) {
 	var allMIDs []int
 	if countryCode != "" {
 		allMIDs = m.getMIDs(countryCode)
@@ -604,16 +629,16 @@ func (m *MMSITool) generateGroupShipStation(countryCode string) (int, error) {
 	}
 
 	// Select a random MID from all available ones
-	selectedMID := allMIDs[rand.Intn(len(allMIDs))]
+	selectedMID := allMIDs[rng.Intn(len(allMIDs))]
 
 	// Generate remaining digits (6 digits, but first digit must be 0 for group)
-	remaining := rand.Intn(100000) // 0 to 99999
+	remaining := rng.Intn(100000) // 0 to 99999
 
 	return selectedMID*1000000 + remaining, nil
 }
 
 // generateCoastStation generates a coast station MMSI
-func (m *MMSITool) generateCoastStation(countryCode string, group bool) (int, error) {
+func (m *MMSITool) generateCoastStation(rng *rand.Rand, countryCode string, group bool) (int, error) {
 	var allMIDs []int
 	if countryCode != "" {
 		allMIDs = m.getMIDs(countryCode)
@@ -625,33 +650,33 @@ func (m *MMSITool) generateCoastStation(countryCode string, group bool) (int, er
 	}
 
 	// Select a random MID from all available ones
-	selectedMID := allMIDs[rand.Intn(len(allMIDs))]
+	selectedMID := allMIDs[rng.Intn(len(allMIDs))]
 
 	// Generate remaining digits
 	var remaining int
 	if group {
 		// Group coast station: 000xxxxxx
-		remaining = rand.Intn(100000) // 0 to 99999
+		remaining = rng.Intn(100000) // 0 to 99999
 	} else {
 		// Regular coast station: 00xxxxxxx
-		remaining = rand.Intn(1000000) // 0 to 999999
+		remaining = rng.Intn(1000000) // 0 to 999999
 	}
 
 	return selectedMID*1000000 + remaining, nil
 }
 
 // generateCraftAssociated generates a craft associated MMSI
-func (m *MMSITool) generateCraftAssociated() (int, error) {
+func (m *MMSITool) generateCraftAssociated(rng *rand.Rand) (int, error) {
 	// Craft associated: 98xxxxxxx (fixed format, doesn't depend on country)
-	remaining := rand.Intn(10000000) // 0 to 9999999
+	remaining := rng.Intn(10000000) // 0 to 9999999
 
 	return 980000000 + remaining, nil
 }
 
 // generateNavigationalAid generates a navigational aid MMSI
-func (m *MMSITool) generateNavigationalAid() (int, error) {
+func (m *MMSITool) generateNavigationalAid(rng *rand.Rand) (int, error) {
 	// Navigational aid: 99xxxxxxx (fixed format, doesn't depend on country)
-	remaining := rand.Intn(10000000) // 0 to 9999999
+	remaining := rng.Intn(10000000) // 0 to 9999999
 
 	return 990000000 + remaining, nil
 }
@@ -685,7 +710,7 @@ func (m *MMSITool) ValidateParams(params map[string]interface{}) error {
 	if operation, ok := params["operation"]; ok {
 		if operationStr, ok := operation.(string); ok && operationStr == "generate" {
 			if count, ok := params["count"]; ok {
-				if countInt, ok := count.(int); ok {
+				if countInt, ok := IntParam(count); ok {
 					if countInt < 1 {
 						return fmt.Errorf("count must be at least 1")
 					}
@@ -701,7 +726,7 @@ func (m *MMSITool) ValidateParams(params map[string]interface{}) error {
 
 	// Validate input for validation
 	if operation, ok := params["operation"]; ok {
-		if operationStr, ok := operation.(string); ok && operationStr == "validate" {
+		if operationStr, ok := operation.(string); ok && operationStr == "validate" && !hasInputs(params) {
 			if input, ok := params["input"]; ok {
 				if _, ok := input.(string); !ok {
 					return fmt.Errorf("input must be a string")
@@ -711,6 +736,9 @@ func (m *MMSITool) ValidateParams(params map[string]interface{}) error {
 			}
 		}
 	}
+	if err := validateBulkParams(params); err != nil {
+		return err
+	}
 
 	// Validate type parameter
 	if mmsiType, ok := params["type"]; ok {
@@ -745,7 +773,7 @@ func (m *MMSITool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	return nil
+	return validateSeed(params)
 }
 
 // GetInputSchema returns the JSON schema for the tool's input parameters
@@ -761,9 +789,16 @@ func (m *MMSITool) GetInputSchema() map[string]interface{} {
 		{
 			Name:        "input",
 			Type:        "string",
-			Description: "MMSI number to validate (required for validation operation)",
+			Description: "MMSI number to validate (required for validation operation unless inputs is given)",
 			Required:    false,
 		},
+		{
+			Name:        "inputs",
+			Type:        "array",
+			Description: "MMSI numbers to validate in bulk (max 10000); the result lists a validation result per value, numbered by line, and a summary",
+			Required:    false,
+			Items:       "string",
+		},
 		{
 			Name:        "count",
 			Type:        "integer",
@@ -783,6 +818,8 @@ func (m *MMSITool) GetInputSchema() map[string]interface{} {
 			Description: "Country code for MMSI generation (e.g., 'US', 'GB', 'DE')",
 			Required:    false,
 		},
+		seedParameter,
+		secureParameter,
 	})
 }
 
@@ -791,9 +828,13 @@ func (m *MMSITool) GetOutputSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"result": map[string]interface{}{
-				"description": "Generated MMSI number(s) or validation result",
-			},
+			"result": withBulkResults(map[string]interface{}{
+				"type":        []string{"string", "array", "object"},
+				"description": "Generated MMSI number(s) or validation result (results and summary when validating inputs)",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			}),
 		},
 	}
 }
@@ -814,6 +855,22 @@ func (m *MMSITool) GetResources() []Resource {
 	}
 }
 
+// CompleteParam returns the candidate values of a parameter for argument completion
+func (m *MMSITool) CompleteParam(name string) []Completion {
+	var completions []Completion
+	switch name {
+	case "country-code":
+		for _, country := range m.countries {
+			completions = append(completions, Completion{Value: country.Code, Label: country.Name})
+		}
+	case "type":
+		for _, mmsiType := range m.types {
+			completions = append(completions, Completion{Value: mmsiType.Name, Label: mmsiType.FullName})
+		}
+	}
+	return completions
+}
+
 // ReadResource reads a specific resource by URI
 func (m *MMSITool) ReadResource(uri string) (string, error) {
 	switch uri {
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"testing"
@@ -209,6 +210,14 @@ func TestMMSIToolGenerateMMSI(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "generate_multiple_json_count",
+			params: map[string]interface{}{
+				"operation": "generate",
+				"count":     float64(3),
+			},
+			expectError: false,
+		},
 		{
 			name: "generate_without_country",
 			params: map[string]interface{}{
@@ -239,7 +248,7 @@ func TestMMSIToolGenerateMMSI(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := tool.generateMMSI(tt.params)
+			result, err := tool.generateMMSI(context.Background(), tt.params)
 
 			if tt.expectError {
 				if err == nil {
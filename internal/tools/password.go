@@ -0,0 +1,288 @@
+package tools
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Character classes of generated passwords and strings
+const (
+	classLower   = "lower"
+	classUpper   = "upper"
+	classDigits  = "digits"
+	classSymbols = "symbols"
+)
+
+// characterClasses lists the character classes in the order they are checked
+var characterClasses = []string{classLower, classUpper, classDigits, classSymbols}
+
+// classCharacters holds the characters of each class. The symbols leave out
+// quotes, backslashes and backticks so generated passwords can be pasted
+// into shells and config files without escaping.
+var classCharacters = map[string]string{
+	classLower:   "abcdefghijklmnopqrstuvwxyz",
+	classUpper:   "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	classDigits:  "0123456789",
+	classSymbols: "!#$%&()*+,-./:;<=>?@[]^_{|}~",
+}
+
+// ambiguousCharacters are easily confused when read aloud or in some fonts
+const ambiguousCharacters = "0Oo1Il|"
+
+// Limits and defaults for generated passwords, strings and passphrases
+const (
+	defaultPasswordLength  = 16
+	maxPasswordLength      = 1024
+	defaultPassphraseWords = 6
+	maxPassphraseWords     = 64
+	defaultWordSeparator   = "-"
+)
+
+// effLargeWordlist is EFF's large wordlist for passphrases, one "rolls<TAB>word"
+// line per word, where rolls are the five dice that select the word. It is
+// published by the Electronic Frontier Foundation under CC BY 3.0 US:
+// https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases
+//
+//go:embed wordlists/eff_large_wordlist.txt
+var effLargeWordlist string
+
+// dicewareWord is an entry of the passphrase wordlist
+type dicewareWord struct {
+	Rolls string
+	Word  string
+}
+
+// dicewareWords returns the parsed passphrase wordlist
+var dicewareWords = sync.OnceValue(func() []dicewareWord {
+	var words []dicewareWord
+	scanner := bufio.NewScanner(strings.NewReader(effLargeWordlist))
+	for scanner.Scan() {
+		rolls, word, ok := strings.Cut(scanner.Text(), "\t")
+		if ok {
+			words = append(words, dicewareWord{Rolls: rolls, Word: word})
+		}
+	}
+	return words
+})
+
+// passwordPolicy describes the characters a password or random string is drawn from
+type passwordPolicy struct {
+	// Length is the number of characters
+	Length int
+	// Alphabet holds the characters every position may be drawn from
+	Alphabet []rune
+	// Classes holds the characters of each class that occur in the alphabet
+	Classes map[string][]rune
+	// Minimums holds the minimum number of characters of each class
+	Minimums map[string]int
+}
+
+// parsePasswordPolicy builds the policy of a password or random string from
+// the parameters. Passwords contain at least one character of each selected
+// class unless minimums are given; random strings default to letters and
+// digits without minimums.
+func parsePasswordPolicy(typeParam string, params map[string]interface{}) (*passwordPolicy, error) {
+	policy := &passwordPolicy{
+		Length:   defaultPasswordLength,
+		Classes:  make(map[string][]rune),
+		Minimums: make(map[string]int),
+	}
+	if length, ok := params["length"]; ok {
+		lengthInt, ok := IntParam(length)
+		if !ok || lengthInt < 1 || lengthInt > maxPasswordLength {
+			return nil, fmt.Errorf("length must be an integer between 1 and %d", maxPasswordLength)
+		}
+		policy.Length = lengthInt
+	}
+
+	// The selected classes make up the alphabet unless a custom one is given
+	classes := []string{classLower, classUpper, classDigits}
+	if typeParam == "password" {
+		classes = characterClasses
+	}
+	if value, ok := params["classes"]; ok {
+		names, err := stringList(value)
+		if err != nil {
+			return nil, fmt.Errorf("classes %v", err)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("classes must not be empty")
+		}
+		for _, name := range names {
+			if _, ok := classCharacters[name]; !ok {
+				return nil, fmt.Errorf("invalid class: %s, must be one of: %s", name, strings.Join(characterClasses, ", "))
+			}
+		}
+		classes = names
+	}
+
+	alphabet, custom := params["alphabet"].(string)
+	if !custom {
+		var sb strings.Builder
+		for _, class := range characterClasses {
+			if contains(classes, class) {
+				sb.WriteString(classCharacters[class])
+			}
+		}
+		alphabet = sb.String()
+	}
+
+	excludeAmbiguous, _ := params["exclude-ambiguous"].(bool)
+	seen := make(map[rune]bool)
+	for _, r := range alphabet {
+		if seen[r] || (excludeAmbiguous && strings.ContainsRune(ambiguousCharacters, r)) || unicode.IsControl(r) {
+			continue
+		}
+		seen[r] = true
+		policy.Alphabet = append(policy.Alphabet, r)
+		for _, class := range characterClasses {
+			if strings.ContainsRune(classCharacters[class], r) {
+				policy.Classes[class] = append(policy.Classes[class], r)
+			}
+		}
+	}
+	if len(policy.Alphabet) < 2 {
+		return nil, fmt.Errorf("alphabet must contain at least 2 distinct characters")
+	}
+
+	// Passwords with the built-in classes need one character of each by default
+	if typeParam == "password" && !custom {
+		for _, class := range classes {
+			policy.Minimums[class] = 1
+		}
+	}
+	total := 0
+	for _, class := range characterClasses {
+		name := "min-" + class
+		if value, ok := params[name]; ok {
+			minimum, ok := IntParam(value)
+			if !ok || minimum < 0 {
+				return nil, fmt.Errorf("%s must be a non-negative integer", name)
+			}
+			policy.Minimums[class] = minimum
+		}
+		if policy.Minimums[class] > 0 && len(policy.Classes[class]) == 0 {
+			return nil, fmt.Errorf("%s requires %s characters in the alphabet", name, class)
+		}
+		total += policy.Minimums[class]
+	}
+	if total > policy.Length {
+		return nil, fmt.Errorf("the minimum counts per class add up to %d, more than the length of %d", total, policy.Length)
+	}
+
+	return policy, nil
+}
+
+// generate draws a password that meets the policy: the minimum number of
+// characters from each class, the rest from the whole alphabet, in random order
+func (p *passwordPolicy) generate(rng *rand.Rand) string {
+	chars := make([]rune, 0, p.Length)
+	for _, class := range characterClasses {
+		for range p.Minimums[class] {
+			chars = append(chars, p.Classes[class][rng.Intn(len(p.Classes[class]))])
+		}
+	}
+	for len(chars) < p.Length {
+		chars = append(chars, p.Alphabet[rng.Intn(len(p.Alphabet))])
+	}
+	rng.Shuffle(len(chars), func(i, j int) {
+		chars[i], chars[j] = chars[j], chars[i]
+	})
+	return string(chars)
+}
+
+// entropyBits estimates the entropy of a generated password as the sum of the
+// entropy of its characters: log2 of the class size for the characters drawn
+// to meet a minimum, and log2 of the alphabet size for the others. The random
+// order adds entropy that the estimate leaves out, so it errs on the low side.
+func (p *passwordPolicy) entropyBits() float64 {
+	bits := 0.0
+	drawn := 0
+	for _, class := range characterClasses {
+		if p.Minimums[class] > 0 {
+			bits += float64(p.Minimums[class]) * math.Log2(float64(len(p.Classes[class])))
+			drawn += p.Minimums[class]
+		}
+	}
+	bits += float64(p.Length-drawn) * math.Log2(float64(len(p.Alphabet)))
+	return roundBits(bits)
+}
+
+// passphrasePolicy describes a Diceware-style passphrase
+type passphrasePolicy struct {
+	Words      int
+	Separator  string
+	Capitalize bool
+}
+
+// parsePassphrasePolicy builds the policy of a passphrase from the parameters
+func parsePassphrasePolicy(params map[string]interface{}) (*passphrasePolicy, error) {
+	policy := &passphrasePolicy{
+		Words:     defaultPassphraseWords,
+		Separator: defaultWordSeparator,
+	}
+	if words, ok := params["words"]; ok {
+		wordsInt, ok := IntParam(words)
+		if !ok || wordsInt < 1 || wordsInt > maxPassphraseWords {
+			return nil, fmt.Errorf("words must be an integer between 1 and %d", maxPassphraseWords)
+		}
+		policy.Words = wordsInt
+	}
+	if separator, ok := params["separator"].(string); ok {
+		policy.Separator = separator
+	}
+	policy.Capitalize, _ = params["capitalize"].(bool)
+	return policy, nil
+}
+
+// generate draws the words of a passphrase. Each word is as likely as rolling
+// its five dice, so the passphrase can be checked against a printed wordlist.
+func (p *passphrasePolicy) generate(rng *rand.Rand) map[string]interface{} {
+	wordlist := dicewareWords()
+	words := make([]string, p.Words)
+	rolls := make([]string, p.Words)
+	for i := range words {
+		entry := wordlist[rng.Intn(len(wordlist))]
+		words[i], rolls[i] = entry.Word, entry.Rolls
+		if p.Capitalize {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return map[string]interface{}{
+		"value":        strings.Join(words, p.Separator),
+		"words":        words,
+		"rolls":        rolls,
+		"entropy_bits": roundBits(float64(p.Words) * math.Log2(float64(len(wordlist)))),
+	}
+}
+
+// roundBits rounds an entropy estimate to two decimal places
+func roundBits(bits float64) float64 {
+	return math.Round(bits*100) / 100
+}
+
+// stringList converts an array parameter to strings. JSON arrays arrive as
+// []interface{}, while CLI callers pass []string.
+func stringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be an array of strings")
+			}
+			list[i] = s
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("must be an array of strings")
+}
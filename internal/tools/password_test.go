@@ -0,0 +1,264 @@
+package tools
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestPasswordGeneration(t *testing.T) {
+	tool := NewRandomTool()
+
+	tests := []struct {
+		name     string
+		params   map[string]interface{}
+		length   int
+		alphabet string
+		minimums map[string]int
+		entropy  float64
+	}{
+		{
+			name:     "password defaults",
+			params:   map[string]interface{}{"type": "password"},
+			length:   16,
+			alphabet: classCharacters[classLower] + classCharacters[classUpper] + classCharacters[classDigits] + classCharacters[classSymbols],
+			minimums: map[string]int{classLower: 1, classUpper: 1, classDigits: 1, classSymbols: 1},
+			entropy:  95.43,
+		},
+		{
+			name:     "string defaults to letters and digits",
+			params:   map[string]interface{}{"type": "string", "length": 10.0},
+			length:   10,
+			alphabet: classCharacters[classLower] + classCharacters[classUpper] + classCharacters[classDigits],
+			entropy:  59.54,
+		},
+		{
+			name:     "selected classes",
+			params:   map[string]interface{}{"type": "password", "length": 8.0, "classes": []interface{}{"lower", "digits"}},
+			length:   8,
+			alphabet: classCharacters[classLower] + classCharacters[classDigits],
+			minimums: map[string]int{classLower: 1, classDigits: 1},
+			entropy:  39.04,
+		},
+		{
+			name:     "minimum counts",
+			params:   map[string]interface{}{"type": "password", "length": 12.0, "min-digits": 4.0, "min-symbols": 3.0},
+			length:   12,
+			alphabet: classCharacters[classLower] + classCharacters[classUpper] + classCharacters[classDigits] + classCharacters[classSymbols],
+			minimums: map[string]int{classLower: 1, classUpper: 1, classDigits: 4, classSymbols: 3},
+		},
+		{
+			name:     "ambiguous characters excluded",
+			params:   map[string]interface{}{"type": "string", "length": 64.0, "exclude-ambiguous": true},
+			length:   64,
+			alphabet: "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789",
+		},
+		{
+			name:     "custom alphabet",
+			params:   map[string]interface{}{"type": "string", "length": 8.0, "alphabet": "0123456789abcdef"},
+			length:   8,
+			alphabet: "0123456789abcdef",
+			entropy:  32,
+		},
+		{
+			name:     "custom alphabet with minimum",
+			params:   map[string]interface{}{"type": "password", "length": 6.0, "alphabet": "abcdef0123", "min-digits": 2.0},
+			length:   6,
+			alphabet: "abcdef0123",
+			minimums: map[string]int{classDigits: 2},
+		},
+		{
+			name:     "unicode alphabet",
+			params:   map[string]interface{}{"type": "string", "length": 5.0, "alphabet": "äöüß"},
+			length:   5,
+			alphabet: "äöüß",
+			entropy:  10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tool.ValidateParams(tt.params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			params := map[string]interface{}{"count": 20.0}
+			for k, v := range tt.params {
+				params[k] = v
+			}
+			result, err := tool.Execute(params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, item := range result.([]map[string]interface{}) {
+				value := item["value"].(string)
+				if n := len([]rune(value)); n != tt.length || item["length"] != tt.length {
+					t.Errorf("Expected %d characters, got %d in %q", tt.length, n, value)
+				}
+				for _, r := range value {
+					if !strings.ContainsRune(tt.alphabet, r) {
+						t.Errorf("Unexpected character %q in %q", r, value)
+					}
+				}
+				for class, minimum := range tt.minimums {
+					count := 0
+					for _, r := range value {
+						if strings.ContainsRune(classCharacters[class], r) {
+							count++
+						}
+					}
+					if count < minimum {
+						t.Errorf("Expected at least %d %s characters in %q, got %d", minimum, class, value, count)
+					}
+				}
+				if tt.entropy != 0 && item["entropy_bits"] != tt.entropy {
+					t.Errorf("Expected entropy %v, got %v", tt.entropy, item["entropy_bits"])
+				}
+			}
+		})
+	}
+}
+
+func TestPasswordValidation(t *testing.T) {
+	tool := NewRandomTool()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"length too small", map[string]interface{}{"type": "password", "length": 0.0}, "length must be an integer between 1 and 1024"},
+		{"length too large", map[string]interface{}{"type": "string", "length": 1025.0}, "length must be an integer between 1 and 1024"},
+		{"unknown class", map[string]interface{}{"type": "password", "classes": []interface{}{"emoji"}}, "invalid class: emoji"},
+		{"empty classes", map[string]interface{}{"type": "password", "classes": []interface{}{}}, "classes must not be empty"},
+		{"classes not strings", map[string]interface{}{"type": "password", "classes": "lower"}, "classes must be an array of strings"},
+		{"negative minimum", map[string]interface{}{"type": "password", "min-digits": -1.0}, "min-digits must be a non-negative integer"},
+		{"minimums exceed length", map[string]interface{}{"type": "password", "length": 4.0, "min-digits": 3.0}, "add up to 6, more than the length of 4"},
+		{"default minimums exceed length", map[string]interface{}{"type": "password", "length": 3.0}, "add up to 4, more than the length of 3"},
+		{"minimum of missing class", map[string]interface{}{"type": "string", "classes": []interface{}{"lower"}, "min-digits": 1.0}, "min-digits requires digits characters"},
+		{"alphabet too small", map[string]interface{}{"type": "string", "alphabet": "aaa"}, "at least 2 distinct characters"},
+		{"alphabet emptied by ambiguous", map[string]interface{}{"type": "string", "alphabet": "0O1", "exclude-ambiguous": true}, "at least 2 distinct characters"},
+		{"words too small", map[string]interface{}{"type": "passphrase", "words": 0.0}, "words must be an integer between 1 and 64"},
+		{"words too large", map[string]interface{}{"type": "passphrase", "words": 65.0}, "words must be an integer between 1 and 64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tool.ValidateParams(tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateParams() error = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := tool.Execute(tt.params); err == nil {
+				t.Error("Execute() expected an error")
+			}
+		})
+	}
+}
+
+func TestPassphraseGeneration(t *testing.T) {
+	tool := NewRandomTool()
+
+	if n := len(dicewareWords()); n != 7776 {
+		t.Fatalf("Expected 7776 words in the wordlist, got %d", n)
+	}
+
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		words     int
+		separator string
+		capital   bool
+	}{
+		{"defaults", map[string]interface{}{"type": "passphrase"}, 6, "-", false},
+		{"word count", map[string]interface{}{"type": "passphrase", "words": 4.0}, 4, "-", false},
+		{"separator", map[string]interface{}{"type": "passphrase", "separator": " "}, 6, " ", false},
+		{"no separator", map[string]interface{}{"type": "passphrase", "separator": "", "capitalize": true}, 6, "", true},
+		{"capitalized", map[string]interface{}{"type": "passphrase", "words": 5.0, "capitalize": true}, 5, "-", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tool.ValidateParams(tt.params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			item := result.(map[string]interface{})
+			words := item["words"].([]string)
+			rolls := item["rolls"].([]string)
+			if len(words) != tt.words || len(rolls) != tt.words {
+				t.Fatalf("Expected %d words and rolls, got %v and %v", tt.words, words, rolls)
+			}
+			if value := strings.Join(words, tt.separator); item["value"] != value {
+				t.Errorf("Expected value %q, got %q", value, item["value"])
+			}
+			for i, word := range words {
+				if unicode.IsUpper([]rune(word)[0]) != tt.capital {
+					t.Errorf("Unexpected capitalization of %q", word)
+				}
+				entry := dicewareWords()[wordIndex(t, rolls[i])]
+				if !strings.EqualFold(entry.Word, word) {
+					t.Errorf("Expected rolls %s to select %q, got %q", rolls[i], entry.Word, word)
+				}
+			}
+			entropy := math.Round(float64(tt.words)*math.Log2(7776)*100) / 100
+			if item["entropy_bits"] != entropy {
+				t.Errorf("Expected entropy %v, got %v", entropy, item["entropy_bits"])
+			}
+		})
+	}
+}
+
+// wordIndex returns the position in the wordlist of the word selected by five dice rolls
+func wordIndex(t *testing.T, rolls string) int {
+	t.Helper()
+	if len(rolls) != 5 {
+		t.Fatalf("Expected five rolls, got %q", rolls)
+	}
+	index := 0
+	for _, r := range rolls {
+		if r < '1' || r > '6' {
+			t.Fatalf("Invalid roll %q in %q", r, rolls)
+		}
+		index = index*6 + int(r-'1')
+	}
+	return index
+}
+
+func TestSecretsAreSecureByDefault(t *testing.T) {
+	tool := NewRandomTool()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		params map[string]interface{}
+		want   string
+	}{
+		{"password", context.Background(), map[string]interface{}{"type": "password"}, SourceCrypto},
+		{"passphrase", context.Background(), map[string]interface{}{"type": "passphrase"}, SourceCrypto},
+		{"bytes", context.Background(), map[string]interface{}{"type": "bytes"}, SourceCrypto},
+		{"string", context.Background(), map[string]interface{}{"type": "string"}, SourceMath},
+		{"integer", context.Background(), map[string]interface{}{"type": "integer"}, SourceMath},
+		{"seeded password", context.Background(), map[string]interface{}{"type": "password", "seed": 42.0}, seededSource(42)},
+		{"password opting out", context.Background(), map[string]interface{}{"type": "password", "secure": false}, SourceMath},
+		{"password in seeded context", WithSeed(context.Background(), 7), map[string]interface{}{"type": "password"}, seededSource(7)},
+		{"passphrase in seeded context", WithSeed(context.Background(), 7), map[string]interface{}{"type": "passphrase"}, seededSource(7)},
+		{"seeded bytes", context.Background(), map[string]interface{}{"type": "bytes", "seed": 42.0}, seededSource(42)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var source string
+			ctx := WithSourceReport(tt.ctx, func(s string) { source = s })
+			if _, err := tool.ExecuteContext(ctx, tt.params); err != nil {
+				t.Fatalf("ExecuteContext() error = %v", err)
+			}
+			if source != tt.want {
+				t.Errorf("Expected source %q, got %q", tt.want, source)
+			}
+		})
+	}
+}
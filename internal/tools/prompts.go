@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Prompt is a reusable, parameterized prompt template offered to MCP clients
+type Prompt struct {
+	// Name identifies the prompt, e.g. "convert-meeting-time"
+	Name string
+	// Title is a short human-readable name
+	Title string
+	// Description explains what the prompt does
+	Description string
+	// Arguments are the values the client fills into the template
+	Arguments []PromptArgument
+	// Tools lists the tools the prompt instructs the agent to call. The prompt
+	// is only offered while all of them are enabled.
+	Tools []string
+}
+
+// PromptArgument describes an argument of a prompt
+type PromptArgument struct {
+	Name        string
+	Description string
+	Required    bool
+	// Tool and Param name the tool parameter whose values the argument takes,
+	// so clients can complete the argument from the tool's candidates
+	Tool  string
+	Param string
+	// List marks arguments that take a comma-separated list of such values
+	List bool
+}
+
+// PromptProvider is implemented by tools and other components that contribute
+// prompts, analogous to how tools contribute resources
+type PromptProvider interface {
+	// GetPrompts returns the list of prompts this provider offers
+	GetPrompts() []Prompt
+
+	// GetPrompt renders the named prompt with the given arguments
+	GetPrompt(name string, arguments map[string]string) (string, error)
+}
+
+// GetPrompts returns the prompts contributed by a tool, or nil when it contributes none
+func GetPrompts(tool Tool) []Prompt {
+	if provider, ok := tool.(PromptProvider); ok {
+		return provider.GetPrompts()
+	}
+	return nil
+}
+
+// renderPrompt checks the arguments against the prompt's definition and executes
+// the template. Every declared argument is available to the template by name,
+// with omitted optional arguments set to the empty string.
+func renderPrompt(prompt Prompt, text string, arguments map[string]string) (string, error) {
+	data := make(map[string]string, len(prompt.Arguments))
+	var names []string
+	for _, argument := range prompt.Arguments {
+		value := strings.TrimSpace(arguments[argument.Name])
+		if argument.Required && value == "" {
+			return "", fmt.Errorf("missing required argument: %s", argument.Name)
+		}
+		data[argument.Name] = value
+		names = append(names, argument.Name)
+	}
+
+	for name := range arguments {
+		if !slices.Contains(names, name) {
+			return "", fmt.Errorf("unknown argument: %s. Available arguments: %s", name, strings.Join(names, ", "))
+		}
+	}
+
+	tmpl, err := template.New(prompt.Name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template for prompt %s: %w", prompt.Name, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %s: %w", prompt.Name, err)
+	}
+	return sb.String(), nil
+}
+
+// WorkflowPrompts provides prompts for workflows that combine several tools
+type WorkflowPrompts struct{}
+
+// NewWorkflowPrompts creates a new provider of the multi-tool workflow prompts
+func NewWorkflowPrompts() *WorkflowPrompts {
+	return &WorkflowPrompts{}
+}
+
+// workflowTemplates maps the workflow prompt names to their templates
+var workflowTemplates = map[string]string{
+	"test-customer-record": `Generate {{if .count}}{{.count}}{{else}}one{{end}} realistic test customer record(s) for a test environment.
+
+Use the mcpipboy tools for all identifiers instead of inventing them:
+1. Call the "uuid" tool (version "v4") to get a unique customer ID for each record.
+2. Call the "iban" tool with operation "generate"{{if .country}} and country-code "{{.country}}"{{end}} to get a bank account number for each record.
+3. Call the "creditcard" tool with operation "generate"{{if .card_type}} and card-type "{{.card_type}}"{{end}} to get a payment card number for each record.
+When more than one record is needed, use the tools' count parameter instead of calling them repeatedly.
+
+Then make up a plausible full name, email address and postal address{{if .country}} in the country with ISO code {{.country}}{{end}} for each record, and return the records as a JSON array of objects with the fields "id", "name", "email", "address", "iban" and "credit_card".
+
+All generated numbers are synthetic and must only be used for testing.`,
+
+	"audit-vessel-identifiers": `Audit the following vessel identifiers:
+
+{{.identifiers}}
+
+For each identifier:
+1. Decide whether it is an IMO number (7 digits, optionally prefixed with "IMO") or an MMSI (9 digits).
+2. Validate IMO numbers with the "imo" tool and MMSIs with the "mmsi" tool, using operation "validate".
+3. For valid MMSIs, report the country and station type returned by the tool. For invalid identifiers, report why they are invalid.
+
+Present the results as a table with the columns identifier, kind, valid and details, followed by a summary of how many identifiers were valid and invalid.`,
+}
+
+// GetPrompts returns the list of workflow prompts
+func (w *WorkflowPrompts) GetPrompts() []Prompt {
+	return []Prompt{
+		{
+			Name:        "test-customer-record",
+			Title:       "Test Customer Record",
+			Description: "Generate realistic test customer records with a customer ID, IBAN and credit card number",
+			Arguments: []PromptArgument{
+				{Name: "count", Description: "Number of records to generate (default: 1)"},
+				{Name: "country", Description: "ISO 3166-1 alpha-2 country code for the IBAN and address, e.g. DE", Tool: "iban", Param: "country-code"},
+				{Name: "card_type", Description: "Credit card type: visa, mastercard, amex, discover, diners or jcb", Tool: "creditcard", Param: "card-type"},
+			},
+			Tools: []string{"uuid", "iban", "creditcard"},
+		},
+		{
+			Name:        "audit-vessel-identifiers",
+			Title:       "Audit Vessel Identifiers",
+			Description: "Validate a list of IMO numbers and MMSIs and report the results",
+			Arguments: []PromptArgument{
+				{Name: "identifiers", Description: "IMO numbers and/or MMSIs, separated by commas or newlines", Required: true},
+			},
+			Tools: []string{"imo", "mmsi"},
+		},
+	}
+}
+
+// GetPrompt renders the named workflow prompt with the given arguments
+func (w *WorkflowPrompts) GetPrompt(name string, arguments map[string]string) (string, error) {
+	for _, prompt := range w.GetPrompts() {
+		if prompt.Name == name {
+			return renderPrompt(prompt, workflowTemplates[name], arguments)
+		}
+	}
+	return "", fmt.Errorf("unknown prompt: %s", name)
+}
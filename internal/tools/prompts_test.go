@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptProviders(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  PromptProvider
+		prompt    string
+		arguments map[string]string
+		contains  []string
+		excludes  []string
+		wantError string
+	}{
+		{
+			name:      "meeting time",
+			provider:  NewTimeTool(),
+			prompt:    "convert-meeting-time",
+			arguments: map[string]string{"time": "next Monday 9am", "from_timezone": "Europe/Oslo", "to_timezones": "America/New_York, Asia/Tokyo"},
+			contains:  []string{"next Monday 9am", "Europe/Oslo", "America/New_York, Asia/Tokyo"},
+		},
+		{
+			name:      "meeting time missing argument",
+			provider:  NewTimeTool(),
+			prompt:    "convert-meeting-time",
+			arguments: map[string]string{"time": "now", "to_timezones": "UTC"},
+			wantError: "missing required argument: from_timezone",
+		},
+		{
+			name:      "blank required argument",
+			provider:  NewWorkflowPrompts(),
+			prompt:    "audit-vessel-identifiers",
+			arguments: map[string]string{"identifiers": "  "},
+			wantError: "missing required argument: identifiers",
+		},
+		{
+			name:      "vessel audit",
+			provider:  NewWorkflowPrompts(),
+			prompt:    "audit-vessel-identifiers",
+			arguments: map[string]string{"identifiers": "IMO 9074729, 257123450"},
+			contains:  []string{"IMO 9074729, 257123450", `"imo" tool`, `"mmsi" tool`},
+		},
+		{
+			name:      "customer record defaults",
+			provider:  NewWorkflowPrompts(),
+			prompt:    "test-customer-record",
+			arguments: map[string]string{},
+			contains:  []string{"Generate one realistic"},
+			excludes:  []string{"country-code", "card-type"},
+		},
+		{
+			name:      "customer record with options",
+			provider:  NewWorkflowPrompts(),
+			prompt:    "test-customer-record",
+			arguments: map[string]string{"count": "5", "country": "NO", "card_type": "visa"},
+			contains:  []string{"Generate 5 realistic", `country-code "NO"`, `card-type "visa"`},
+		},
+		{
+			name:      "unknown argument",
+			provider:  NewWorkflowPrompts(),
+			prompt:    "test-customer-record",
+			arguments: map[string]string{"currency": "NOK"},
+			wantError: "unknown argument: currency. Available arguments: count, country, card_type",
+		},
+		{
+			name:      "unknown prompt",
+			provider:  NewTimeTool(),
+			prompt:    "missing",
+			wantError: "unknown prompt: missing",
+		},
+		{
+			name:      "forwarded by defaults wrapper",
+			provider:  WithDefaults(NewTimeTool(), map[string]interface{}{"timezone": "utc"}).(PromptProvider),
+			prompt:    "convert-meeting-time",
+			arguments: map[string]string{"time": "now", "from_timezone": "UTC", "to_timezones": "Europe/Paris"},
+			contains:  []string{"Europe/Paris"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.provider.GetPrompt(tt.prompt, tt.arguments)
+			if tt.wantError != "" {
+				if err == nil || err.Error() != tt.wantError {
+					t.Fatalf("Expected error %q, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPrompt() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(text, want) {
+					t.Errorf("Expected prompt to contain %q, got:\n%s", want, text)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(text, unwanted) {
+					t.Errorf("Expected prompt not to contain %q, got:\n%s", unwanted, text)
+				}
+			}
+		})
+	}
+}
+
+func TestPromptDefinitions(t *testing.T) {
+	registry := NewToolRegistry()
+	for _, tool := range []Tool{NewTimeTool(), NewUUIDTool(), NewIBANTool(), NewCreditCardTool(), NewIMOTool(), NewMMSITool()} {
+		registry.RegisterTool(tool)
+	}
+
+	prompts := append(GetPrompts(NewTimeTool()), NewWorkflowPrompts().GetPrompts()...)
+	if len(prompts) != 3 {
+		t.Fatalf("Expected 3 prompts, got %d", len(prompts))
+	}
+	if GetPrompts(NewEchoTool()) != nil {
+		t.Error("Expected no prompts for a tool that does not provide any")
+	}
+
+	for _, prompt := range prompts {
+		t.Run(prompt.Name, func(t *testing.T) {
+			if prompt.Title == "" || prompt.Description == "" {
+				t.Error("Expected a title and description")
+			}
+			if len(prompt.Tools) == 0 {
+				t.Error("Expected the prompt to name the tools it uses")
+			}
+			for _, name := range prompt.Tools {
+				if _, exists := registry.GetTool(name); !exists {
+					t.Errorf("Prompt uses unknown tool %s", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Limits and defaults of random bytes
+const (
+	defaultRandomBytes = 32
+	maxRandomBytes     = 1024
+)
+
+// base58Alphabet is the Bitcoin alphabet, which leaves out 0, O, I and l
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// crockfordAlphabet is Crockford's base32 alphabet, which leaves out I, L, O and U
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// byteEncoding turns random bytes into text
+type byteEncoding struct {
+	Name        string
+	Description string
+	Encode      func(data []byte) string
+	// EncodedLen returns the length of n encoded bytes, or is nil when the
+	// length depends on the bytes themselves
+	EncodedLen func(n int) int
+}
+
+// crockfordEncoding is the unpadded Crockford base32 encoding
+var crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+// byteEncodings lists the encodings of random bytes, the default first
+var byteEncodings = []byteEncoding{
+	{"hex", "Lowercase hexadecimal, 2 characters per byte", hex.EncodeToString, hex.EncodedLen},
+	{"base64", "Standard base64 with = padding", base64.StdEncoding.EncodeToString, base64.StdEncoding.EncodedLen},
+	{"base64url", "URL and filename safe base64 without padding, common for tokens", base64.RawURLEncoding.EncodeToString, base64.RawURLEncoding.EncodedLen},
+	{"base32", "Standard base32 with = padding", base32.StdEncoding.EncodeToString, base32.StdEncoding.EncodedLen},
+	{"base58", "Bitcoin base58, without the look-alike characters 0, O, I and l; its length varies with the bytes", base58Encode, nil},
+	{"crockford", "Crockford base32 without padding, case-insensitive and without I, L, O and U", crockfordEncoding.EncodeToString, crockfordEncoding.EncodedLen},
+}
+
+// byteEncodingNames returns the names of the encodings of random bytes
+func byteEncodingNames() []string {
+	names := make([]string, len(byteEncodings))
+	for i, encoding := range byteEncodings {
+		names[i] = encoding.Name
+	}
+	return names
+}
+
+// byteEncodingDescriptions returns the names and descriptions of the encodings
+// of random bytes for the types resource
+func byteEncodingDescriptions() []map[string]string {
+	descriptions := make([]map[string]string, len(byteEncodings))
+	for i, encoding := range byteEncodings {
+		descriptions[i] = map[string]string{"name": encoding.Name, "description": encoding.Description}
+	}
+	return descriptions
+}
+
+// bytesPolicy describes random bytes and how they are encoded
+type bytesPolicy struct {
+	Bytes    int
+	Encoding byteEncoding
+	Prefix   string
+}
+
+// parseBytesPolicy builds the policy of random bytes from the parameters. The
+// size is given in bytes, or as the length of the encoded text, which a whole
+// number of bytes must encode to.
+func parseBytesPolicy(params map[string]interface{}) (*bytesPolicy, error) {
+	policy := &bytesPolicy{Bytes: defaultRandomBytes, Encoding: byteEncodings[0]}
+	if value, ok := params["encoding"]; ok {
+		name, _ := value.(string)
+		found := false
+		for _, encoding := range byteEncodings {
+			if encoding.Name == strings.ToLower(name) {
+				policy.Encoding, found = encoding, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid encoding: %v, must be one of: %s", value, strings.Join(byteEncodingNames(), ", "))
+		}
+	}
+	if value, ok := params["prefix"]; ok {
+		prefix, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("prefix parameter must be a string")
+		}
+		policy.Prefix = prefix
+	}
+
+	bytesValue, hasBytes := params["bytes"]
+	lengthValue, hasLength := params["length"]
+	switch {
+	case hasBytes && hasLength:
+		return nil, fmt.Errorf("bytes and length cannot be used together")
+	case hasBytes:
+		n, ok := IntParam(bytesValue)
+		if !ok || n < 1 || n > maxRandomBytes {
+			return nil, fmt.Errorf("bytes must be an integer between 1 and %d", maxRandomBytes)
+		}
+		policy.Bytes = n
+	case hasLength:
+		length, ok := IntParam(lengthValue)
+		if !ok || length < 1 {
+			return nil, fmt.Errorf("length must be a positive integer")
+		}
+		n, err := policy.Encoding.bytesForLength(length)
+		if err != nil {
+			return nil, err
+		}
+		policy.Bytes = n
+	}
+	return policy, nil
+}
+
+// bytesForLength returns the number of bytes that encode to length characters.
+// With padding several byte counts share a length, so it returns the largest.
+func (e byteEncoding) bytesForLength(length int) (int, error) {
+	if e.EncodedLen == nil {
+		return 0, fmt.Errorf("length cannot be used with %s encoding, whose length varies with the bytes; use bytes instead", e.Name)
+	}
+	if longest := e.EncodedLen(maxRandomBytes); length > longest {
+		return 0, fmt.Errorf("length must be at most %d for %s encoding", longest, e.Name)
+	}
+	n := 0
+	for n < maxRandomBytes && e.EncodedLen(n+1) <= length {
+		n++
+	}
+	if n > 0 && e.EncodedLen(n) == length {
+		return n, nil
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("length %d does not fit %s encoding, where a single byte takes %d characters", length, e.Name, e.EncodedLen(1))
+	}
+	return 0, fmt.Errorf("length %d does not fit %s encoding of whole bytes; the nearest lengths are %d and %d", length, e.Name, e.EncodedLen(n), e.EncodedLen(n+1))
+}
+
+// generate draws the random bytes and returns them encoded, after the prefix
+func (p *bytesPolicy) generate(rng *rand.Rand) map[string]interface{} {
+	data := make([]byte, p.Bytes)
+	rng.Read(data)
+	encoded := p.Encoding.Encode(data)
+	return map[string]interface{}{
+		"value":        p.Prefix + encoded,
+		"bytes":        p.Bytes,
+		"encoding":     p.Encoding.Name,
+		"length":       len(encoded),
+		"entropy_bits": float64(p.Bytes * 8),
+	}
+}
+
+// base58Encode encodes bytes in base58 with the Bitcoin alphabet; each leading
+// zero byte becomes a leading 1
+func base58Encode(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+	// Convert the big-endian number to base 58 digits, least significant first
+	digits := make([]byte, 0, len(data)*138/100+1)
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("1", zeros))
+	for i := len(digits) - 1; i >= 0; i-- {
+		sb.WriteByte(base58Alphabet[digits[i]])
+	}
+	return sb.String()
+}
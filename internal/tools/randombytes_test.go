@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestBase58Encode(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, ""},
+		{"zero byte", []byte{0}, "1"},
+		{"leading zeros", []byte{0, 0, 1}, "112"},
+		{"text", []byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+		{"max byte", []byte{0xff}, "5Q"},
+		{"two bytes", []byte{0x01, 0x00}, "5R"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base58Encode(tt.data); got != tt.want {
+				t.Errorf("base58Encode(%x) = %q, want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRandomBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   map[string]interface{}
+		bytes    int
+		length   int
+		prefix   string
+		decode   func(s string) ([]byte, error)
+		alphabet string
+		encoding string
+	}{
+		{"default hex", map[string]interface{}{}, 32, 64, "", hex.DecodeString, "", "hex"},
+		{"base64", map[string]interface{}{"encoding": "base64", "bytes": 16.0}, 16, 24, "", base64.StdEncoding.DecodeString, "", "base64"},
+		{"base64url with prefix", map[string]interface{}{"encoding": "base64url", "bytes": 32.0, "prefix": "sk_live_"}, 32, 43, "sk_live_", base64.RawURLEncoding.DecodeString, "", "base64url"},
+		{"base32 by length", map[string]interface{}{"encoding": "base32", "length": 16.0}, 10, 16, "", base32.StdEncoding.DecodeString, "", "base32"},
+		{"crockford by length", map[string]interface{}{"encoding": "Crockford", "length": 26.0}, 16, 26, "", crockfordEncoding.DecodeString, crockfordAlphabet, "crockford"},
+		{"base58", map[string]interface{}{"encoding": "base58", "bytes": 24.0, "prefix": "pk_"}, 24, 0, "pk_", nil, base58Alphabet, "base58"},
+		{"hex by length", map[string]interface{}{"length": 12.0}, 6, 12, "", hex.DecodeString, "", "hex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := parseBytesPolicy(tt.params)
+			if err != nil {
+				t.Fatalf("parseBytesPolicy() error = %v", err)
+			}
+			if policy.Bytes != tt.bytes {
+				t.Fatalf("Expected %d bytes, got %d", tt.bytes, policy.Bytes)
+			}
+			rng := rand.New(rand.NewSource(1))
+			for range 50 {
+				result := policy.generate(rng)
+				value := result["value"].(string)
+				if !strings.HasPrefix(value, tt.prefix) {
+					t.Fatalf("Expected %q to start with %q", value, tt.prefix)
+				}
+				encoded := strings.TrimPrefix(value, tt.prefix)
+				if result["length"] != len(encoded) || (tt.length > 0 && len(encoded) != tt.length) {
+					t.Errorf("Expected %q to have length %d, got %v", encoded, tt.length, result["length"])
+				}
+				if result["encoding"] != tt.encoding || result["bytes"] != tt.bytes || result["entropy_bits"] != float64(tt.bytes*8) {
+					t.Errorf("Unexpected result %v", result)
+				}
+				if tt.decode != nil {
+					data, err := tt.decode(encoded)
+					if err != nil || len(data) != tt.bytes {
+						t.Errorf("Expected %q to decode to %d bytes, got %d (%v)", encoded, tt.bytes, len(data), err)
+					}
+				}
+				for _, r := range encoded {
+					if tt.alphabet != "" && !strings.ContainsRune(tt.alphabet, r) {
+						t.Errorf("Unexpected character %q in %q", r, encoded)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRandomBytesValidation(t *testing.T) {
+	tool := NewRandomTool()
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"invalid encoding", map[string]interface{}{"type": "bytes", "encoding": "base16"}, "invalid encoding"},
+		{"too few bytes", map[string]interface{}{"type": "bytes", "bytes": 0.0}, "bytes must be an integer between 1 and 1024"},
+		{"too many bytes", map[string]interface{}{"type": "bytes", "bytes": 1025.0}, "bytes must be an integer between 1 and 1024"},
+		{"bytes and length", map[string]interface{}{"type": "bytes", "bytes": 16.0, "length": 32.0}, "cannot be used together"},
+		{"odd hex length", map[string]interface{}{"type": "bytes", "length": 31.0}, "nearest lengths are 30 and 32"},
+		{"unpadded base64 length", map[string]interface{}{"type": "bytes", "encoding": "base64", "length": 22.0}, "nearest lengths are 20 and 24"},
+		{"impossible base64url length", map[string]interface{}{"type": "bytes", "encoding": "base64url", "length": 41.0}, "nearest lengths are 40 and 42"},
+		{"shorter than a byte", map[string]interface{}{"type": "bytes", "encoding": "crockford", "length": 1.0}, "a single byte takes 2 characters"},
+		{"too long", map[string]interface{}{"type": "bytes", "length": 2050.0}, "at most 2048"},
+		{"base58 length", map[string]interface{}{"type": "bytes", "encoding": "base58", "length": 44.0}, "length cannot be used with base58"},
+		{"prefix not a string", map[string]interface{}{"type": "bytes", "prefix": 42.0}, "prefix parameter must be a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tool.ValidateParams(tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
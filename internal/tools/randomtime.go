@@ -0,0 +1,337 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits of random dates, times and durations
+const (
+	// maxWeekdayRangeDays bounds the days scanned for the weekdays filter, about 550 years
+	maxWeekdayRangeDays = 200000
+)
+
+// durationFormats lists the output formats of random durations
+var durationFormats = []string{"go", "iso", "seconds"}
+
+// weekdayNames maps weekday names and their three-letter abbreviations to weekdays
+var weekdayNames = func() map[string]time.Weekday {
+	names := make(map[string]time.Weekday)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		names[name] = day
+		names[name[:3]] = day
+	}
+	return names
+}()
+
+// businessDays are the weekdays selected by the business-days parameter
+var businessDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// timeInterval is a span of whole Unix seconds, both ends included, with the
+// number of seconds in the intervals before it
+type timeInterval struct {
+	Start, End int64
+	Before     int64
+}
+
+// timeRange describes the random instants or dates to draw
+type timeRange struct {
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+	Format   string
+	// FirstDay and DayCount are the midnight of the first day and the number of days in the range
+	FirstDay time.Time
+	DayCount int
+	// Weekdays holds the selected weekdays, or nil when every day is allowed
+	Weekdays map[time.Weekday]bool
+	// Days holds the midnights of the selected days when Weekdays is set
+	Days []time.Time
+	// Intervals holds the selected parts of the range of random instants
+	// when Weekdays is set, with the total number of seconds they cover
+	Intervals []timeInterval
+	Seconds   int64
+}
+
+// parseTimeRange builds the range of random instants ("datetime") or dates
+// ("date") from the parameters. Start and end accept any input go-anytime
+// understands, resolved against now; a missing end is a year after start and
+// a missing start a year before end, so the default range is the past year.
+func parseTimeRange(typeParam string, params map[string]interface{}, now time.Time) (*timeRange, error) {
+	r := &timeRange{Format: "iso"}
+	if typeParam == "date" {
+		r.Format = "date"
+	}
+	if format, ok := params["format"]; ok {
+		formatStr, ok := format.(string)
+		if !ok || !contains(timeFormats, formatStr) {
+			return nil, fmt.Errorf("invalid format: %v, must be one of: %s", format, strings.Join(timeFormats, ", "))
+		}
+		r.Format = formatStr
+	}
+
+	timezone, ok := params["timezone"].(string)
+	if _, present := params["timezone"]; present && !ok {
+		return nil, fmt.Errorf("timezone parameter must be a string")
+	}
+	loc, err := loadTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+	r.Location = loc
+
+	start, hasStart, err := timeParam(params, "start", now)
+	if err != nil {
+		return nil, err
+	}
+	end, hasEnd, err := timeParam(params, "end", now)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !hasStart && !hasEnd:
+		end = now
+		start = end.AddDate(-1, 0, 0)
+	case !hasStart:
+		start = end.AddDate(-1, 0, 0)
+	case !hasEnd:
+		end = start.AddDate(1, 0, 0)
+	}
+	r.Start, r.End = start.In(loc), end.In(loc)
+	if r.Start.After(r.End) {
+		return nil, fmt.Errorf("start must not be after end")
+	}
+
+	// Count the days by calendar, as daylight saving time makes some days
+	// shorter, and in Unix seconds, as a time.Duration spans only 292 years
+	r.FirstDay = midnight(r.Start)
+	first := time.Date(r.Start.Year(), r.Start.Month(), r.Start.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(r.End.Year(), r.End.Month(), r.End.Day(), 0, 0, 0, 0, time.UTC)
+	r.DayCount = int((last.Unix()-first.Unix())/86400) + 1
+
+	weekdays, err := weekdaysParam(params)
+	if err != nil {
+		return nil, err
+	}
+	if weekdays == nil {
+		if typeParam == "datetime" && unixCeil(r.Start) > r.End.Unix() {
+			return nil, fmt.Errorf("the range from start to end contains no whole second")
+		}
+		return r, nil
+	}
+
+	// Collect the selected days, and for instants the part of each that lies in the range
+	if r.DayCount > maxWeekdayRangeDays {
+		return nil, fmt.Errorf("ranges restricted to weekdays must not span more than %d days", maxWeekdayRangeDays)
+	}
+	r.Weekdays = weekdays
+	for i := range r.DayCount {
+		day := r.FirstDay.AddDate(0, 0, i)
+		if !weekdays[day.Weekday()] {
+			continue
+		}
+		if typeParam == "date" {
+			r.Days = append(r.Days, day)
+			continue
+		}
+		interval := timeInterval{
+			Start:  max(day.Unix(), unixCeil(r.Start)),
+			End:    min(day.AddDate(0, 0, 1).Unix()-1, r.End.Unix()),
+			Before: r.Seconds,
+		}
+		if interval.Start <= interval.End {
+			r.Intervals = append(r.Intervals, interval)
+			r.Seconds += interval.End - interval.Start + 1
+		}
+	}
+	if len(r.Days) == 0 && len(r.Intervals) == 0 {
+		return nil, fmt.Errorf("the range from start to end contains none of the selected weekdays")
+	}
+	return r, nil
+}
+
+// draw returns a random instant or date of the range in the selected format
+func (r *timeRange) draw(rng *rand.Rand, typeParam string) (string, error) {
+	var tm time.Time
+	switch {
+	case typeParam == "date" && r.Weekdays != nil:
+		tm = r.Days[rng.Intn(len(r.Days))]
+	case typeParam == "date":
+		tm = r.FirstDay.AddDate(0, 0, rng.Intn(r.DayCount))
+	case r.Weekdays != nil:
+		// Find the interval holding the drawn second, weighting each by its length
+		offset := rng.Int63n(r.Seconds)
+		i := sort.Search(len(r.Intervals), func(i int) bool {
+			interval := r.Intervals[i]
+			return interval.Before+interval.End-interval.Start >= offset
+		})
+		tm = time.Unix(r.Intervals[i].Start+offset-r.Intervals[i].Before, 0).In(r.Location)
+	default:
+		start := unixCeil(r.Start)
+		tm = time.Unix(start+rng.Int63n(r.End.Unix()-start+1), 0).In(r.Location)
+	}
+	return NewTimeTool().formatTime(tm, r.Format)
+}
+
+// durationRange describes the random durations to draw
+type durationRange struct {
+	Min        time.Duration
+	Max        time.Duration
+	Resolution time.Duration
+	Format     string
+}
+
+// parseDurationRange builds the range of random durations from the parameters
+func parseDurationRange(params map[string]interface{}) (*durationRange, error) {
+	r := &durationRange{Max: 24 * time.Hour, Resolution: time.Second, Format: "go"}
+	targets := []struct {
+		name   string
+		target *time.Duration
+	}{{"min-duration", &r.Min}, {"max-duration", &r.Max}, {"resolution", &r.Resolution}}
+	for _, t := range targets {
+		name, target := t.name, t.target
+		value, ok := params[name]
+		if !ok {
+			continue
+		}
+		valueStr, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s parameter must be a string", name)
+		}
+		duration, err := time.ParseDuration(valueStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s, expected a duration such as 90s, 1h30m or 250ms", name, valueStr)
+		}
+		*target = duration
+	}
+	if r.Resolution <= 0 {
+		return nil, fmt.Errorf("resolution must be greater than 0")
+	}
+	if r.Min > r.Max {
+		return nil, fmt.Errorf("min-duration must be less than or equal to max-duration")
+	}
+	if format, ok := params["format"]; ok {
+		formatStr, ok := format.(string)
+		if !ok || !contains(durationFormats, formatStr) {
+			return nil, fmt.Errorf("invalid format: %v, must be one of: %s", format, strings.Join(durationFormats, ", "))
+		}
+		r.Format = formatStr
+	}
+	if span := r.Max - r.Min; span < 0 || span/r.Resolution == math.MaxInt64 {
+		return nil, fmt.Errorf("the range from min-duration to max-duration is too large")
+	}
+	return r, nil
+}
+
+// draw returns a random multiple of the resolution added to the minimum, in the selected format
+func (r *durationRange) draw(rng *rand.Rand) interface{} {
+	duration := r.Min + time.Duration(rng.Int63n(int64((r.Max-r.Min)/r.Resolution)+1))*r.Resolution
+	switch r.Format {
+	case "seconds":
+		return duration.Seconds()
+	case "iso":
+		return isoDuration(duration)
+	}
+	return duration.String()
+}
+
+// isoDuration formats a duration in ISO 8601, e.g. PT1H30M or PT0.25S. It
+// uses hours rather than days, as days vary in length with daylight saving time.
+func isoDuration(d time.Duration) string {
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteString("-")
+		d = -d
+	}
+	sb.WriteString("PT")
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := d % time.Minute
+	if hours > 0 {
+		sb.WriteString(strconv.FormatInt(int64(hours), 10) + "H")
+	}
+	if minutes > 0 {
+		sb.WriteString(strconv.FormatInt(int64(minutes), 10) + "M")
+	}
+	if seconds > 0 || d == 0 {
+		sb.WriteString(strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64) + "S")
+	}
+	return sb.String()
+}
+
+// timeParam parses the named timestamp parameter with go-anytime
+func timeParam(params map[string]interface{}, name string, now time.Time) (time.Time, bool, error) {
+	value, ok := params[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	input, ok := value.(string)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("%s parameter must be a string", name)
+	}
+	tm, err := parseTimeInput(input, now)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return tm, true, nil
+}
+
+// weekdaysParam returns the weekdays selected by the weekdays or business-days
+// parameter, or nil when every day is allowed
+func weekdaysParam(params map[string]interface{}) (map[time.Weekday]bool, error) {
+	business, _ := params["business-days"].(bool)
+	if _, ok := params["business-days"]; ok {
+		if _, isBool := params["business-days"].(bool); !isBool {
+			return nil, fmt.Errorf("business-days parameter must be a boolean")
+		}
+	}
+	value, hasWeekdays := params["weekdays"]
+	if business && hasWeekdays {
+		return nil, fmt.Errorf("business-days and weekdays cannot be used together")
+	}
+
+	weekdays := make(map[time.Weekday]bool)
+	switch {
+	case business:
+		for _, day := range businessDays {
+			weekdays[day] = true
+		}
+	case hasWeekdays:
+		names, err := stringList(value)
+		if err != nil {
+			return nil, fmt.Errorf("weekdays %v", err)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("weekdays must not be empty")
+		}
+		for _, name := range names {
+			day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return nil, fmt.Errorf("invalid weekday: %s, must be a day name such as monday or mon", name)
+			}
+			weekdays[day] = true
+		}
+	default:
+		return nil, nil
+	}
+	return weekdays, nil
+}
+
+// midnight returns the start of the day of tm in its location
+func midnight(tm time.Time) time.Time {
+	return time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, tm.Location())
+}
+
+// unixCeil returns the first whole Unix second at or after tm
+func unixCeil(tm time.Time) int64 {
+	if tm.Nanosecond() > 0 {
+		return tm.Unix() + 1
+	}
+	return tm.Unix()
+}
@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRandomTimes(t *testing.T) {
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		typeName string
+		params   map[string]interface{}
+		earliest string
+		latest   string
+		weekdays []time.Weekday
+	}{
+		{"default range is the past year", "datetime", map[string]interface{}{"timezone": "utc"}, "2024-10-15T12:00:00Z", "2025-10-15T12:00:00Z", nil},
+		{"missing end is a year after start", "datetime", map[string]interface{}{"start": "2020-01-01", "timezone": "utc"}, "2020-01-01T00:00:00Z", "2021-01-01T00:00:00Z", nil},
+		{"short range", "datetime", map[string]interface{}{"start": "2025-03-01T10:00:00Z", "end": "2025-03-01T10:00:05Z", "timezone": "utc"}, "2025-03-01T10:00:00Z", "2025-03-01T10:00:05Z", nil},
+		{"business days", "datetime", map[string]interface{}{"start": "2025-01-01", "end": "2025-01-31", "business-days": true, "timezone": "Europe/Oslo"}, "2024-12-31T23:00:00Z", "2025-01-31T00:00:00Z", businessDays},
+		{"weekends in a timezone", "datetime", map[string]interface{}{"start": "2025-03-01", "end": "2025-04-30", "weekdays": []interface{}{"saturday", "Sun"}, "timezone": "America/New_York"}, "2025-03-01T00:00:00Z", "2025-04-30T00:00:00Z", []time.Weekday{time.Saturday, time.Sunday}},
+		{"dates include both ends", "date", map[string]interface{}{"start": "2025-02-27", "end": "2025-03-01", "timezone": "utc"}, "2025-02-27T00:00:00Z", "2025-03-01T00:00:00Z", nil},
+		{"single weekday", "date", map[string]interface{}{"start": "2025-01-01", "end": "2025-12-31", "weekdays": []string{"wed"}, "timezone": "utc"}, "2025-01-01T00:00:00Z", "2025-12-31T00:00:00Z", []time.Weekday{time.Wednesday}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["format"] = "rfc3339"
+			r, err := parseTimeRange(tt.typeName, tt.params, now)
+			if err != nil {
+				t.Fatalf("parseTimeRange() error = %v", err)
+			}
+			earliest, _ := time.Parse(time.RFC3339, tt.earliest)
+			latest, _ := time.Parse(time.RFC3339, tt.latest)
+			rng := rand.New(rand.NewSource(1))
+			for range 500 {
+				value, err := r.draw(rng, tt.typeName)
+				if err != nil {
+					t.Fatalf("draw() error = %v", err)
+				}
+				tm, err := time.Parse(time.RFC3339, value)
+				if err != nil {
+					t.Fatalf("Expected an RFC 3339 time, got %q", value)
+				}
+				if tm.Before(earliest) || tm.After(latest) {
+					t.Fatalf("Expected %s between %s and %s", value, tt.earliest, tt.latest)
+				}
+				if tt.typeName == "date" && (tm.Hour() != 0 || tm.Minute() != 0 || tm.Second() != 0) {
+					t.Errorf("Expected %s at midnight", value)
+				}
+				if tt.weekdays != nil && !weekdayIn(tm.In(r.Location).Weekday(), tt.weekdays) {
+					t.Errorf("Expected %s on one of %v", value, tt.weekdays)
+				}
+			}
+		})
+	}
+}
+
+// weekdayIn reports whether day is one of days
+func weekdayIn(day time.Weekday, days []time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRandomTimesCoverWholeRange(t *testing.T) {
+	// Every date of a short range, and only those, turns up
+	r, err := parseTimeRange("date", map[string]interface{}{"start": "2024-02-27", "end": "2024-03-02", "timezone": "utc"}, time.Now())
+	if err != nil {
+		t.Fatalf("parseTimeRange() error = %v", err)
+	}
+	seen := make(map[string]int)
+	rng := rand.New(rand.NewSource(1))
+	for range 1000 {
+		value, _ := r.draw(rng, "date")
+		seen[value]++
+	}
+	for _, date := range []string{"2024-02-27", "2024-02-28", "2024-02-29", "2024-03-01", "2024-03-02"} {
+		if seen[date] == 0 {
+			t.Errorf("Expected %s to be drawn, got %v", date, seen)
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("Expected 5 distinct dates, got %v", seen)
+	}
+}
+
+func TestRandomDurations(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		min    time.Duration
+		max    time.Duration
+		step   time.Duration
+	}{
+		{"defaults", map[string]interface{}{}, 0, 24 * time.Hour, time.Second},
+		{"range", map[string]interface{}{"min-duration": "30s", "max-duration": "15m"}, 30 * time.Second, 15 * time.Minute, time.Second},
+		{"resolution", map[string]interface{}{"min-duration": "1h", "max-duration": "8h", "resolution": "15m"}, time.Hour, 8 * time.Hour, 15 * time.Minute},
+		{"milliseconds", map[string]interface{}{"max-duration": "1s", "resolution": "1ms"}, 0, time.Second, time.Millisecond},
+		{"fixed", map[string]interface{}{"min-duration": "90s", "max-duration": "90s"}, 90 * time.Second, 90 * time.Second, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseDurationRange(tt.params)
+			if err != nil {
+				t.Fatalf("parseDurationRange() error = %v", err)
+			}
+			rng := rand.New(rand.NewSource(1))
+			for range 500 {
+				value := r.draw(rng).(string)
+				d, err := time.ParseDuration(value)
+				if err != nil {
+					t.Fatalf("Expected a Go duration, got %q", value)
+				}
+				if d < tt.min || d > tt.max || (d-tt.min)%tt.step != 0 {
+					t.Fatalf("Expected %s between %s and %s in steps of %s", d, tt.min, tt.max, tt.step)
+				}
+			}
+		})
+	}
+}
+
+func TestIsoDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "PT0S"},
+		{250 * time.Millisecond, "PT0.25S"},
+		{90 * time.Second, "PT1M30S"},
+		{2 * time.Hour, "PT2H"},
+		{26*time.Hour + 3*time.Minute + 4*time.Second, "PT26H3M4S"},
+		{-time.Minute, "-PT1M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := isoDuration(tt.duration); got != tt.want {
+				t.Errorf("isoDuration(%s) = %q, want %q", tt.duration, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRandomToolTimes(t *testing.T) {
+	tool := NewRandomTool()
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		check  func(value interface{}) bool
+	}{
+		{"date format", map[string]interface{}{"type": "date"}, func(value interface{}) bool {
+			_, err := time.Parse("2006-01-02", value.(string))
+			return err == nil
+		}},
+		{"unix datetime", map[string]interface{}{"type": "datetime", "format": "unix", "start": "2025-01-01T00:00:00Z", "end": "2025-01-02T00:00:00Z"}, func(value interface{}) bool {
+			seconds, err := strconv.ParseInt(value.(string), 10, 64)
+			return err == nil && seconds >= 1735689600 && seconds <= 1735776000
+		}},
+		{"weekday format", map[string]interface{}{"type": "date", "format": "weekday", "weekdays": []interface{}{"friday"}}, func(value interface{}) bool {
+			return strings.HasPrefix(value.(string), "Friday, ")
+		}},
+		{"iso duration", map[string]interface{}{"type": "duration", "format": "iso"}, func(value interface{}) bool {
+			return strings.HasPrefix(value.(string), "PT")
+		}},
+		{"seconds duration", map[string]interface{}{"type": "duration", "format": "seconds", "max-duration": "1m"}, func(value interface{}) bool {
+			return value.(float64) >= 0 && value.(float64) <= 60
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tool.ValidateParams(tt.params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !tt.check(result) {
+				t.Errorf("Unexpected result %#v", result)
+			}
+		})
+	}
+}
+
+func TestRandomTimeValidation(t *testing.T) {
+	tool := NewRandomTool()
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"start after end", map[string]interface{}{"type": "datetime", "start": "2025-02-01", "end": "2025-01-01"}, "start must not be after end"},
+		{"invalid start", map[string]interface{}{"type": "date", "start": "not a date"}, "invalid start"},
+		{"invalid timezone", map[string]interface{}{"type": "datetime", "timezone": "Mars/Olympus"}, "invalid timezone"},
+		{"invalid time format", map[string]interface{}{"type": "datetime", "format": "go"}, "invalid format"},
+		{"invalid weekday", map[string]interface{}{"type": "date", "weekdays": []interface{}{"someday"}}, "invalid weekday"},
+		{"empty weekdays", map[string]interface{}{"type": "date", "weekdays": []interface{}{}}, "weekdays must not be empty"},
+		{"weekdays and business days", map[string]interface{}{"type": "date", "weekdays": []interface{}{"monday"}, "business-days": true}, "cannot be used together"},
+		{"no selected weekday in range", map[string]interface{}{"type": "date", "start": "2025-01-04", "end": "2025-01-05", "business-days": true}, "contains none of the selected weekdays"},
+		{"weekday range too long", map[string]interface{}{"type": "date", "start": "1200-01-01", "end": "2025-01-01", "business-days": true}, "must not span more than"},
+		{"no whole second", map[string]interface{}{"type": "datetime", "start": "2025-01-01T00:00:00.2Z", "end": "2025-01-01T00:00:00.8Z"}, "no whole second"},
+		{"invalid duration", map[string]interface{}{"type": "duration", "max-duration": "forever"}, "invalid max-duration"},
+		{"min above max", map[string]interface{}{"type": "duration", "min-duration": "2h", "max-duration": "1h"}, "less than or equal to max-duration"},
+		{"zero resolution", map[string]interface{}{"type": "duration", "resolution": "0s"}, "resolution must be greater than 0"},
+		{"invalid duration format", map[string]interface{}{"type": "duration", "format": "date"}, "invalid format"},
+		{"duration span too large", map[string]interface{}{"type": "duration", "min-duration": "-2562047h", "max-duration": "2562047h"}, "too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tool.ValidateParams(tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRandomToolCompleteParam(t *testing.T) {
+	tool := NewRandomTool()
+	tests := []struct {
+		param string
+		want  []string
+	}{
+		{"type", []string{"integer", "dice", "datetime", "duration"}},
+		{"timezone", []string{"utc", "local", "Europe/Oslo"}},
+		{"weekdays", []string{"monday", "sunday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.param, func(t *testing.T) {
+			var values []string
+			for _, completion := range tool.CompleteParam(tt.param) {
+				values = append(values, completion.Value)
+			}
+			for _, want := range tt.want {
+				if !contains(values, want) {
+					t.Errorf("Expected %q among the completions of %s", want, tt.param)
+				}
+			}
+		})
+	}
+}
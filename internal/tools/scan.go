@@ -0,0 +1,441 @@
+// Package tools provides the scan tool implementation
+package tools
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// scanPattern finds candidates for one kind of identifier in free text
+type scanPattern struct {
+	// Kind names the identifier, e.g. "iban"
+	Kind string
+	// Regexp matches candidates. When it has a capturing group, the group is
+	// the identifier and the rest of the match a label such as "IMO".
+	Regexp *regexp.Regexp
+	// Tool validates the candidates
+	Tool Tool
+	// Params are passed to the tool along with the candidate
+	Params map[string]interface{}
+	// Value is the result field holding the normalized identifier
+	Value string
+}
+
+// scanMatch is a candidate identifier found in the text
+type scanMatch struct {
+	start, end int // byte offsets of the identifier in the text
+	pattern    *scanPattern
+	result     map[string]interface{}
+}
+
+// ScanTool finds identifiers in free text and validates them
+type ScanTool struct {
+	patterns []scanPattern
+}
+
+// NewScanTool creates a new scan tool instance
+func NewScanTool() *ScanTool {
+	tool := &ScanTool{}
+	tool.populatePatterns()
+	return tool
+}
+
+// populatePatterns lists the identifiers the tool looks for, most specific
+// first: a candidate is dropped when it overlaps one found by an earlier
+// pattern, so the digits of a labelled ISBN are not also reported as a card
+// number. IMO and MMSI numbers are only recognised with their label, as any
+// 7 or 9 digit number would otherwise be a candidate.
+func (s *ScanTool) populatePatterns() {
+	isbn := NewISBNTool()
+	s.patterns = []scanPattern{
+		{
+			Kind:   "uuid",
+			Regexp: regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`),
+			Tool:   NewUUIDTool(),
+			Params: map[string]interface{}{"version": "validate"},
+			Value:  "uuid",
+		},
+		{
+			Kind:   "iban",
+			Regexp: regexp.MustCompile(`\b[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]){11,30}\b`),
+			Tool:   NewIBANTool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Value:  "iban",
+		},
+		{
+			Kind:   "isbn",
+			Regexp: regexp.MustCompile(`(?i)\bISBN(?:-1[03])?:?\s*([0-9](?:[ -]?[0-9]){8,11}(?:[ -]?[0-9X]))\b`),
+			Tool:   isbn,
+			Params: map[string]interface{}{"operation": "validate"},
+			Value:  "isbn",
+		},
+		{
+			Kind:   "imo",
+			Regexp: regexp.MustCompile(`(?i)\bIMO(?:\s*(?:No\.?|number|#))?[\s:.#-]*([0-9]{7})\b`),
+			Tool:   NewIMOTool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Value:  "imo",
+		},
+		{
+			Kind:   "mmsi",
+			Regexp: regexp.MustCompile(`(?i)\bMMSI(?:\s*(?:No\.?|number|#))?[\s:.#-]*([0-9]{9})\b`),
+			Tool:   NewMMSITool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Value:  "mmsi",
+		},
+		{
+			Kind:   "isbn",
+			Regexp: regexp.MustCompile(`\b97[89](?:[ -]?[0-9]){10}\b`),
+			Tool:   isbn,
+			Params: map[string]interface{}{"operation": "validate", "format": "isbn13"},
+			Value:  "isbn",
+		},
+		{
+			Kind:   "creditcard",
+			Regexp: regexp.MustCompile(`\b[0-9](?:[ -]?[0-9]){12,18}\b`),
+			Tool:   NewCreditCardTool(),
+			Params: map[string]interface{}{"operation": "validate"},
+			Value:  "card",
+		},
+	}
+}
+
+// scanKinds returns the kinds of identifier the tool looks for
+func (s *ScanTool) scanKinds() []string {
+	var kinds []string
+	for _, pattern := range s.patterns {
+		if !slices.Contains(kinds, pattern.Kind) {
+			kinds = append(kinds, pattern.Kind)
+		}
+	}
+	return kinds
+}
+
+// Name returns the tool's name
+func (s *ScanTool) Name() string {
+	return "scan"
+}
+
+// Description returns the tool's description
+func (s *ScanTool) Description() string {
+	return "Find identifiers (IBAN, credit card, ISBN, IMO, MMSI, UUID) in free text and validate each one, returning character offsets, type, normalized value and validity, optionally with card numbers masked"
+}
+
+// Annotations returns the tool's behavioural hints for clients
+func (s *ScanTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "Scan Text",
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  false,
+		Tags:       []string{"identifiers", "validation", "text"},
+	}
+}
+
+// Execute runs the scan tool
+func (s *ScanTool) Execute(params map[string]interface{}) (interface{}, error) {
+	if err := s.ValidateParams(params); err != nil {
+		return nil, err
+	}
+
+	text := params["text"].(string)
+	kinds := s.scanKinds()
+	if types, ok := params["types"]; ok {
+		kinds = schemaStrings(types)
+	}
+	mask, _ := params["mask"].(bool)
+
+	matches := s.scan(text, kinds)
+
+	results := make([]map[string]interface{}, len(matches))
+	for i, match := range matches {
+		results[i] = s.describeMatch(text, match, mask)
+	}
+
+	result := map[string]interface{}{
+		"matches": results,
+		"count":   len(results),
+	}
+	if mask {
+		result["masked_text"] = maskCards(text, matches)
+	}
+	return result, nil
+}
+
+// scan finds the candidates of the given kinds, ordered by position
+func (s *ScanTool) scan(text string, kinds []string) []scanMatch {
+	var matches []scanMatch
+	for i := range s.patterns {
+		pattern := &s.patterns[i]
+		if !slices.Contains(kinds, pattern.Kind) {
+			continue
+		}
+
+		for _, loc := range pattern.Regexp.FindAllStringSubmatchIndex(text, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) > 2 {
+				start, end = loc[2], loc[3]
+			}
+
+			match := s.validateCandidate(text, start, end, pattern)
+			overlaps := slices.ContainsFunc(matches, func(other scanMatch) bool {
+				return match.start < other.end && other.start < match.end
+			})
+			if !overlaps {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+	return matches
+}
+
+// validateCandidate validates a candidate with the pattern's tool. Patterns
+// that allow spaces can run into the words or numbers that follow an
+// identifier, so when the whole candidate is invalid the shorter candidates
+// ending at a separator are tried as well. The whole candidate is reported
+// when none of them is valid.
+func (s *ScanTool) validateCandidate(text string, start, end int, pattern *scanPattern) scanMatch {
+	validate := func(end int) map[string]interface{} {
+		params := maps.Clone(pattern.Params)
+		params["input"] = text[start:end]
+		value, err := pattern.Tool.Execute(params)
+		result, ok := value.(map[string]interface{})
+		if err != nil || !ok {
+			message := "unexpected validation result"
+			if err != nil {
+				message = err.Error()
+			}
+			result = map[string]interface{}{"valid": false, "error": message}
+		}
+		return result
+	}
+
+	whole := validate(end)
+	if valid, _ := whole["valid"].(bool); valid {
+		return scanMatch{start: start, end: end, pattern: pattern, result: whole}
+	}
+
+	for shorter := strings.LastIndexAny(text[start:end], " -"); shorter > 0; shorter = strings.LastIndexAny(text[start:start+shorter], " -") {
+		if result := validate(start + shorter); result["valid"] == true {
+			return scanMatch{start: start, end: start + shorter, pattern: pattern, result: result}
+		}
+	}
+	return scanMatch{start: start, end: end, pattern: pattern, result: whole}
+}
+
+// describeMatch converts a match to its result, with offsets counted in
+// characters and card numbers masked when requested
+func (s *ScanTool) describeMatch(text string, match scanMatch, mask bool) map[string]interface{} {
+	raw := text[match.start:match.end]
+	valid, _ := match.result["valid"].(bool)
+
+	value, _ := match.result[match.pattern.Value].(string)
+	if value == "" {
+		value = strings.NewReplacer(" ", "", "-", "").Replace(raw)
+	}
+
+	fields := maps.Clone(match.result)
+	delete(fields, "valid")
+	delete(fields, "input")
+	delete(fields, "error")
+
+	if mask && match.pattern.Kind == "creditcard" {
+		raw = maskCardNumber(raw)
+		value = maskCardNumber(value)
+		if card, ok := fields["card"].(string); ok {
+			fields["card"] = maskCardNumber(card)
+		}
+	}
+
+	result := map[string]interface{}{
+		"start":  utf8.RuneCountInString(text[:match.start]),
+		"end":    utf8.RuneCountInString(text[:match.end]),
+		"text":   raw,
+		"type":   match.pattern.Kind,
+		"value":  value,
+		"valid":  valid,
+		"fields": fields,
+	}
+	if message, ok := match.result["error"].(string); ok {
+		result["error"] = message
+	}
+	return result
+}
+
+// maskCards returns the text with every card number candidate masked
+func maskCards(text string, matches []scanMatch) string {
+	var sb strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match.pattern.Kind != "creditcard" {
+			continue
+		}
+		sb.WriteString(text[last:match.start])
+		sb.WriteString(maskCardNumber(text[match.start:match.end]))
+		last = match.end
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// maskCardNumber replaces all but the last four digits of a card number with
+// asterisks, keeping the separators
+func maskCardNumber(number string) string {
+	digits := 0
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	masked := []rune(number)
+	for i, r := range masked {
+		if r >= '0' && r <= '9' && digits > 4 {
+			masked[i] = '*'
+			digits--
+		}
+	}
+	return string(masked)
+}
+
+// ValidateParams validates the input parameters
+func (s *ScanTool) ValidateParams(params map[string]interface{}) error {
+	text, ok := params["text"]
+	if !ok {
+		return fmt.Errorf("text parameter is required")
+	}
+	if _, ok := text.(string); !ok {
+		return fmt.Errorf("text parameter must be a string")
+	}
+
+	if types, ok := params["types"]; ok {
+		kinds := s.scanKinds()
+		for _, item := range schemaList(types) {
+			kind, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("types must be an array of strings")
+			}
+			if !slices.Contains(kinds, kind) {
+				return fmt.Errorf("invalid type: %s. Supported types: %s", kind, strings.Join(kinds, ", "))
+			}
+		}
+	}
+
+	if mask, ok := params["mask"]; ok {
+		if _, ok := mask.(bool); !ok {
+			return fmt.Errorf("mask parameter must be a boolean")
+		}
+	}
+	return nil
+}
+
+// GetInputSchema returns the JSON schema for tool input parameters
+func (s *ScanTool) GetInputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"text": map[string]interface{}{
+				"type":        "string",
+				"description": "Free text to scan, such as an email or the text of a PDF",
+			},
+			"types": map[string]interface{}{
+				"type":        "array",
+				"description": "Kinds of identifier to look for (default: all)",
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": s.scanKinds(),
+				},
+			},
+			"mask": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Mask all but the last four digits of card numbers, in the matches and in a masked copy of the text",
+				"default":     false,
+			},
+		},
+		"required":             []string{"text"},
+		"additionalProperties": false,
+	}
+}
+
+// GetOutputSchema returns the JSON schema for tool output
+func (s *ScanTool) GetOutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"result": map[string]interface{}{
+				"type":        "object",
+				"description": "The identifiers found in the text",
+				"properties": map[string]interface{}{
+					"matches": map[string]interface{}{
+						"type":        "array",
+						"description": "Candidate identifiers in order of appearance, valid or not",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"start": map[string]interface{}{
+									"type":        "integer",
+									"description": "Character offset of the identifier in the text",
+								},
+								"end": map[string]interface{}{
+									"type":        "integer",
+									"description": "Character offset just after the identifier",
+								},
+								"text": map[string]interface{}{
+									"type":        "string",
+									"description": "The identifier as written in the text",
+								},
+								"type": map[string]interface{}{
+									"type":        "string",
+									"description": "Kind of identifier",
+								},
+								"value": map[string]interface{}{
+									"type":        "string",
+									"description": "Normalized identifier",
+								},
+								"valid": map[string]interface{}{
+									"type":        "boolean",
+									"description": "Whether the identifier passed validation",
+								},
+								"error": map[string]interface{}{
+									"type":        "string",
+									"description": "Why validation failed",
+								},
+								"fields": map[string]interface{}{
+									"type":        "object",
+									"description": "Fields extracted by the validator",
+								},
+							},
+						},
+					},
+					"count": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of matches",
+					},
+					"masked_text": map[string]interface{}{
+						"type":        "string",
+						"description": "The text with card numbers masked, when mask is set",
+					},
+				},
+			},
+		},
+	}
+}
+
+// GetResources returns the list of resources this tool provides
+func (s *ScanTool) GetResources() []Resource {
+	return []Resource{}
+}
+
+// ReadResource reads a specific resource by URI
+func (s *ScanTool) ReadResource(uri string) (string, error) {
+	return "", fmt.Errorf("no resources available for scan tool")
+}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScanTool(t *testing.T) {
+	tool := NewScanTool()
+
+	type want struct {
+		typ   string
+		text  string
+		value string
+		valid bool
+	}
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		matches []want
+		masked  string
+		wantErr bool
+	}{
+		{
+			name: "identifiers in an email",
+			params: map[string]interface{}{
+				"text": "Vessel IMO 9176187 (MMSI: 257123450) paid from GB82 WEST 1234 5698 7654 32 EUR.\nRef ISBN 978-0-306-40615-7, card 4111 1111 1111 1111.",
+			},
+			matches: []want{
+				{typ: "imo", text: "9176187", value: "9176187", valid: true},
+				{typ: "mmsi", text: "257123450", value: "257123450", valid: true},
+				{typ: "iban", text: "GB82 WEST 1234 5698 7654 32", value: "GB82WEST12345698765432", valid: true},
+				{typ: "isbn", text: "978-0-306-40615-7", value: "9780306406157", valid: true},
+				{typ: "creditcard", text: "4111 1111 1111 1111", value: "4111111111111111", valid: true},
+			},
+		},
+		{
+			name:   "invalid candidates are reported",
+			params: map[string]interface{}{"text": "IMO 9176188 and 4111-1111-1111-1112"},
+			matches: []want{
+				{typ: "imo", text: "9176188", value: "9176188"},
+				{typ: "creditcard", text: "4111-1111-1111-1112", value: "4111111111111112"},
+			},
+		},
+		{
+			name:   "unlabelled isbn and uuid",
+			params: map[string]interface{}{"text": "see 9780306406157 for 550e8400-e29b-41d4-a716-446655440000"},
+			matches: []want{
+				{typ: "isbn", text: "9780306406157", value: "9780306406157", valid: true},
+				{typ: "uuid", text: "550e8400-e29b-41d4-a716-446655440000", value: "550e8400-e29b-41d4-a716-446655440000", valid: true},
+			},
+		},
+		{
+			name:    "bare 7 digit numbers are not imo numbers",
+			params:  map[string]interface{}{"text": "Order 9176187 shipped"},
+			matches: []want{},
+		},
+		{
+			name:   "types filter",
+			params: map[string]interface{}{"text": "IMO 9176187, card 4111111111111111", "types": []interface{}{"creditcard"}},
+			matches: []want{
+				{typ: "creditcard", text: "4111111111111111", value: "4111111111111111", valid: true},
+			},
+		},
+		{
+			name:   "mask",
+			params: map[string]interface{}{"text": "Card: 4111 1111 1111 1111, IMO 9176187", "mask": true},
+			matches: []want{
+				{typ: "creditcard", text: "**** **** **** 1111", value: "************1111", valid: true},
+				{typ: "imo", text: "9176187", value: "9176187", valid: true},
+			},
+			masked: "Card: **** **** **** 1111, IMO 9176187",
+		},
+		{
+			name:    "missing text",
+			params:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			params:  map[string]interface{}{"text": "x", "types": []interface{}{"vin"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(tt.params)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			resultMap := result.(map[string]interface{})
+			matches := resultMap["matches"].([]map[string]interface{})
+			if len(matches) != len(tt.matches) {
+				t.Fatalf("Expected %d matches, got %v", len(tt.matches), matches)
+			}
+
+			text := []rune(tt.params["text"].(string))
+			for i, w := range tt.matches {
+				m := matches[i]
+				if m["type"] != w.typ || m["text"] != w.text || m["value"] != w.value || m["valid"] != w.valid {
+					t.Errorf("Match %d: expected %+v, got %v", i, w, m)
+				}
+				if tt.masked == "" {
+					if got := string(text[m["start"].(int):m["end"].(int)]); got != w.text {
+						t.Errorf("Match %d: offsets select %q, expected %q", i, got, w.text)
+					}
+				}
+			}
+
+			if masked, _ := resultMap["masked_text"].(string); masked != tt.masked {
+				t.Errorf("Expected masked text %q, got %q", tt.masked, masked)
+			}
+		})
+	}
+}
+
+func TestScanOffsetsCountCharacters(t *testing.T) {
+	text := "Zahlung für Schiff «IMO 9176187»"
+	result, err := NewScanTool().Execute(map[string]interface{}{"text": text})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	matches := result.(map[string]interface{})["matches"].([]map[string]interface{})
+	if len(matches) != 1 {
+		t.Fatalf("Expected one match, got %v", matches)
+	}
+	start := strings.Index(text, "9176187")
+	if want := len([]rune(text[:start])); matches[0]["start"] != want {
+		t.Errorf("Expected start %d, got %v", want, matches[0]["start"])
+	}
+}
+
+func TestMaskCardNumber(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"4111111111111111", "************1111"},
+		{"4111 1111 1111 1111", "**** **** **** 1111"},
+		{"3782-822463-10005", "****-******-*0005"},
+		{"1234", "1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := maskCardNumber(tt.input); got != tt.expected {
+				t.Errorf("maskCardNumber(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
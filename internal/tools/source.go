@@ -0,0 +1,212 @@
+package tools
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Names of the random sources reported by the generating tools
+const (
+	// SourceMath is math/rand's randomly seeded global source
+	SourceMath = "math/rand"
+	// SourceCrypto is the operating system's cryptographically secure generator
+	SourceCrypto = "crypto/rand"
+)
+
+// seedTime is the clock reading used by seeded generators that embed a
+// timestamp, such as UUID v7, so that their output is reproducible
+var seedTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// seedKey is the context key under which the default seed is stored
+type seedKey struct{}
+
+// secureKey is the context key under which the default secure mode is stored
+type secureKey struct{}
+
+// sourceKey is the context key under which the source reporter is stored
+type sourceKey struct{}
+
+// WithSeed returns a context in which generating tools draw from a source
+// seeded with seed, unless a call passes its own seed parameter. Every call
+// starts from the seed afresh, so identical calls return identical values.
+func WithSeed(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, seedKey{}, seed)
+}
+
+// WithSecure returns a context in which generating tools draw from crypto/rand,
+// unless a call passes its own seed or secure parameter
+func WithSecure(ctx context.Context) context.Context {
+	return context.WithValue(ctx, secureKey{}, true)
+}
+
+// WithSourceReport returns a context that tells fn the name of the random
+// source a tool call drew from, e.g. "crypto/rand" or "math/rand (seed 42)"
+func WithSourceReport(ctx context.Context, fn func(source string)) context.Context {
+	return context.WithValue(ctx, sourceKey{}, fn)
+}
+
+// secureByDefault returns a context in which a tool call draws from crypto/rand
+// unless it passes a seed or secure parameter or the context carries a seed.
+// Generators of secrets use it so that only an explicit request makes them predictable.
+func secureByDefault(ctx context.Context, params map[string]interface{}) context.Context {
+	_, hasSeed := params["seed"]
+	_, hasSecure := params["secure"]
+	_, ctxSeed := ctx.Value(seedKey{}).(int64)
+	if hasSeed || hasSecure || ctxSeed {
+		return ctx
+	}
+	return WithSecure(ctx)
+}
+
+// reportSource reports the random source to the reporter attached to the context, if any
+func reportSource(ctx context.Context, source string) {
+	if fn, ok := ctx.Value(sourceKey{}).(func(string)); ok && fn != nil {
+		fn(source)
+	}
+}
+
+// seedFor returns the seed for a tool call: the seed parameter, or failing
+// that the seed attached to the context. A secure parameter overrides the
+// context's seed.
+func seedFor(ctx context.Context, params map[string]interface{}) (int64, bool) {
+	if seed, ok := seedParam(params["seed"]); ok {
+		return seed, true
+	}
+	if _, ok := params["secure"].(bool); ok {
+		return 0, false
+	}
+	seed, ok := ctx.Value(seedKey{}).(int64)
+	return seed, ok
+}
+
+// secureFor reports whether a tool call must draw from crypto/rand: when it
+// asks to with the secure parameter, or when the context does and the call
+// neither passes a seed nor opts out
+func secureFor(ctx context.Context, params map[string]interface{}) bool {
+	if secure, ok := params["secure"].(bool); ok {
+		return secure
+	}
+	if _, ok := params["seed"]; ok {
+		return false
+	}
+	secure, _ := ctx.Value(secureKey{}).(bool)
+	return secure
+}
+
+// seedParam converts a seed parameter to an int64. CLI callers pass int64 so
+// that large seeds keep their precision; seeds decoded from JSON arrive as float64.
+func seedParam(value interface{}) (int64, bool) {
+	return Int64Param(value)
+}
+
+// randFor returns the random source for a tool call and reports its name.
+// Seeded calls get a source of their own, which yields the same sequence on
+// every platform; secure calls draw from crypto/rand and other calls from the
+// global math/rand source.
+func randFor(ctx context.Context, params map[string]interface{}) *rand.Rand {
+	if secureFor(ctx, params) {
+		reportSource(ctx, SourceCrypto)
+		return rand.New(cryptoSource{})
+	}
+	if seed, ok := seedFor(ctx, params); ok {
+		reportSource(ctx, seededSource(seed))
+		return rand.New(rand.NewSource(seed))
+	}
+	reportSource(ctx, SourceMath)
+	return rand.New(globalSource{})
+}
+
+// clockFor returns the current time of a tool call. Seeded calls read seedTime
+// instead, so that default and relative time ranges are reproducible too.
+func clockFor(ctx context.Context, params map[string]interface{}) time.Time {
+	if !secureFor(ctx, params) {
+		if _, ok := seedFor(ctx, params); ok {
+			return seedTime
+		}
+	}
+	return time.Now()
+}
+
+// seededSource returns the reported name of the source seeded with seed
+func seededSource(seed int64) string {
+	return fmt.Sprintf("%s (seed %d)", SourceMath, seed)
+}
+
+// validateSeed checks the optional seed and secure parameters
+func validateSeed(params map[string]interface{}) error {
+	seed, hasSeed := params["seed"]
+	if hasSeed {
+		if _, ok := seedParam(seed); !ok {
+			return fmt.Errorf("seed parameter must be an integer")
+		}
+	}
+	if secure, ok := params["secure"]; ok {
+		secureBool, ok := secure.(bool)
+		if !ok {
+			return fmt.Errorf("secure parameter must be a boolean")
+		}
+		if secureBool && hasSeed {
+			return fmt.Errorf("seed and secure cannot be used together")
+		}
+	}
+	return nil
+}
+
+// seedParameter describes the seed parameter shared by the generating tools
+var seedParameter = ParameterDefinition{
+	Name:        "seed",
+	Type:        "integer",
+	Description: "Seed for the random source; the same seed always generates the same values (default: random)",
+	Required:    false,
+}
+
+// secureParameter describes the secure parameter shared by the generating tools
+var secureParameter = ParameterDefinition{
+	Name:        "secure",
+	Type:        "boolean",
+	Description: "Draw from the cryptographically secure crypto/rand instead of math/rand, e.g. for secrets and draws that must not be predictable (cannot be combined with seed)",
+	Required:    false,
+}
+
+// globalSource is a rand.Source backed by the top-level math/rand functions,
+// which are safe for concurrent use and randomly seeded
+type globalSource struct{}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer
+func (globalSource) Int63() int64 {
+	return rand.Int63()
+}
+
+// Uint64 returns a pseudo-random 64-bit integer
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64()
+}
+
+// Seed is a no-op; the global source is never reseeded
+func (globalSource) Seed(int64) {}
+
+// cryptoSource is a rand.Source backed by crypto/rand. The range methods of
+// rand.Rand, such as Intn and Int63n, reject the draws that would favour
+// low values, so sampling a range from this source stays unbiased.
+type cryptoSource struct{}
+
+// Int63 returns a non-negative random 63-bit integer
+func (s cryptoSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Uint64 returns a random 64-bit integer
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	// crypto/rand.Read never returns an error; it aborts the program when
+	// the operating system's generator fails
+	crand.Read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// Seed is a no-op; crypto/rand cannot be seeded
+func (cryptoSource) Seed(int64) {}
@@ -0,0 +1,300 @@
+package tools
+
+import (
+	"context"
+	"maps"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSeededGeneration(t *testing.T) {
+	tests := []struct {
+		name   string
+		tool   Tool
+		params map[string]interface{}
+	}{
+		{"random integer", NewRandomTool(), map[string]interface{}{"type": "integer", "count": 5.0}},
+		{"random float", NewRandomTool(), map[string]interface{}{"type": "float", "count": 5.0}},
+		{"random boolean", NewRandomTool(), map[string]interface{}{"type": "boolean", "count": 20.0}},
+		{"random password", NewRandomTool(), map[string]interface{}{"type": "password", "count": 3.0}},
+		{"random passphrase", NewRandomTool(), map[string]interface{}{"type": "passphrase", "count": 3.0}},
+		{"random normal", NewRandomTool(), map[string]interface{}{"type": "normal", "count": 5.0}},
+		{"random poisson", NewRandomTool(), map[string]interface{}{"type": "poisson", "lambda": 20.0, "count": 5.0}},
+		{"random dice", NewRandomTool(), map[string]interface{}{"type": "dice", "notation": "4d6dl1!+1d8r1", "count": 5.0}},
+		{"random sample", NewRandomTool(), map[string]interface{}{"type": "sample", "items": []interface{}{"a", "b", "c", "d"}, "k": 2.0, "count": 5.0}},
+		{"random bytes", NewRandomTool(), map[string]interface{}{"type": "bytes", "encoding": "base58", "prefix": "sk_", "count": 5.0}},
+		{"random datetime", NewRandomTool(), map[string]interface{}{"type": "datetime", "business-days": true, "count": 5.0}},
+		{"random duration", NewRandomTool(), map[string]interface{}{"type": "duration", "format": "iso", "count": 5.0}},
+		{"uuid v4", NewUUIDTool(), map[string]interface{}{"version": "v4", "count": 3.0}},
+		{"uuid v6", NewUUIDTool(), map[string]interface{}{"version": "v6", "count": 3.0}},
+		{"uuid v7", NewUUIDTool(), map[string]interface{}{"version": "v7", "count": 3.0}},
+		{"id ulid", NewIDTool(), map[string]interface{}{"count": 3.0}},
+		{"id ksuid", NewIDTool(), map[string]interface{}{"format": "ksuid", "count": 3.0}},
+		{"id nanoid", NewIDTool(), map[string]interface{}{"format": "nanoid", "count": 3.0}},
+		{"id typeid", NewIDTool(), map[string]interface{}{"format": "typeid", "prefix": "user", "count": 3.0}},
+		{"imo", NewIMOTool(), map[string]interface{}{"operation": "generate", "count": 5.0}},
+		{"mmsi", NewMMSITool(), map[string]interface{}{"operation": "generate", "count": 5.0}},
+		{"mmsi type", NewMMSITool(), map[string]interface{}{"operation": "generate", "type": "ship", "count": 5.0}},
+		{"creditcard", NewCreditCardTool(), map[string]interface{}{"operation": "generate", "count": 5.0}},
+		{"isbn", NewISBNTool(), map[string]interface{}{"operation": "generate", "format": "isbn10", "count": 5.0}},
+		{"ean13", NewEAN13Tool(), map[string]interface{}{"operation": "generate", "count": 5.0}},
+		{"iban", NewIBANTool(), map[string]interface{}{"operation": "generate", "count": 5.0}},
+	}
+
+	generate := func(t *testing.T, ctx context.Context, tool Tool, params map[string]interface{}) interface{} {
+		t.Helper()
+		result, err := AsContextTool(tool).ExecuteContext(ctx, params)
+		if err != nil {
+			t.Fatalf("ExecuteContext() error = %v", err)
+		}
+		return result
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			seeded := maps.Clone(tt.params)
+			seeded["seed"] = 42.0
+			if err := tt.tool.ValidateParams(seeded); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+
+			first := generate(t, ctx, tt.tool, seeded)
+			if second := generate(t, ctx, tt.tool, seeded); !reflect.DeepEqual(first, second) {
+				t.Errorf("Expected the same seed to generate %v, got %v", first, second)
+			}
+
+			// A seed attached to the context applies to calls without a seed parameter
+			if fromContext := generate(t, WithSeed(ctx, 42), tt.tool, tt.params); !reflect.DeepEqual(first, fromContext) {
+				t.Errorf("Expected the context seed to generate %v, got %v", first, fromContext)
+			}
+
+			// Seeds given as Go integers by CLI callers behave like JSON numbers
+			seeded["seed"] = int64(42)
+			if fromInt := generate(t, ctx, tt.tool, seeded); !reflect.DeepEqual(first, fromInt) {
+				t.Errorf("Expected an int64 seed to generate %v, got %v", first, fromInt)
+			}
+
+			seeded["seed"] = 43.0
+			if other := generate(t, ctx, tt.tool, seeded); reflect.DeepEqual(first, other) {
+				t.Errorf("Expected another seed to generate other values, got %v twice", other)
+			}
+		})
+	}
+}
+
+func TestSeededGenerationIsStable(t *testing.T) {
+	// Seeded output must not change between releases or platforms, since
+	// users commit it to their test fixtures
+	tests := []struct {
+		name     string
+		tool     Tool
+		params   map[string]interface{}
+		expected interface{}
+	}{
+		{"random integer", NewRandomTool(), map[string]interface{}{"count": 3.0, "seed": 42.0}, []int64{6, 61, 22}},
+		{"uuid v4", NewUUIDTool(), map[string]interface{}{"version": "v4", "seed": 42.0}, "538c7f96-b164-4f1b-97bb-9f4bb472e89f"},
+		{"uuid v6", NewUUIDTool(), map[string]interface{}{"version": "v6", "seed": 42.0}, "1d3bfde6-3b00-6000-938c-7f96b164bf1b"},
+		{"uuid v7", NewUUIDTool(), map[string]interface{}{"version": "v7", "seed": 42.0}, "00dc6acf-ac00-738c-bf96-b164bf1b97bb"},
+		{"id ulid", NewIDTool(), map[string]interface{}{"seed": 42.0}, "00VHNCZB00AE67Z5NHCJZHQ5XV"},
+		{"id typeid", NewIDTool(), map[string]interface{}{"format": "typeid", "prefix": "user", "seed": 42.0}, "user_00vhnczb00ee6bz5nhcjzhq5xv"},
+		{"iban", NewIBANTool(), map[string]interface{}{"operation": "generate", "seed": 42.0}, "NL86U3T8PN2UH24GAX"},
+		{"creditcard", NewCreditCardTool(), map[string]interface{}{"operation": "generate", "seed": 42.0}, "3578035768397582"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSeedValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		seed    interface{}
+		wantErr bool
+	}{
+		{"json number", 42.0, false},
+		{"negative", -7.0, false},
+		{"int64", int64(1) << 62, false},
+		{"fraction", 1.5, true},
+		{"string", "42", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRandomTool().ValidateParams(map[string]interface{}{"seed": tt.seed})
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "seed parameter must be an integer") {
+					t.Errorf("Expected a seed error, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUnseededUUIDsUseTheClock(t *testing.T) {
+	result, err := NewUUIDTool().Execute(map[string]interface{}{"version": "v7"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.HasPrefix(result.(string), "00dc6acf") {
+		t.Errorf("Expected an unseeded v7 UUID to carry the current time, got %v", result)
+	}
+}
+
+func TestRandomSourceSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      func(context.Context) context.Context
+		params   map[string]interface{}
+		expected string
+	}{
+		{"default", nil, map[string]interface{}{}, SourceMath},
+		{"secure parameter", nil, map[string]interface{}{"secure": true}, SourceCrypto},
+		{"seed parameter", nil, map[string]interface{}{"seed": 42.0}, "math/rand (seed 42)"},
+		{"server secure", WithSecure, map[string]interface{}{}, SourceCrypto},
+		{"seed parameter overrides server secure", WithSecure, map[string]interface{}{"seed": 7.0}, "math/rand (seed 7)"},
+		{"secure false overrides server secure", WithSecure, map[string]interface{}{"secure": false}, SourceMath},
+		{"server seed", func(ctx context.Context) context.Context { return WithSeed(ctx, 42) }, map[string]interface{}{}, "math/rand (seed 42)"},
+		{"secure parameter overrides server seed", func(ctx context.Context) context.Context { return WithSeed(ctx, 42) }, map[string]interface{}{"secure": true}, SourceCrypto},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			var reported []string
+			ctx = WithSourceReport(ctx, func(source string) { reported = append(reported, source) })
+
+			params := maps.Clone(tt.params)
+			params["type"] = "integer"
+			if _, err := NewRandomTool().ExecuteContext(ctx, params); err != nil {
+				t.Fatalf("ExecuteContext() error = %v", err)
+			}
+			if len(reported) != 1 || reported[0] != tt.expected {
+				t.Errorf("Expected source %q to be reported once, got %v", tt.expected, reported)
+			}
+		})
+	}
+
+	t.Run("uuids are always secure unless seeded", func(t *testing.T) {
+		var reported string
+		ctx := WithSourceReport(context.Background(), func(source string) { reported = source })
+		if _, err := NewUUIDTool().ExecuteContext(ctx, map[string]interface{}{"version": "v4"}); err != nil {
+			t.Fatalf("ExecuteContext() error = %v", err)
+		}
+		if reported != SourceCrypto {
+			t.Errorf("Expected source %q, got %q", SourceCrypto, reported)
+		}
+	})
+
+	t.Run("ids are secure unless seeded", func(t *testing.T) {
+		var reported string
+		ctx := WithSourceReport(context.Background(), func(source string) { reported = source })
+		if _, err := NewIDTool().ExecuteContext(ctx, map[string]interface{}{"format": "nanoid"}); err != nil {
+			t.Fatalf("ExecuteContext() error = %v", err)
+		}
+		if reported != SourceCrypto {
+			t.Errorf("Expected source %q, got %q", SourceCrypto, reported)
+		}
+	})
+}
+
+func TestSecureSourceIsUniform(t *testing.T) {
+	// Chi-squared goodness of fit against the uniform distribution. The
+	// critical values have a 0.01% chance of being exceeded by a fair source.
+	tests := []struct {
+		name     string
+		buckets  int
+		critical float64
+		draw     func(rng *rand.Rand) int
+	}{
+		// Six is not a power of two, so a naive modulo would be biased
+		{"die", 6, 25.74, func(rng *rand.Rand) int { return rng.Intn(6) }},
+		{"large range", 10, 33.72, func(rng *rand.Rand) int { return int(rng.Int63n(3_000_000_000) / 300_000_000) }},
+		{"float", 10, 33.72, func(rng *rand.Rand) int { return int(rng.Float64() * 10) }},
+	}
+
+	const draws = 60000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := randFor(context.Background(), map[string]interface{}{"secure": true})
+			counts := make([]int, tt.buckets)
+			for range draws {
+				counts[tt.draw(rng)]++
+			}
+
+			expected := float64(draws) / float64(tt.buckets)
+			chiSquared := 0.0
+			for _, count := range counts {
+				diff := float64(count) - expected
+				chiSquared += diff * diff / expected
+			}
+			if chiSquared > tt.critical {
+				t.Errorf("Distribution %v is not uniform: chi-squared %.2f exceeds %.2f", counts, chiSquared, tt.critical)
+			}
+		})
+	}
+
+	t.Run("random tool", func(t *testing.T) {
+		counts := make(map[int64]int)
+		for range 30 {
+			result, err := NewRandomTool().Execute(map[string]interface{}{"min": 1.0, "max": 6.0, "count": 1000.0, "secure": true})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, value := range result.([]int64) {
+				counts[value]++
+			}
+		}
+
+		chiSquared := 0.0
+		for value := int64(1); value <= 6; value++ {
+			diff := float64(counts[value]) - 5000
+			chiSquared += diff * diff / 5000
+		}
+		if len(counts) != 6 || chiSquared > 25.74 {
+			t.Errorf("Distribution %v is not uniform: chi-squared %.2f", counts, chiSquared)
+		}
+	})
+}
+
+func TestSecureValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"secure", map[string]interface{}{"secure": true}, ""},
+		{"insecure with seed", map[string]interface{}{"secure": false, "seed": 1.0}, ""},
+		{"secure with seed", map[string]interface{}{"secure": true, "seed": 1.0}, "seed and secure cannot be used together"},
+		{"not a boolean", map[string]interface{}{"secure": "yes"}, "secure parameter must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewIBANTool().ValidateParams(tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
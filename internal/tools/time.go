@@ -10,6 +10,9 @@ import (
 	"github.com/ijt/go-anytime"
 )
 
+// timeFormats lists the supported output formats
+var timeFormats = []string{"iso", "rfc3339", "unix", "date", "datetime", "time", "weekday"}
+
 // TimeTool implements comprehensive time functionality
 type TimeTool struct{}
 
@@ -25,7 +28,18 @@ func (t *TimeTool) Name() string {
 
 // Description returns the tool's description
 func (t *TimeTool) Description() string {
-	return "Comprehensive time utility with parsing, formatting, and calculations. Note: Dates before year 1000 are not supported due to parsing library limitations."
+	return "Comprehensive time utility with parsing, formatting, and calculations. Note: Dates before year 1000 are only supported as RFC 3339 timestamps due to parsing library limitations."
+}
+
+// Annotations returns the tool's behavioural hints for clients
+func (t *TimeTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "Time",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"time", "utility"},
+	}
 }
 
 // Execute runs the time tool
@@ -49,21 +63,9 @@ func (t *TimeTool) Execute(params map[string]interface{}) (interface{}, error) {
 	var err error
 
 	if input != "" {
-		// Parse the input timestamp using go-anytime
-		// Use UTC as reference to avoid timezone confusion
-		baseTime, err = anytime.Parse(input, time.Now().UTC())
+		baseTime, err = parseTimeInput(input, time.Now())
 		if err != nil {
-			// Provide helpful hints for common parsing issues
-			errorMsg := fmt.Sprintf("failed to parse timestamp: %v", err)
-
-			// Add hints for common issues
-			if strings.Contains(err.Error(), "expected natural date") {
-				errorMsg += "\n\nHint: If you were trying to parse a year prior to 1000, note that dates before year 1000 are not supported. Otherwise, try using a more standard date format like 'YYYY-MM-DD' or 'January 1, 2025'."
-			} else if strings.Contains(err.Error(), "left unparsed") {
-				errorMsg += "\n\nHint: Try using a more standard date format like 'YYYY-MM-DD' or 'January 1, 2025'."
-			}
-
-			return nil, fmt.Errorf("%s", errorMsg)
+			return nil, err
 		}
 	} else {
 		// No input provided, use current time
@@ -72,15 +74,11 @@ func (t *TimeTool) Execute(params map[string]interface{}) (interface{}, error) {
 
 	// Apply timezone
 	if timezone != "local" {
-		if timezone == "utc" {
-			baseTime = baseTime.UTC()
-		} else {
-			loc, err := time.LoadLocation(timezone)
-			if err != nil {
-				return nil, fmt.Errorf("invalid timezone: %s", timezone)
-			}
-			baseTime = baseTime.In(loc)
+		loc, err := loadTimezone(timezone)
+		if err != nil {
+			return nil, err
 		}
+		baseTime = baseTime.In(loc)
 	}
 
 	// Apply offset if provided
@@ -101,6 +99,47 @@ func (t *TimeTool) Execute(params map[string]interface{}) (interface{}, error) {
 	return result, nil
 }
 
+// parseTimeInput parses a timestamp with go-anytime, resolving relative
+// expressions such as "3 days ago" against now. RFC 3339 timestamps are parsed
+// directly, since go-anytime rejects fractional seconds
+func parseTimeInput(input string, now time.Time) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339Nano, input); err == nil {
+		return parsed, nil
+	}
+
+	// Use UTC as reference to avoid timezone confusion
+	parsed, err := anytime.Parse(input, now.UTC())
+	if err != nil {
+		// Provide helpful hints for common parsing issues
+		errorMsg := fmt.Sprintf("failed to parse timestamp: %v", err)
+
+		// Add hints for common issues
+		if strings.Contains(err.Error(), "expected natural date") {
+			errorMsg += "\n\nHint: If you were trying to parse a year prior to 1000, note that dates before year 1000 are only supported as RFC 3339 timestamps like '0001-01-01T00:00:00Z'. Otherwise, try using a more standard date format like 'YYYY-MM-DD' or 'January 1, 2025'."
+		} else if strings.Contains(err.Error(), "left unparsed") {
+			errorMsg += "\n\nHint: Try using a more standard date format like 'YYYY-MM-DD' or 'January 1, 2025'."
+		}
+
+		return time.Time{}, fmt.Errorf("%s", errorMsg)
+	}
+	return parsed, nil
+}
+
+// loadTimezone returns the location of a timezone parameter: utc, local, or an IANA timezone name
+func loadTimezone(timezone string) (*time.Location, error) {
+	switch timezone {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", timezone)
+	}
+	return loc, nil
+}
+
 // formatTime formats a time according to the specified format
 func (t *TimeTool) formatTime(tm time.Time, format string) (string, error) {
 	switch format {
@@ -130,9 +169,8 @@ func (t *TimeTool) ValidateParams(params map[string]interface{}) error {
 	// Validate format
 	if format, ok := params["format"]; ok {
 		if formatStr, ok := format.(string); ok {
-			validFormats := []string{"iso", "rfc3339", "unix", "date", "datetime", "time", "weekday"}
-			if !contains(validFormats, formatStr) {
-				return fmt.Errorf("invalid format: %s, must be one of: %s", formatStr, strings.Join(validFormats, ", "))
+			if !contains(timeFormats, formatStr) {
+				return fmt.Errorf("invalid format: %s, must be one of: %s", formatStr, strings.Join(timeFormats, ", "))
 			}
 		} else {
 			return fmt.Errorf("format parameter must be a string")
@@ -142,11 +180,8 @@ func (t *TimeTool) ValidateParams(params map[string]interface{}) error {
 	// Validate timezone
 	if timezone, ok := params["timezone"]; ok {
 		if timezoneStr, ok := timezone.(string); ok {
-			if timezoneStr != "utc" && timezoneStr != "local" {
-				// Try to load the timezone to validate it
-				if _, err := time.LoadLocation(timezoneStr); err != nil {
-					return fmt.Errorf("invalid timezone: %s", timezoneStr)
-				}
+			if _, err := loadTimezone(timezoneStr); err != nil {
+				return err
 			}
 		} else {
 			return fmt.Errorf("timezone parameter must be a string")
@@ -185,7 +220,7 @@ func (t *TimeTool) GetInputSchema() map[string]interface{} {
 		{
 			Name:        "input",
 			Type:        "string",
-			Description: "Input timestamp (any format supported by go-anytime). Note: Dates before year 1000 are not supported.",
+			Description: "Input timestamp (any format supported by go-anytime). Note: Dates before year 1000 are only supported as RFC 3339 timestamps.",
 			Required:    false,
 		},
 		{
@@ -334,6 +369,61 @@ func (t *TimeTool) ReadResource(uri string) (string, error) {
 	}
 }
 
+// CompleteParam returns the candidate values of a parameter for argument completion
+func (t *TimeTool) CompleteParam(name string) []Completion {
+	var completions []Completion
+	switch name {
+	case "format":
+		for _, format := range timeFormats {
+			completions = append(completions, Completion{Value: format})
+		}
+	case "timezone":
+		completions = append(completions, Completion{Value: "utc"}, Completion{Value: "local"})
+		for _, zone := range TimezoneNames() {
+			completions = append(completions, Completion{Value: zone})
+		}
+	}
+	return completions
+}
+
+// meetingTimeTemplate is the template of the convert-meeting-time prompt
+const meetingTimeTemplate = `Convert a meeting time across timezones.
+
+The meeting is at {{.time}} in the {{.from_timezone}} timezone. Convert it to each of these timezones: {{.to_timezones}}.
+
+Use the "time" tool for all conversions instead of calculating them yourself:
+1. Call the tool with input "{{.time}}", timezone "{{.from_timezone}}" and format "iso" to find the UTC offset in effect in {{.from_timezone}} on that date, and write the meeting time as an ISO 8601 timestamp with that offset.
+2. For each target timezone, call the tool with that timestamp as input, the target timezone and format "datetime", and once more with format "weekday".
+
+Present the results as a table with the columns timezone, local date and time, and weekday. Point out times outside regular working hours (08:00 to 18:00) and meetings that fall on a different day than in {{.from_timezone}}.`
+
+// GetPrompts returns the list of prompts this tool provides
+func (t *TimeTool) GetPrompts() []Prompt {
+	return []Prompt{
+		{
+			Name:        "convert-meeting-time",
+			Title:       "Convert Meeting Time",
+			Description: "Convert a meeting time to several timezones and flag inconvenient local times",
+			Arguments: []PromptArgument{
+				{Name: "time", Description: "Meeting date and time, e.g. '2025-03-14 15:00' or 'next Monday 9am'", Required: true},
+				{Name: "from_timezone", Description: "IANA timezone the meeting time is given in, e.g. Europe/Oslo", Required: true, Tool: "time", Param: "timezone"},
+				{Name: "to_timezones", Description: "Comma-separated IANA timezones to convert to, e.g. America/New_York, Asia/Tokyo", Required: true, Tool: "time", Param: "timezone", List: true},
+			},
+			Tools: []string{"time"},
+		},
+	}
+}
+
+// GetPrompt renders a specific prompt by name
+func (t *TimeTool) GetPrompt(name string, arguments map[string]string) (string, error) {
+	switch name {
+	case "convert-meeting-time":
+		return renderPrompt(t.GetPrompts()[0], meetingTimeTemplate, arguments)
+	default:
+		return "", fmt.Errorf("unknown prompt: %s", name)
+	}
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -345,9 +345,12 @@ func TestTimeToolEdgeCases(t *testing.T) {
 				"input":  "0001-01-01T00:00:00Z",
 				"format": "weekday",
 			},
-			wantErr: true,
+			wantErr: false,
 			validate: func(t *testing.T, result interface{}) {
-				// This should fail due to go-anytime library limitations
+				// RFC 3339 timestamps are parsed without go-anytime
+				if result != "Monday, January 1, 0001" {
+					t.Errorf("Expected weekday 'Monday, January 1, 0001', got: %v", result)
+				}
 			},
 		},
 		{
@@ -356,9 +359,12 @@ func TestTimeToolEdgeCases(t *testing.T) {
 				"input":  "0000-01-01T00:00:00Z",
 				"format": "weekday",
 			},
-			wantErr: true,
+			wantErr: false,
 			validate: func(t *testing.T, result interface{}) {
-				// This should fail due to go-anytime library limitations
+				// RFC 3339 timestamps are parsed without go-anytime
+				if result != "Saturday, January 1, 0000" {
+					t.Errorf("Expected weekday 'Saturday, January 1, 0000', got: %v", result)
+				}
 			},
 		},
 		{
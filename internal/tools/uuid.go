@@ -1,15 +1,28 @@
 package tools
 
 import (
+	"context"
 	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// uuidVersions lists the values of the version parameter
+var uuidVersions = []string{"v1", "v3", "v4", "v5", "v6", "v7", "v8", "validate"}
+
+// gregorianOffset is the number of 100-nanosecond intervals between the start
+// of the Gregorian calendar, 1582-10-15, and the Unix epoch; v1 and v6 UUIDs
+// count their timestamps from the former
+const gregorianOffset = 122192928000000000
+
 // UUIDTool implements comprehensive UUID generation and validation
 type UUIDTool struct{}
 
@@ -25,11 +38,27 @@ func (u *UUIDTool) Name() string {
 
 // Description returns the tool's description
 func (u *UUIDTool) Description() string {
-	return "Generate and validate UUIDs with various versions (v1, v4, v5, v7)"
+	return "Generate and validate UUIDs with various versions (v1, v3, v4, v5, v6, v7, v8)"
+}
+
+// Annotations returns the tool's behavioural hints for clients
+func (u *UUIDTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "UUID",
+		ReadOnly:   true,
+		Idempotent: false,
+		OpenWorld:  false,
+		Tags:       []string{"identifiers", "generation", "validation"},
+	}
 }
 
 // Execute runs the UUID tool
 func (u *UUIDTool) Execute(params map[string]interface{}) (interface{}, error) {
+	return u.ExecuteContext(context.Background(), params)
+}
+
+// ExecuteContext runs the UUID tool, stopping early when the context is cancelled
+func (u *UUIDTool) ExecuteContext(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	// Parse parameters
 	version, _ := params["version"].(string)
 	if version == "" {
@@ -49,29 +78,98 @@ func (u *UUIDTool) Execute(params map[string]interface{}) (interface{}, error) {
 	// Execute based on version
 	switch version {
 	case "v1":
-		return u.generateV1(int(count))
+		return u.generateV1(ctx, int(count))
+	case "v3":
+		return u.generateNameBased(ctx, params, 3, int(count))
 	case "v4":
-		return u.generateV4(int(count))
+		random, _, _ := u.randomSource(ctx, params)
+		return u.generateV4(ctx, random, int(count))
 	case "v5":
-		return u.generateV5(params, int(count))
+		return u.generateNameBased(ctx, params, 5, int(count))
+	case "v6":
+		random, clock, order := u.randomSource(ctx, params)
+		clock, _, err := u.withTimestamp(params, clock, order)
+		if err != nil {
+			return nil, err
+		}
+		return u.generateV6(ctx, random, clock, int(count))
 	case "v7":
-		return u.generateV7(int(count))
+		random, clock, order := u.randomSource(ctx, params)
+		clock, order, err := u.withTimestamp(params, clock, order)
+		if err != nil {
+			return nil, err
+		}
+		return u.generateV7(ctx, random, clock, order, int(count))
+	case "v8":
+		return u.generateV8(params, int(count))
 	case "validate":
 		return u.validateUUID(params)
 	default:
-		return nil, fmt.Errorf("invalid version: %s, must be one of: v1, v4, v5, v7, validate", version)
+		return nil, fmt.Errorf("invalid version: %s, must be one of: %s", version, strings.Join(uuidVersions, ", "))
+	}
+}
+
+// randomSource returns the source of random bits, the clock and the generator
+// ordering the v7 UUIDs of a call. Seeded calls draw from the seeded source,
+// read a fixed clock and order their v7 UUIDs within the call so that they are
+// reproducible; other calls use crypto/rand, whether or not they ask to be
+// secure, and the current time, and their v7 UUIDs sort in order across calls.
+func (u *UUIDTool) randomSource(ctx context.Context, params map[string]interface{}) (io.Reader, func() time.Time, *v7Generator) {
+	if _, ok := seedFor(ctx, params); ok && !secureFor(ctx, params) {
+		return randFor(ctx, params), func() time.Time { return seedTime }, newV7Generator()
 	}
+	reportSource(ctx, SourceCrypto)
+	return rand.Reader, time.Now, processV7
+}
+
+// withTimestamp replaces the clock with the timestamp parameter when it is
+// given, e.g. to backfill historical data. The v7 UUIDs of such a call are
+// ordered within the call only, so that they do not hold back the UUIDs of the
+// current time generated after them.
+func (u *UUIDTool) withTimestamp(params map[string]interface{}, clock func() time.Time, order *v7Generator) (func() time.Time, *v7Generator, error) {
+	timestamp, ok, err := timeParam(params, "timestamp", time.Now())
+	if err != nil || !ok {
+		return clock, order, err
+	}
+	version, _ := params["version"].(string)
+	if err := u.validateTimestamp(version, timestamp); err != nil {
+		return nil, nil, err
+	}
+	return func() time.Time { return timestamp }, newV7Generator(), nil
+}
+
+// validateTimestamp checks that a custom timestamp fits the timestamp field of
+// v6 UUIDs, 60 bits of 100 ns ticks since 1582-10-15, or of v7 UUIDs, 48 bits
+// of milliseconds since 1970-01-01
+func (u *UUIDTool) validateTimestamp(version string, timestamp time.Time) error {
+	switch version {
+	case "v6":
+		if timestamp.Year() < 1582 || timestamp.Year() > 5236 || uuidTicks(timestamp) < 0 || uuidTicks(timestamp) >= 1<<60 {
+			return fmt.Errorf("timestamp must be between 1582-10-15 and 5236-03-31 for UUID v6")
+		}
+	case "v7":
+		if timestamp.Before(time.Unix(0, 0)) || timestamp.Year() > 10889 || timestamp.UnixMilli() >= 1<<48 {
+			return fmt.Errorf("timestamp must be between 1970-01-01 and the year 10889 for UUID v7")
+		}
+	default:
+		return fmt.Errorf("timestamp is only supported by UUID v6 and v7")
+	}
+	return nil
 }
 
 // generateV1 generates UUID v1 (time-based)
-func (u *UUIDTool) generateV1(count int) (interface{}, error) {
+func (u *UUIDTool) generateV1(ctx context.Context, count int) (interface{}, error) {
 	var results []string
-	for range count {
+	err := generateEach(ctx, count, func(int) error {
 		id, err := uuid.NewUUID()
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate UUID v1: %v", err)
+			return fmt.Errorf("failed to generate UUID v1: %v", err)
 		}
 		results = append(results, id.String())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return single value if count is 1, otherwise return array
@@ -82,14 +180,18 @@ func (u *UUIDTool) generateV1(count int) (interface{}, error) {
 }
 
 // generateV4 generates UUID v4 (random)
-func (u *UUIDTool) generateV4(count int) (interface{}, error) {
+func (u *UUIDTool) generateV4(ctx context.Context, random io.Reader, count int) (interface{}, error) {
 	var results []string
-	for range count {
-		id, err := uuid.NewRandom()
+	err := generateEach(ctx, count, func(int) error {
+		id, err := uuid.NewRandomFromReader(random)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate UUID v4: %v", err)
+			return fmt.Errorf("failed to generate UUID v4: %v", err)
 		}
 		results = append(results, id.String())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return single value if count is 1, otherwise return array
@@ -99,16 +201,16 @@ func (u *UUIDTool) generateV4(count int) (interface{}, error) {
 	return results, nil
 }
 
-// generateV5 generates UUID v5 (name-based SHA-1)
-func (u *UUIDTool) generateV5(params map[string]interface{}, count int) (interface{}, error) {
+// generateNameBased generates UUID v3 (name-based MD5) or v5 (name-based SHA-1)
+func (u *UUIDTool) generateNameBased(ctx context.Context, params map[string]interface{}, version int, count int) (interface{}, error) {
 	namespace, _ := params["namespace"].(string)
 	name, _ := params["name"].(string)
 
 	if namespace == "" {
-		return nil, fmt.Errorf("namespace parameter is required for UUID v5")
+		return nil, fmt.Errorf("namespace parameter is required for UUID v%d", version)
 	}
 	if name == "" {
-		return nil, fmt.Errorf("name parameter is required for UUID v5")
+		return nil, fmt.Errorf("name parameter is required for UUID v%d", version)
 	}
 
 	// Parse namespace UUID
@@ -118,7 +220,7 @@ func (u *UUIDTool) generateV5(params map[string]interface{}, count int) (interfa
 	}
 
 	var results []string
-	for i := range count {
+	err = generateEach(ctx, count, func(i int) error {
 		// For multiple generations, append index to make them unique
 		uniqueName := name
 		if count > 1 {
@@ -126,7 +228,14 @@ func (u *UUIDTool) generateV5(params map[string]interface{}, count int) (interfa
 		}
 
 		id := uuid.NewSHA1(namespaceUUID, []byte(uniqueName))
+		if version == 3 {
+			id = uuid.NewMD5(namespaceUUID, []byte(uniqueName))
+		}
 		results = append(results, id.String())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return single value if count is 1, otherwise return array
@@ -136,17 +245,68 @@ func (u *UUIDTool) generateV5(params map[string]interface{}, count int) (interfa
 	return results, nil
 }
 
-// generateV7 generates UUID v7 (time-ordered)
-func (u *UUIDTool) generateV7(count int) (interface{}, error) {
+// generateV6 generates UUID v6 (reordered time), the fields of v1 with the
+// timestamp's most significant bits first so that the UUIDs sort by time
+func (u *UUIDTool) generateV6(ctx context.Context, random io.Reader, clock func() time.Time, count int) (interface{}, error) {
+	// As RFC 9562 suggests, the clock sequence and node are random rather
+	// than a MAC address; one draw serves the whole batch
+	var seqNode [8]byte
+	if _, err := io.ReadFull(random, seqNode[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate UUID v6: %v", err)
+	}
+
 	var results []string
-	for range count {
-		// Generate UUID v7 using time-based approach
-		// Note: Go's uuid package doesn't have v7 yet, so we'll implement a basic version
-		id, err := u.generateV7Impl()
+	last := int64(0)
+	err := generateEach(ctx, count, func(int) error {
+		// Timestamps count in 100 ns ticks, so a fast batch can read the same
+		// tick twice; moving on to the next keeps the UUIDs unique and ordered
+		ticks := max(uuidTicks(clock()), last+1)
+		last = ticks
+		results = append(results, makeV6(ticks, seqNode).String())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return single value if count is 1, otherwise return array
+	if count == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// makeV6 lays out a UUID v6 from its timestamp in 100 ns ticks since
+// 1582-10-15 and the random bits of its clock sequence and node
+func makeV6(ticks int64, seqNode [8]byte) uuid.UUID {
+	var id uuid.UUID
+	binary.BigEndian.PutUint32(id[0:], uint32(ticks>>28))
+	binary.BigEndian.PutUint16(id[4:], uint16(ticks>>12))
+	binary.BigEndian.PutUint16(id[6:], 0x6000|uint16(ticks&0xfff))
+	copy(id[8:], seqNode[:])
+	id[8] = 0x80 | id[8]&0x3f // Set variant bits
+	id[10] |= 0x01            // Mark the node as random with the multicast bit
+	return id
+}
+
+// uuidTicks converts a time to the 100 ns ticks since 1582-10-15 of v1 and v6 UUIDs
+func uuidTicks(t time.Time) int64 {
+	return t.Unix()*10000000 + int64(t.Nanosecond()/100) + gregorianOffset
+}
+
+// generateV7 generates UUID v7 (time-ordered), in order through the generator
+func (u *UUIDTool) generateV7(ctx context.Context, random io.Reader, clock func() time.Time, order *v7Generator, count int) (interface{}, error) {
+	var results []string
+	err := generateEach(ctx, count, func(int) error {
+		id, err := order.next(random, clock())
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate UUID v7: %v", err)
+			return fmt.Errorf("failed to generate UUID v7: %v", err)
 		}
-		results = append(results, id)
+		results = append(results, id.String())
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return single value if count is 1, otherwise return array
@@ -156,45 +316,92 @@ func (u *UUIDTool) generateV7(count int) (interface{}, error) {
 	return results, nil
 }
 
-// generateV7Impl implements a basic UUID v7 generation
-func (u *UUIDTool) generateV7Impl() (string, error) {
-	// UUID v7: 48-bit timestamp + 12-bit random + 4-bit version + 2-bit variant + 62-bit random
-	now := time.Now()
-	timestamp := now.UnixMilli()
+// v7Generator lays out v7 UUIDs that sort in the order they are generated,
+// with RFC 9562's fixed-length dedicated counter method: the 12 bits of
+// rand_a count the UUIDs within a millisecond, starting from a random value
+// below 2048 so that at least 2048 UUIDs fit each millisecond. When the
+// counter runs out, or the clock goes back, the UUIDs carry on from the last
+// timestamp rather than break the order.
+type v7Generator struct {
+	mu      sync.Mutex
+	millis  int64
+	counter uint16
+}
+
+// processV7 orders the v7 UUIDs of every call in the process that reads the current time
+var processV7 = newV7Generator()
 
-	// Generate random bytes
+// newV7Generator creates a generator that has not generated any UUIDs yet
+func newV7Generator() *v7Generator {
+	return &v7Generator{millis: -1}
+}
+
+// next returns the next UUID v7 for the time now, drawing its random bits from random
+func (g *v7Generator) next(random io.Reader, now time.Time) (uuid.UUID, error) {
+	var id uuid.UUID
 	randomBytes := make([]byte, 10)
-	if _, err := rand.Read(randomBytes); err != nil {
-		return "", err
-	}
-
-	// Construct UUID v7
-	// First 6 bytes: timestamp (48 bits)
-	uuidBytes := make([]byte, 16)
-	uuidBytes[0] = byte(timestamp >> 40)
-	uuidBytes[1] = byte(timestamp >> 32)
-	uuidBytes[2] = byte(timestamp >> 24)
-	uuidBytes[3] = byte(timestamp >> 16)
-	uuidBytes[4] = byte(timestamp >> 8)
-	uuidBytes[5] = byte(timestamp)
-
-	// Next 2 bytes: random (12 bits) + version (4 bits)
-	uuidBytes[6] = randomBytes[0] & 0x0F // Clear top 4 bits
-	uuidBytes[6] |= 0x70                 // Set version to 7
-	uuidBytes[7] = randomBytes[1]
-
-	// Next byte: variant (2 bits) + random (6 bits)
-	uuidBytes[8] = randomBytes[2] & 0x3F // Clear top 2 bits
-	uuidBytes[8] |= 0x80                 // Set variant bits
-
-	// Remaining 7 bytes: random
-	copy(uuidBytes[9:], randomBytes[3:])
-
-	// Convert to UUID string
-	id := uuid.UUID(uuidBytes)
+	if _, err := io.ReadFull(random, randomBytes); err != nil {
+		return id, err
+	}
+	start := binary.BigEndian.Uint16(randomBytes) & 0x07ff
+
+	g.mu.Lock()
+	switch millis := now.UnixMilli(); {
+	case millis > g.millis:
+		g.millis, g.counter = millis, start
+	case g.counter == 0x0fff:
+		// Borrow the next millisecond rather than wrap around
+		g.millis, g.counter = g.millis+1, start
+	default:
+		g.counter++
+	}
+	millis, counter := g.millis, g.counter
+	g.mu.Unlock()
+
+	if millis < 0 || millis >= 1<<48 {
+		return id, fmt.Errorf("the time %s is outside the range of v7 timestamps", time.UnixMilli(millis).UTC().Format(time.RFC3339Nano))
+	}
+
+	// 48-bit timestamp, 4-bit version and 12-bit counter, 2-bit variant and 62 random bits
+	binary.BigEndian.PutUint16(id[0:], uint16(millis>>32))
+	binary.BigEndian.PutUint32(id[2:], uint32(millis))
+	binary.BigEndian.PutUint16(id[6:], 0x7000|counter)
+	copy(id[8:], randomBytes[2:])
+	id[8] = 0x80 | id[8]&0x3f // Set variant bits
+	return id, nil
+}
+
+// generateV8 generates UUID v8 (custom) from the caller's bits, setting the
+// version and variant bits
+func (u *UUIDTool) generateV8(params map[string]interface{}, count int) (interface{}, error) {
+	custom, _ := params["custom"].(string)
+	if custom == "" {
+		return nil, fmt.Errorf("custom parameter is required for UUID v8")
+	}
+	if count > 1 {
+		return nil, fmt.Errorf("count must be 1 for UUID v8, whose bits all come from the custom parameter")
+	}
+	id, err := parseV8Custom(custom)
+	if err != nil {
+		return nil, err
+	}
 	return id.String(), nil
 }
 
+// parseV8Custom builds a UUID v8 from 32 hex digits, with or without the
+// hyphens of a UUID; the version and variant bits are overwritten
+func parseV8Custom(custom string) (uuid.UUID, error) {
+	var id uuid.UUID
+	data, err := hex.DecodeString(strings.ReplaceAll(custom, "-", ""))
+	if err != nil || len(data) != len(id) {
+		return id, fmt.Errorf("invalid custom bits: %s, expected 32 hex digits such as 0123456789abcdef0123456789abcdef", custom)
+	}
+	copy(id[:], data)
+	id[6] = 0x80 | id[6]&0x0f // Set version to 8
+	id[8] = 0x80 | id[8]&0x3f // Set variant bits
+	return id, nil
+}
+
 // validateUUID validates a UUID string
 func (u *UUIDTool) validateUUID(params map[string]interface{}) (interface{}, error) {
 	input, _ := params["input"].(string)
@@ -223,20 +430,31 @@ func (u *UUIDTool) validateUUID(params map[string]interface{}) (interface{}, err
 	// Extract additional information based on version
 	switch version {
 	case 1:
-		// UUID v1: Extract timestamp and MAC address
-		timestamp := u.extractV1Timestamp(id)
-		result["timestamp"] = timestamp
+		// UUID v1: Extract timestamp, clock sequence and MAC address
+		result["timestamp"] = u.extractV1Timestamp(id)
+		result["clock_sequence"] = id.ClockSequence()
+		result["node"] = u.extractV1MAC(id)
 		result["mac_address"] = u.extractV1MAC(id)
-	case 5:
-		// UUID v5: Extract namespace (if possible to determine)
+	case 3, 5:
+		// UUID v3 and v5: Extract namespace (if possible to determine)
 		namespace := u.extractV5Namespace()
 		if namespace != "" {
 			result["namespace"] = namespace
 		}
+	case 6:
+		// UUID v6: Extract timestamp, clock sequence and node, laid out as in v1
+		result["timestamp"] = u.extractV6Timestamp(id)
+		result["clock_sequence"] = id.ClockSequence()
+		result["node"] = u.extractV1MAC(id)
 	case 7:
 		// UUID v7: Extract timestamp
 		timestamp := u.extractV7Timestamp(id)
 		result["timestamp"] = timestamp
+	case 8:
+		// UUID v8: Extract the three custom fields around the version and variant bits
+		result["custom_a"] = hex.EncodeToString(id[0:6])
+		result["custom_b"] = fmt.Sprintf("%03x", binary.BigEndian.Uint16(id[6:8])&0x0fff)
+		result["custom_c"] = fmt.Sprintf("%016x", binary.BigEndian.Uint64(id[8:16])&0x3fffffffffffffff)
 	}
 
 	return result, nil
@@ -244,25 +462,29 @@ func (u *UUIDTool) validateUUID(params map[string]interface{}) (interface{}, err
 
 // extractV1Timestamp extracts the timestamp from a UUID v1
 func (u *UUIDTool) extractV1Timestamp(id uuid.UUID) string {
-	// UUID v1 timestamp is in the first 8 bytes (60 bits)
-	// Convert to Unix timestamp (seconds since epoch)
-	bytes := id[:8]
-
-	// The timestamp in UUID v1 is in 100-nanosecond intervals since 1582-10-15 00:00:00 UTC
-	// We need to convert this to Unix timestamp
-	// UUID v1 epoch: 1582-10-15 00:00:00 UTC
-	// Unix epoch: 1970-01-01 00:00:00 UTC
-	// Difference: 122192928000000000 nanoseconds
-
-	// Read the timestamp as big-endian
-	timestamp := int64(bytes[0])<<40 | int64(bytes[1])<<32 | int64(bytes[2])<<24 |
-		int64(bytes[3])<<16 | int64(bytes[4])<<8 | int64(bytes[5])
+	// UUID v1 stores its 60-bit timestamp low field first: time_low in
+	// bytes 0-3, time_mid in bytes 4-5 and time_hi below the version in 6-7
+	ticks := int64(binary.BigEndian.Uint32(id[0:4])) |
+		int64(binary.BigEndian.Uint16(id[4:6]))<<32 |
+		int64(binary.BigEndian.Uint16(id[6:8])&0x0fff)<<48
+	return ticksTimestamp(ticks)
+}
 
-	// Convert from 100-nanosecond intervals to seconds
-	// and adjust for the epoch difference
-	unixTimestamp := (timestamp - 122192928000000000) / 10000000
+// extractV6Timestamp extracts the timestamp from a UUID v6
+func (u *UUIDTool) extractV6Timestamp(id uuid.UUID) string {
+	// UUID v6 stores the same timestamp as v1 most significant bits first,
+	// with the version between its middle and low 12 bits
+	ticks := int64(binary.BigEndian.Uint32(id[0:4]))<<28 |
+		int64(binary.BigEndian.Uint16(id[4:6]))<<12 |
+		int64(binary.BigEndian.Uint16(id[6:8])&0x0fff)
+	return ticksTimestamp(ticks)
+}
 
-	return time.Unix(unixTimestamp, 0).UTC().Format(time.RFC3339)
+// ticksTimestamp formats a v1 or v6 timestamp, counted in 100-nanosecond
+// intervals since 1582-10-15 00:00:00 UTC, in RFC 3339
+func ticksTimestamp(ticks int64) string {
+	unixTicks := ticks - gregorianOffset
+	return time.Unix(unixTicks/10000000, unixTicks%10000000*100).UTC().Format(time.RFC3339Nano)
 }
 
 // extractV1MAC extracts the MAC address from a UUID v1
@@ -295,7 +517,7 @@ func (u *UUIDTool) extractV7Timestamp(id uuid.UUID) string {
 	unixTimestamp := timestamp / 1000
 	nanoSeconds := (timestamp % 1000) * 1000000
 
-	return time.Unix(unixTimestamp, nanoSeconds).UTC().Format(time.RFC3339)
+	return time.Unix(unixTimestamp, nanoSeconds).UTC().Format(time.RFC3339Nano)
 }
 
 // ValidateParams validates the input parameters
@@ -303,9 +525,8 @@ func (u *UUIDTool) ValidateParams(params map[string]interface{}) error {
 	// Validate version
 	if version, ok := params["version"]; ok {
 		if versionStr, ok := version.(string); ok {
-			validVersions := []string{"v1", "v4", "v5", "v7", "validate"}
-			if !contains(validVersions, versionStr) {
-				return fmt.Errorf("invalid version: %s, must be one of: %s", versionStr, strings.Join(validVersions, ", "))
+			if !contains(uuidVersions, versionStr) {
+				return fmt.Errorf("invalid version: %s, must be one of: %s", versionStr, strings.Join(uuidVersions, ", "))
 			}
 		} else {
 			return fmt.Errorf("version parameter must be a string")
@@ -323,9 +544,9 @@ func (u *UUIDTool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	// Validate namespace for v5
+	// Validate namespace for v3 and v5
 	if version, ok := params["version"]; ok {
-		if versionStr, ok := version.(string); ok && versionStr == "v5" {
+		if versionStr, ok := version.(string); ok && (versionStr == "v3" || versionStr == "v5") {
 			if namespace, ok := params["namespace"]; ok {
 				if namespaceStr, ok := namespace.(string); ok {
 					if _, err := uuid.Parse(namespaceStr); err != nil {
@@ -338,9 +559,9 @@ func (u *UUIDTool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	// Validate name for v5
+	// Validate name for v3 and v5
 	if version, ok := params["version"]; ok {
-		if versionStr, ok := version.(string); ok && versionStr == "v5" {
+		if versionStr, ok := version.(string); ok && (versionStr == "v3" || versionStr == "v5") {
 			if name, ok := params["name"]; ok {
 				if _, ok := name.(string); !ok {
 					return fmt.Errorf("name parameter must be a string")
@@ -349,6 +570,36 @@ func (u *UUIDTool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
+	// Validate custom bits for v8
+	if version, ok := params["version"]; ok {
+		if versionStr, ok := version.(string); ok && versionStr == "v8" {
+			if custom, ok := params["custom"]; ok {
+				customStr, ok := custom.(string)
+				if !ok {
+					return fmt.Errorf("custom parameter must be a string")
+				}
+				if _, err := parseV8Custom(customStr); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Validate the custom timestamp of v6 and v7
+	if _, ok := params["timestamp"]; ok {
+		timestamp, _, err := timeParam(params, "timestamp", time.Now())
+		if err != nil {
+			return err
+		}
+		version, _ := params["version"].(string)
+		if version == "" {
+			version = "v4"
+		}
+		if err := u.validateTimestamp(version, timestamp); err != nil {
+			return err
+		}
+	}
+
 	// Validate input for validation
 	if version, ok := params["version"]; ok {
 		if versionStr, ok := version.(string); ok && versionStr == "validate" {
@@ -360,7 +611,7 @@ func (u *UUIDTool) ValidateParams(params map[string]interface{}) error {
 		}
 	}
 
-	return nil
+	return validateSeed(params)
 }
 
 // GetInputSchema returns the JSON schema for tool input parameters
@@ -369,7 +620,7 @@ func (u *UUIDTool) GetInputSchema() map[string]interface{} {
 		{
 			Name:        "version",
 			Type:        "string",
-			Description: "UUID version: v1 (time-based), v4 (random), v5 (name-based SHA-1), v7 (time-ordered), validate",
+			Description: "UUID version: v1 (time-based), v3 (name-based MD5), v4 (random), v5 (name-based SHA-1), v6 (reordered time), v7 (time-ordered), v8 (custom), validate",
 			Required:    false,
 		},
 		{
@@ -381,13 +632,25 @@ func (u *UUIDTool) GetInputSchema() map[string]interface{} {
 		{
 			Name:        "namespace",
 			Type:        "string",
-			Description: "Namespace UUID for v5 generation (required for v5)",
+			Description: "Namespace UUID for v3 and v5 generation (required for v3 and v5)",
 			Required:    false,
 		},
 		{
 			Name:        "name",
 			Type:        "string",
-			Description: "Name for v5 generation (required for v5)",
+			Description: "Name for v3 and v5 generation (required for v3 and v5)",
+			Required:    false,
+		},
+		{
+			Name:        "custom",
+			Type:        "string",
+			Description: "Custom bits for v8 generation as 32 hex digits, with or without hyphens; the version and variant bits are overwritten (required for v8)",
+			Required:    false,
+		},
+		{
+			Name:        "timestamp",
+			Type:        "string",
+			Description: "Custom time of v6 and v7 UUIDs, e.g. to backfill historical data, as an RFC 3339 timestamp, a date or relative input such as 'yesterday' (default: now); the UUIDs of a batch still sort in order",
 			Required:    false,
 		},
 		{
@@ -396,6 +659,18 @@ func (u *UUIDTool) GetInputSchema() map[string]interface{} {
 			Description: "UUID string to validate (required for validate)",
 			Required:    false,
 		},
+		{
+			Name:        "seed",
+			Type:        "integer",
+			Description: "Seed for the random bits of v4, v6 and v7 UUIDs; seeded v6 and v7 UUIDs carry the fixed timestamp 2000-01-01T00:00:00Z unless timestamp is given, so the same seed always generates the same UUIDs (default: random)",
+			Required:    false,
+		},
+		{
+			Name:        "secure",
+			Type:        "boolean",
+			Description: "Draw the random bits from crypto/rand even when the server sets a default seed; unseeded UUIDs always do (cannot be combined with seed)",
+			Required:    false,
+		},
 	})
 }
 
@@ -405,13 +680,10 @@ func (u *UUIDTool) GetOutputSchema() map[string]interface{} {
 		"type": "object",
 		"properties": map[string]interface{}{
 			"result": map[string]interface{}{
-				"type":        "array",
+				"type":        []string{"string", "array", "object"},
 				"description": "Array of UUIDs (or single UUID if count=1), or validation result for validate",
 				"items": map[string]interface{}{
-					"oneOf": []map[string]interface{}{
-						{"type": "string"},
-						{"type": "object"},
-					},
+					"type": "string",
 				},
 			},
 		},
@@ -450,6 +722,13 @@ func (u *UUIDTool) ReadResource(uri string) (string, error) {
 				"name":            "Time-based UUID",
 				"description":     "Based on timestamp and MAC address",
 				"characteristics": []string{"time-ordered", "includes MAC address", "predictable"},
+				"decoded":         []string{"timestamp", "clock_sequence", "node"},
+			},
+			{
+				"version":         "v3",
+				"name":            "Name-based UUID (MD5)",
+				"description":     "Generated from namespace and name using MD5; prefer v5 unless matching legacy systems",
+				"characteristics": []string{"deterministic", "requires namespace", "requires name"},
 			},
 			{
 				"version":         "v4",
@@ -463,11 +742,26 @@ func (u *UUIDTool) ReadResource(uri string) (string, error) {
 				"description":     "Generated from namespace and name using SHA-1",
 				"characteristics": []string{"deterministic", "requires namespace", "requires name"},
 			},
+			{
+				"version":         "v6",
+				"name":            "Reordered time UUID",
+				"description":     "The fields of v1 with the timestamp's most significant bits first, and a random clock sequence and node",
+				"characteristics": []string{"time-ordered", "sortable", "includes timestamp", "field-compatible with v1"},
+				"decoded":         []string{"timestamp", "clock_sequence", "node"},
+			},
 			{
 				"version":         "v7",
 				"name":            "Time-ordered UUID",
-				"description":     "Time-ordered with random component",
-				"characteristics": []string{"time-ordered", "sortable", "includes timestamp"},
+				"description":     "Time-ordered with random component; a 12-bit counter after the millisecond timestamp keeps the UUIDs generated within a millisecond, in a batch or across calls, in order",
+				"characteristics": []string{"time-ordered", "sortable", "includes timestamp", "monotonic"},
+				"decoded":         []string{"timestamp"},
+			},
+			{
+				"version":         "v8",
+				"name":            "Custom UUID",
+				"description":     "122 bits laid out by the caller, with only the version and variant fixed",
+				"characteristics": []string{"custom layout", "requires custom bits"},
+				"decoded":         []string{"custom_a", "custom_b", "custom_c"},
 			},
 		}
 		jsonData, err := json.Marshal(versions)
@@ -512,6 +806,11 @@ func (u *UUIDTool) ReadResource(uri string) (string, error) {
 				"example":     "550e8400-e29b-11d4-a716-446655440000",
 				"description": "Time-based UUID with MAC address",
 			},
+			{
+				"version":     "v3",
+				"example":     "9073926b-929f-31c2-abc9-fad77ae3e8eb",
+				"description": "Name-based UUID (example.com in DNS namespace)",
+			},
 			{
 				"version":     "v4",
 				"example":     "f47ac10b-58cc-4372-a567-0e02b2c3d479",
@@ -519,14 +818,24 @@ func (u *UUIDTool) ReadResource(uri string) (string, error) {
 			},
 			{
 				"version":     "v5",
-				"example":     "886313e1-3b8a-5372-9b90-0c9aee199e5d",
+				"example":     "cfbff0d1-9375-5685-968c-48ce8b15ae17",
 				"description": "Name-based UUID (example.com in DNS namespace)",
 			},
+			{
+				"version":     "v6",
+				"example":     "1f1c9459-2a68-61b8-8474-bf246b52fde0",
+				"description": "Reordered time UUID",
+			},
 			{
 				"version":     "v7",
 				"example":     "0188f7f2-8b00-7c65-9c1d-0b0b0b0b0b0b",
 				"description": "Time-ordered UUID",
 			},
+			{
+				"version":     "v8",
+				"example":     "01234567-89ab-8def-8123-456789abcdef",
+				"description": "Custom UUID from the bits 0123456789abcdef0123456789abcdef",
+			},
 		}
 		jsonData, err := json.Marshal(examples)
 		if err != nil {
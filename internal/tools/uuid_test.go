@@ -1,7 +1,9 @@
 package tools
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -657,3 +659,253 @@ func TestUUIDToolResources(t *testing.T) {
 		t.Error("ReadResource with unknown URI should return error")
 	}
 }
+
+func TestUUIDToolNewVersions(t *testing.T) {
+	tool := NewUUIDTool()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		version uuid.Version
+		want    string
+	}{
+		{"v3 example.com in DNS namespace", map[string]interface{}{"version": "v3", "namespace": uuid.NameSpaceDNS.String(), "name": "example.com"}, 3, "9073926b-929f-31c2-abc9-fad77ae3e8eb"},
+		{"v6", map[string]interface{}{"version": "v6"}, 6, ""},
+		{"v8 from custom bits", map[string]interface{}{"version": "v8", "custom": "0123456789abcdef0123456789abcdef"}, 8, "01234567-89ab-8def-8123-456789abcdef"},
+		{"v8 overwrites version and variant", map[string]interface{}{"version": "v8", "custom": "ffffffff-ffff-ffff-ffff-ffffffffffff"}, 8, "ffffffff-ffff-8fff-bfff-ffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tool.ValidateParams(tt.params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			id, err := uuid.Parse(result.(string))
+			if err != nil {
+				t.Fatalf("Expected valid UUID, got: %v, error: %v", result, err)
+			}
+			if id.Version() != tt.version || id.Variant() != uuid.RFC4122 {
+				t.Errorf("Expected version %d with the RFC 9562 variant, got version %d and %s", tt.version, id.Version(), id.Variant())
+			}
+			if tt.want != "" && id.String() != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, id)
+			}
+		})
+	}
+}
+
+func TestUUIDToolV6Ordering(t *testing.T) {
+	tool := NewUUIDTool()
+	before := time.Now().Truncate(time.Microsecond)
+
+	result, err := tool.Execute(map[string]interface{}{"version": "v6", "count": float64(100)})
+	if err != nil {
+		t.Fatalf("v6 generation failed: %v", err)
+	}
+	ids := result.([]string)
+	for i, id := range ids {
+		if i > 0 && id <= ids[i-1] {
+			t.Fatalf("Expected v6 UUIDs in increasing order, got %s after %s", id, ids[i-1])
+		}
+		decoded, _ := tool.Execute(map[string]interface{}{"version": "validate", "input": id})
+		timestamp, err := time.Parse(time.RFC3339Nano, decoded.(map[string]interface{})["timestamp"].(string))
+		if err != nil || timestamp.Before(before) || timestamp.After(time.Now()) {
+			t.Errorf("Expected the timestamp of %s to be the time of generation, got %v", id, decoded)
+		}
+	}
+}
+
+func TestUUIDToolValidateDecodesFields(t *testing.T) {
+	tool := NewUUIDTool()
+
+	// Test vectors from RFC 9562, Appendix A
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]interface{}
+	}{
+		{"v1", "C232AB00-9414-11EC-B3C8-9F6BDECED846", map[string]interface{}{
+			"version": 1, "timestamp": "2022-02-22T19:22:22Z", "clock_sequence": 0x33c8, "node": "9f:6b:de:ce:d8:46", "mac_address": "9f:6b:de:ce:d8:46",
+		}},
+		{"v6", "1EC9414C-232A-6B00-B3C8-9F6BDECED846", map[string]interface{}{
+			"version": 6, "timestamp": "2022-02-22T19:22:22Z", "clock_sequence": 0x33c8, "node": "9f:6b:de:ce:d8:46",
+		}},
+		{"v7", "017F22E2-79B0-7CC3-98C4-DC0C0C07398F", map[string]interface{}{
+			"version": 7, "timestamp": "2022-02-22T19:22:22Z",
+		}},
+		{"v8", "2489E9AD-2EE2-8E00-8EC9-32D5F69181C0", map[string]interface{}{
+			"version": 8, "custom_a": "2489e9ad2ee2", "custom_b": "e00", "custom_c": "0ec932d5f69181c0",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(map[string]interface{}{"version": "validate", "input": tt.input})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			resultMap := result.(map[string]interface{})
+			for key, want := range tt.want {
+				if resultMap[key] != want {
+					t.Errorf("Expected %s = %v, got %v", key, want, resultMap[key])
+				}
+			}
+		})
+	}
+}
+
+func TestUUIDToolNewVersionErrors(t *testing.T) {
+	tool := NewUUIDTool()
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr string
+	}{
+		{"v3 without name", map[string]interface{}{"version": "v3", "namespace": uuid.NameSpaceDNS.String()}, "name parameter is required for UUID v3"},
+		{"v3 with invalid namespace", map[string]interface{}{"version": "v3", "namespace": "invalid", "name": "example"}, "invalid namespace UUID"},
+		{"v8 without custom bits", map[string]interface{}{"version": "v8"}, "custom parameter is required"},
+		{"v8 with short custom bits", map[string]interface{}{"version": "v8", "custom": "0123456789abcdef"}, "invalid custom bits"},
+		{"v8 with non-hex custom bits", map[string]interface{}{"version": "v8", "custom": "0123456789abcdef0123456789abcdeg"}, "invalid custom bits"},
+		{"v8 with count", map[string]interface{}{"version": "v8", "custom": "0123456789abcdef0123456789abcdef", "count": float64(2)}, "count must be 1"},
+		{"v7 before 1970", map[string]interface{}{"version": "v7", "timestamp": "1969-12-31T23:59:59Z"}, "between 1970-01-01 and the year 10889"},
+		{"v6 before 1582", map[string]interface{}{"version": "v6", "timestamp": "1500-01-01T00:00:00Z"}, "between 1582-10-15 and 5236-03-31"},
+		{"v4 with timestamp", map[string]interface{}{"version": "v4", "timestamp": "2020-01-01T00:00:00Z"}, "only supported by UUID v6 and v7"},
+		{"invalid timestamp", map[string]interface{}{"version": "v7", "timestamp": "not a time"}, "invalid timestamp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tool.ValidateParams(tt.params)
+			if err == nil {
+				_, err = tool.Execute(tt.params)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestV7GeneratorCounter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Random bits of all ones start the counter at its highest start, 0x7ff
+	random := strings.NewReader(strings.Repeat("\xff", 10*5000))
+
+	tests := []struct {
+		name    string
+		clock   []time.Time
+		millis  []int64
+		counter []uint16
+	}{
+		{"new millisecond starts below 2048", []time.Time{now}, []int64{now.UnixMilli()}, []uint16{0x7ff}},
+		{"same millisecond counts up", []time.Time{now, now, now}, []int64{now.UnixMilli(), now.UnixMilli(), now.UnixMilli()}, []uint16{0x7ff, 0x800, 0x801}},
+		{"clock going back counts up", []time.Time{now, now.Add(-time.Second)}, []int64{now.UnixMilli(), now.UnixMilli()}, []uint16{0x7ff, 0x800}},
+		{"next millisecond restarts", []time.Time{now, now.Add(time.Millisecond)}, []int64{now.UnixMilli(), now.UnixMilli() + 1}, []uint16{0x7ff, 0x7ff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newV7Generator()
+			for i, clock := range tt.clock {
+				id, err := g.next(random, clock)
+				if err != nil {
+					t.Fatalf("next() error = %v", err)
+				}
+				millis := int64(id[0])<<40 | int64(id[1])<<32 | int64(id[2])<<24 | int64(id[3])<<16 | int64(id[4])<<8 | int64(id[5])
+				counter := uint16(id[6]&0x0f)<<8 | uint16(id[7])
+				if id.Version() != 7 || id.Variant() != uuid.RFC4122 || millis != tt.millis[i] || counter != tt.counter[i] {
+					t.Errorf("Expected UUID %d at %d with counter %#x, got %s", i, tt.millis[i], tt.counter[i], id)
+				}
+			}
+		})
+	}
+
+	t.Run("counter overflow borrows the next millisecond", func(t *testing.T) {
+		g := newV7Generator()
+		var last uuid.UUID
+		for i := range 0x1000 - 0x7ff + 1 {
+			id, err := g.next(random, now)
+			if err != nil {
+				t.Fatalf("next() error = %v", err)
+			}
+			if i > 0 && id.String() <= last.String() {
+				t.Fatalf("Expected %s after %s", id, last)
+			}
+			last = id
+		}
+		if g.millis != now.UnixMilli()+1 || g.counter != 0x7ff {
+			t.Errorf("Expected the counter to restart in the next millisecond, got %d and %#x", g.millis, g.counter)
+		}
+	})
+}
+
+func TestUUIDToolV7Ordering(t *testing.T) {
+	tool := NewUUIDTool()
+	var ids []string
+	for range 3 {
+		result, err := tool.Execute(map[string]interface{}{"version": "v7", "count": float64(1000)})
+		if err != nil {
+			t.Fatalf("v7 generation failed: %v", err)
+		}
+		ids = append(ids, result.([]string)...)
+
+		// Backfilled UUIDs do not hold back the UUIDs generated after them
+		if _, err := tool.Execute(map[string]interface{}{"version": "v7", "timestamp": "2100-01-01T00:00:00Z"}); err != nil {
+			t.Fatalf("v7 generation failed: %v", err)
+		}
+	}
+
+	// UUIDs sort in generation order within a batch and across calls
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("Expected v7 UUIDs in increasing order, got %s after %s", ids[i], ids[i-1])
+		}
+	}
+	decoded, _ := tool.Execute(map[string]interface{}{"version": "validate", "input": ids[len(ids)-1]})
+	if timestamp := decoded.(map[string]interface{})["timestamp"].(string); !strings.HasPrefix(timestamp, time.Now().UTC().Format("2006-")) {
+		t.Errorf("Expected the last UUID to carry the current time, got %s", timestamp)
+	}
+}
+
+func TestUUIDToolCustomTimestamp(t *testing.T) {
+	tool := NewUUIDTool()
+	tests := []struct {
+		name      string
+		params    map[string]interface{}
+		timestamp string
+	}{
+		{"v7", map[string]interface{}{"version": "v7", "timestamp": "2020-02-29T12:34:56.789Z"}, "2020-02-29T12:34:56.789Z"},
+		{"v7 date", map[string]interface{}{"version": "v7", "timestamp": "2019-06-01"}, "2019-06-01T00:00:00Z"},
+		{"seeded v7", map[string]interface{}{"version": "v7", "timestamp": "2020-02-29T12:34:56.789Z", "seed": 42.0}, "2020-02-29T12:34:56.789Z"},
+		{"v6", map[string]interface{}{"version": "v6", "timestamp": "1999-12-31T23:59:59.9999999Z"}, "1999-12-31T23:59:59.9999999Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params["count"] = float64(100)
+			if err := tool.ValidateParams(tt.params); err != nil {
+				t.Fatalf("ValidateParams() error = %v", err)
+			}
+			result, err := tool.Execute(tt.params)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			ids := result.([]string)
+			for i, id := range ids {
+				if i > 0 && id <= ids[i-1] {
+					t.Fatalf("Expected UUIDs in increasing order, got %s after %s", id, ids[i-1])
+				}
+			}
+			// The first UUID carries the timestamp; v6 UUIDs of a batch then move on by a tick each
+			decoded, _ := tool.Execute(map[string]interface{}{"version": "validate", "input": ids[0]})
+			if timestamp := decoded.(map[string]interface{})["timestamp"]; timestamp != tt.timestamp {
+				t.Errorf("Expected timestamp %s, got %v", tt.timestamp, timestamp)
+			}
+		})
+	}
+}
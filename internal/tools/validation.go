@@ -0,0 +1,337 @@
+// Package tools provides input validation for mcpipboy tools
+package tools
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidationError describes a parameter that does not satisfy a tool's input schema
+type ValidationError struct {
+	// Path locates the offending value, e.g. "count" or "inputs[2]"
+	Path string
+	// Message explains what is wrong with the value
+	Message string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidateInput validates parameters for a tool. The parameters are first checked
+// against the tool's input schema and then passed to the tool's own ValidateParams,
+// so every caller (MCP, CLI or registry) goes through the same validation layer.
+func ValidateInput(tool Tool, params map[string]interface{}) error {
+	if err := ValidateSchema(tool.GetInputSchema(), params); err != nil {
+		return err
+	}
+	return tool.ValidateParams(params)
+}
+
+// ValidateSchema validates parameters against a JSON schema as produced by
+// GetInputSchema. It supports the subset of JSON Schema used by the tools:
+// type, enum, minimum, maximum, minLength, maxLength, required, properties,
+// additionalProperties and items. Object schemas that declare properties are
+// closed unless additionalProperties explicitly allows more.
+func ValidateSchema(schema map[string]interface{}, params map[string]interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	return validateValue("", schema, params)
+}
+
+// validateValue validates a single value against a schema, reporting errors at the given path
+func validateValue(path string, schema map[string]interface{}, value interface{}) error {
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, typ := range types {
+			if matchesType(typ, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be %s, got %s", joinTypes(types), describeValue(value))}
+		}
+	}
+
+	if enum := schemaList(schema["enum"]); len(enum) > 0 {
+		found := false
+		for _, allowed := range enum {
+			if valuesEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be one of %s, got %s", formatList(enum), describeValue(value))}
+		}
+	}
+
+	if number, ok := toFloat(value); ok {
+		if minimum, ok := toFloat(schema["minimum"]); ok && number < minimum {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be at least %v, got %v", minimum, number)}
+		}
+		if maximum, ok := toFloat(schema["maximum"]); ok && number > maximum {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be at most %v, got %v", maximum, number)}
+		}
+	}
+
+	if str, ok := value.(string); ok {
+		length := len([]rune(str))
+		if minLength, ok := toFloat(schema["minLength"]); ok && float64(length) < minLength {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be at least %v characters long", minLength)}
+		}
+		if maxLength, ok := toFloat(schema["maxLength"]); ok && float64(length) > maxLength {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("must be at most %v characters long", maxLength)}
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return validateObject(path, schema, v)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := validateValue(fmt.Sprintf("%s[%d]", path, i), items, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateObject validates the properties of an object value
+func validateObject(path string, schema map[string]interface{}, object map[string]interface{}) error {
+	for _, name := range schemaStrings(schema["required"]) {
+		if _, ok := object[name]; !ok {
+			return &ValidationError{Path: joinPath(path, name), Message: "is required"}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	// Visit properties in a stable order so error messages are deterministic
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := joinPath(path, name)
+		if propertySchema, ok := properties[name].(map[string]interface{}); ok {
+			if err := validateValue(propertyPath, propertySchema, object[name]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return &ValidationError{Path: propertyPath, Message: "unknown parameter"}
+			}
+		case map[string]interface{}:
+			if err := validateValue(propertyPath, additional, object[name]); err != nil {
+				return err
+			}
+		default:
+			if properties != nil {
+				return &ValidationError{Path: propertyPath, Message: "unknown parameter"}
+			}
+		}
+	}
+
+	return nil
+}
+
+// joinPath appends a property name to a path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// schemaTypes returns the type or types declared by a schema
+func schemaTypes(value interface{}) []string {
+	if typ, ok := value.(string); ok {
+		return []string{typ}
+	}
+	return schemaStrings(value)
+}
+
+// schemaStrings converts a schema keyword value ([]string or []interface{}) to a string slice
+func schemaStrings(value interface{}) []string {
+	var result []string
+	for _, item := range schemaList(value) {
+		if str, ok := item.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+// schemaList converts a schema keyword holding any kind of slice to []interface{}
+func schemaList(value interface{}) []interface{} {
+	if value == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil
+	}
+	result := make([]interface{}, rv.Len())
+	for i := range result {
+		result[i] = rv.Index(i).Interface()
+	}
+	return result
+}
+
+// matchesType reports whether a value is an instance of the given JSON Schema type
+func matchesType(typ string, value interface{}) bool {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		_, ok := Int64Param(value)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+// toFloat converts any Go numeric value to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// maxExactInteger is the largest integer up to which every float64 is exact;
+// floats beyond it may already have lost digits
+const maxExactInteger = 1 << 53
+
+// Int64Param converts a numeric parameter to an int64. Parameters decoded from
+// JSON arrive as float64, while CLI callers pass Go ints, so both are accepted
+// as long as the value is a whole number. Integers are taken as they are, while
+// floats beyond ±2^53 are rejected rather than rounded.
+func Int64Param(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	number, ok := toFloat(value)
+	if !ok || number != math.Trunc(number) || math.Abs(number) > maxExactInteger {
+		return 0, false
+	}
+	return int64(number), true
+}
+
+// IntParam converts a numeric parameter to an int like Int64Param, rejecting
+// values that do not fit in an int
+func IntParam(value interface{}) (int, bool) {
+	number, ok := Int64Param(value)
+	if !ok || int64(int(number)) != number {
+		return 0, false
+	}
+	return int(number), true
+}
+
+// valuesEqual compares two values, treating all numeric types as equal by value
+func valuesEqual(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return a == b
+}
+
+// joinTypes formats a list of types for an error message, e.g. "a string or an array"
+func joinTypes(types []string) string {
+	articles := make([]string, len(types))
+	for i, typ := range types {
+		if strings.IndexAny(typ[:1], "aeiou") == 0 {
+			articles[i] = "an " + typ
+		} else {
+			articles[i] = "a " + typ
+		}
+	}
+	return strings.Join(articles, " or ")
+}
+
+// formatList formats a list of allowed values for an error message
+func formatList(values []interface{}) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		if str, ok := value.(string); ok {
+			formatted[i] = fmt.Sprintf("%q", str)
+		} else {
+			formatted[i] = fmt.Sprintf("%v", value)
+		}
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+// describeValue describes a value for an error message
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return fmt.Sprintf("boolean %v", v)
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if number, ok := toFloat(value); ok {
+		return fmt.Sprintf("number %v", number)
+	}
+	return fmt.Sprintf("%T", value)
+}
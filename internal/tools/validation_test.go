@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"operation": map[string]interface{}{
+				"type": "string",
+				"enum": []string{"validate", "generate"},
+			},
+			"count": map[string]interface{}{
+				"type":    "integer",
+				"minimum": 1,
+				"maximum": 100,
+			},
+			"ratio": map[string]interface{}{
+				"type": "number",
+			},
+			"code": map[string]interface{}{
+				"type":      "string",
+				"minLength": 2,
+				"maxLength": 2,
+			},
+			"inputs": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+			"options": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"strict": map[string]interface{}{"type": "boolean"},
+				},
+			},
+		},
+		"required":             []string{"operation"},
+		"additionalProperties": false,
+	}
+
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:   "valid parameters",
+			params: map[string]interface{}{"operation": "generate", "count": 5},
+		},
+		{
+			name:   "integer decoded from JSON",
+			params: map[string]interface{}{"operation": "generate", "count": float64(5)},
+		},
+		{
+			name:   "integer accepted as number",
+			params: map[string]interface{}{"operation": "generate", "ratio": 2},
+		},
+		{
+			name:   "valid nested values",
+			params: map[string]interface{}{"operation": "validate", "inputs": []interface{}{"a", "b"}, "options": map[string]interface{}{"strict": true}},
+		},
+		{
+			name:        "missing required parameter",
+			params:      map[string]interface{}{},
+			expectedErr: "operation: is required",
+		},
+		{
+			name:        "wrong type",
+			params:      map[string]interface{}{"operation": 42},
+			expectedErr: "operation: must be a string, got number 42",
+		},
+		{
+			name:        "value not in enum",
+			params:      map[string]interface{}{"operation": "delete"},
+			expectedErr: `operation: must be one of ["validate", "generate"], got string "delete"`,
+		},
+		{
+			name:        "fractional integer",
+			params:      map[string]interface{}{"operation": "generate", "count": 2.5},
+			expectedErr: "count: must be an integer, got number 2.5",
+		},
+		{
+			name:        "below minimum",
+			params:      map[string]interface{}{"operation": "generate", "count": 0},
+			expectedErr: "count: must be at least 1, got 0",
+		},
+		{
+			name:        "above maximum",
+			params:      map[string]interface{}{"operation": "generate", "count": float64(101)},
+			expectedErr: "count: must be at most 100, got 101",
+		},
+		{
+			name:        "string too short",
+			params:      map[string]interface{}{"operation": "generate", "code": "G"},
+			expectedErr: "code: must be at least 2 characters long",
+		},
+		{
+			name:        "string too long",
+			params:      map[string]interface{}{"operation": "generate", "code": "GBR"},
+			expectedErr: "code: must be at most 2 characters long",
+		},
+		{
+			name:        "unknown parameter",
+			params:      map[string]interface{}{"operation": "generate", "bogus": true},
+			expectedErr: "bogus: unknown parameter",
+		},
+		{
+			name:        "invalid array item",
+			params:      map[string]interface{}{"operation": "validate", "inputs": []interface{}{"a", 7}},
+			expectedErr: "inputs[1]: must be a string, got number 7",
+		},
+		{
+			name:        "invalid nested property",
+			params:      map[string]interface{}{"operation": "validate", "options": map[string]interface{}{"strict": "yes"}},
+			expectedErr: `options.strict: must be a boolean, got string "yes"`,
+		},
+		{
+			name:        "unknown nested property",
+			params:      map[string]interface{}{"operation": "validate", "options": map[string]interface{}{"lenient": true}},
+			expectedErr: "options.lenient: unknown parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchema(schema, tt.params)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSchema() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateSchema() expected error %q, got nil", tt.expectedErr)
+			}
+			if err.Error() != tt.expectedErr {
+				t.Errorf("ValidateSchema() error = %q, want %q", err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaNilSchema(t *testing.T) {
+	if err := ValidateSchema(nil, map[string]interface{}{"anything": 1}); err != nil {
+		t.Errorf("ValidateSchema() with nil schema should accept anything, got %v", err)
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		tool        Tool
+		params      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:   "valid IMO generation with JSON count",
+			tool:   NewIMOTool(),
+			params: map[string]interface{}{"operation": "generate", "count": float64(3)},
+		},
+		{
+			name:        "fractional IMO count",
+			tool:        NewIMOTool(),
+			params:      map[string]interface{}{"operation": "generate", "count": 2.5},
+			expectedErr: "count: must be an integer",
+		},
+		{
+			name:        "credit card count above schema maximum",
+			tool:        NewCreditCardTool(),
+			params:      map[string]interface{}{"operation": "generate", "count": float64(101)},
+			expectedErr: "count: must be at most 100",
+		},
+		{
+			name:        "unknown IBAN parameter",
+			tool:        NewIBANTool(),
+			params:      map[string]interface{}{"operation": "validate", "input": "GB82WEST12345698765432", "country": "GB"},
+			expectedErr: "country: unknown parameter",
+		},
+		{
+			name:        "tool rules still apply",
+			tool:        NewIBANTool(),
+			params:      map[string]interface{}{"operation": "validate"},
+			expectedErr: "input parameter is required",
+		},
+		{
+			name: "tool ValidateParams runs after schema validation",
+			tool: &MockTool{
+				name: "mock",
+				validateFunc: func(params map[string]interface{}) error {
+					return fmt.Errorf("rejected by tool")
+				},
+			},
+			params:      map[string]interface{}{},
+			expectedErr: "rejected by tool",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInput(tt.tool, tt.params)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("ValidateInput() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("ValidateInput() error = %v, want error containing %q", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestIntParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected int
+		ok       bool
+	}{
+		{"int", 5, 5, true},
+		{"int64", int64(7), 7, true},
+		{"whole float64", float64(3), 3, true},
+		{"fractional float64", 2.5, 0, false},
+		{"string", "5", 0, false},
+		{"nil", nil, 0, false},
+		{"largest exact float64", float64(1 << 53), 1 << 53, true},
+		{"float64 beyond 2^53", float64(1<<53 + 2), 0, false},
+		{"negative float64 beyond 2^53", -float64(1<<53 + 2), 0, false},
+		{"huge float64", 1e300, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := IntParam(tt.value)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("IntParam(%v) = %d, %v, want %d, %v", tt.value, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestInt64Param(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected int64
+		ok       bool
+	}{
+		{"int64 beyond 2^53 keeps every digit", int64(9007199254740993), 9007199254740993, true},
+		{"largest int64", int64(math.MaxInt64), math.MaxInt64, true},
+		{"int", 42, 42, true},
+		{"whole float64", float64(-3), -3, true},
+		{"float64 beyond 2^53", float64(9007199254740994), 0, false},
+		{"fractional float64", 0.5, 0, false},
+		{"infinity", math.Inf(1), 0, false},
+		{"string", "42", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Int64Param(tt.value)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("Int64Param(%v) = %d, %v, want %d, %v", tt.value, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -25,6 +25,17 @@ func (v *VersionTool) Description() string {
 	return "Returns the current version of mcpipboy"
 }
 
+// Annotations returns the tool's behavioural hints for clients
+func (v *VersionTool) Annotations() ToolAnnotations {
+	return ToolAnnotations{
+		Title:      "Version",
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  false,
+		Tags:       []string{"utility"},
+	}
+}
+
 // Execute runs the version tool
 func (v *VersionTool) Execute(params map[string]interface{}) (interface{}, error) {
 	return version.Version(), nil